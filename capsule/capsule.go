@@ -98,7 +98,7 @@ func (manager *Manager) AddConnection(name string, driver string, datasource str
 	config := dbal.Config{
 		Name:     name,
 		Driver:   driver,
-		DSN:      datasource,
+		DSN:      manager.dsn(driver, datasource),
 		ReadOnly: readonly,
 	}
 
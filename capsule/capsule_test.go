@@ -1,10 +1,15 @@
 package capsule
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
 	"github.com/yaoapp/xun/unit"
 )
 
@@ -71,3 +76,272 @@ func TestPing(t *testing.T) {
 	err = conn.Ping(1 * time.Second)
 	assert.Equal(t, "context deadline exceeded", err.Error())
 }
+
+func TestRetry(t *testing.T) {
+	unit.SetLogger()
+	manager := New()
+
+	// no retry policy
+	calls := 0
+	err := manager.Retry(func() error {
+		calls++
+		return dbal.NewError(dbal.ErrDeadlock, errors.New("deadlock"))
+	})
+	assert.True(t, errors.Is(err, dbal.ErrDeadlock))
+	assert.Equal(t, 1, calls)
+
+	retries := []int{}
+	manager.SetRetry(dbal.RetryPolicy{
+		Times:   3,
+		Backoff: time.Millisecond,
+		OnRetry: func(attempt int, err error, wait time.Duration) {
+			retries = append(retries, attempt)
+			assert.True(t, wait >= time.Millisecond*time.Duration(1<<(attempt-1))/2)
+		},
+	})
+
+	// success on the third call
+	calls = 0
+	err = manager.Retry(func() error {
+		calls++
+		if calls < 3 {
+			return dbal.NewError(dbal.ErrSerialization, errors.New("serialization failure"))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, retries)
+
+	// the retries are exhausted
+	calls = 0
+	err = manager.Retry(func() error {
+		calls++
+		return dbal.NewError(dbal.ErrDeadlock, errors.New("deadlock"))
+	})
+	assert.True(t, errors.Is(err, dbal.ErrDeadlock))
+	assert.Equal(t, 4, calls)
+
+	// the error is not retryable
+	calls = 0
+	err = manager.Retry(func() error {
+		calls++
+		return dbal.NewError(dbal.ErrUniqueViolation, errors.New("duplicate"))
+	})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation))
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransaction(t *testing.T) {
+	unit.SetLogger()
+	manager, err := Add("test", unit.Driver(), unit.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	manager.Schema().DropTableIfExists("table_test_capsule_transaction")
+	manager.Schema().MustCreateTable("table_test_capsule_transaction", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name")
+	})
+	defer manager.Schema().DropTableIfExists("table_test_capsule_transaction")
+
+	qb := manager.Query()
+	err = manager.Transaction(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(tx.Rebind("INSERT INTO table_test_capsule_transaction (name) VALUES (?)"), "committed")
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), qb.Table("table_test_capsule_transaction").MustCount())
+
+	err = manager.Transaction(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(tx.Rebind("INSERT INTO table_test_capsule_transaction (name) VALUES (?)"), "rolled back")
+		if err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	assert.Equal(t, "rollback", err.Error())
+	assert.Equal(t, int64(1), qb.Table("table_test_capsule_transaction").MustCount())
+}
+
+func TestCopyTable(t *testing.T) {
+	unit.SetLogger()
+	manager, err := Add("test", unit.Driver(), unit.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	sch := manager.Schema()
+	sch.DropTableIfExists("table_test_capsule_copy")
+	sch.DropTableIfExists("table_test_capsule_copy_to")
+	sch.MustCreateTable("table_test_capsule_copy", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index()
+		table.Integer("score").SetDefault(0)
+		table.String("status", 20).SetDefault("unknown")
+	})
+	defer sch.DropTableIfExists("table_test_capsule_copy")
+	defer sch.DropTableIfExists("table_test_capsule_copy_to")
+
+	qb := manager.Query()
+	for i := 1; i <= 10; i++ {
+		qb.Table("table_test_capsule_copy").MustInsert(map[string]interface{}{"name": fmt.Sprintf("user-%d", i), "score": i})
+	}
+	qb.Table("table_test_capsule_copy").Where("id", 4).MustDelete()
+
+	progress := []int64{}
+	copied, err := manager.CopyTable("test", "test", "table_test_capsule_copy", CopyOption{
+		To:        "table_test_capsule_copy_to",
+		ChunkSize: 4,
+		BatchSize: 3,
+		Progress: func(table string, copied int64, total int64) {
+			assert.Equal(t, int64(9), total)
+			progress = append(progress, copied)
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), copied)
+	assert.Equal(t, []int64{3, 4, 7, 8, 9}, progress)
+
+	table := sch.MustGetTable("table_test_capsule_copy_to")
+	assert.True(t, table.HasColumn("id", "name", "score"))
+	assert.True(t, table.HasIndex("name_index"))
+
+	row := qb.Table("table_test_capsule_copy_to").Where("id", 10).MustFirst()
+	assert.Equal(t, "user-10", row.Get("name"))
+	assert.False(t, qb.Table("table_test_capsule_copy_to").Where("id", 4).MustExists())
+
+	// the new rows continue the ids
+	id := qb.Table("table_test_capsule_copy_to").MustInsertGetID(map[string]interface{}{"name": "new"})
+	assert.Equal(t, int64(11), id)
+	assert.Equal(t, "unknown", qb.Table("table_test_capsule_copy_to").Where("id", 11).MustValue("status"))
+
+	// the destination table exists
+	_, err = manager.CopyTable("test", "test", "table_test_capsule_copy", CopyOption{To: "table_test_capsule_copy_to"})
+	assert.NotNil(t, err)
+
+	copied = manager.MustCopyTable("test", "test", "table_test_capsule_copy", CopyOption{To: "table_test_capsule_copy_to", Drop: true})
+	assert.Equal(t, int64(9), copied)
+
+	_, err = manager.CopyTable("test", "unknown", "table_test_capsule_copy")
+	assert.NotNil(t, err)
+
+	// the destination table is dropped when the copy fails
+	_, err = manager.CopyTable("test", "test", "table_test_capsule_copy", CopyOption{To: "table_test_capsule_copy_to", Drop: true, Key: "missing"})
+	assert.NotNil(t, err)
+	assert.False(t, sch.MustHasTable("table_test_capsule_copy_to"))
+}
+
+func TestCopyTableNotUniqueKey(t *testing.T) {
+	unit.SetLogger()
+	manager, err := Add("test", unit.Driver(), unit.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	sch := manager.Schema()
+	sch.DropTableIfExists("table_test_capsule_copy")
+	sch.DropTableIfExists("table_test_capsule_copy_to")
+	sch.MustCreateTable("table_test_capsule_copy", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+		table.Integer("score").SetDefault(0)
+		table.Integer("rank").Null()
+	})
+	defer sch.DropTableIfExists("table_test_capsule_copy")
+	defer sch.DropTableIfExists("table_test_capsule_copy_to")
+
+	// the chunk boundaries fall inside the rows sharing the same score
+	qb := manager.Query()
+	for i := 1; i <= 10; i++ {
+		qb.Table("table_test_capsule_copy").MustInsert(map[string]interface{}{"name": fmt.Sprintf("user-%d", i), "score": i % 3})
+	}
+
+	copied, err := manager.CopyTable("test", "test", "table_test_capsule_copy", CopyOption{
+		To:        "table_test_capsule_copy_to",
+		Key:       "score",
+		ChunkSize: 2,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), copied)
+	assert.Equal(t, int64(10), qb.Table("table_test_capsule_copy_to").MustCount())
+	assert.Equal(t, int64(10), qb.Table("table_test_capsule_copy_to").Distinct().MustCount("id"))
+
+	// the rows with the null key can not be paged
+	_, err = manager.CopyTable("test", "test", "table_test_capsule_copy", CopyOption{
+		To:   "table_test_capsule_copy_to",
+		Key:  "rank",
+		Drop: true,
+	})
+	assert.NotNil(t, err)
+}
+
+func TestCopyTableOtherDriver(t *testing.T) {
+	unit.SetLogger()
+	manager, err := Add("test", unit.Driver(), unit.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	// copy to a SQLite database, the copy is between two drivers unless the unit driver is SQLite
+	_, err = manager.Add("test_copy", "sqlite3", "file:/tmp/xun_copy.db", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the manager picks a primary connection randomly, use the connections explicitly
+	src, err := manager.connection("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := manager.connection("test_copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sch := manager.schemaOf(&src.DB, src.Config)
+	sch.DropTableIfExists("table_test_capsule_copy")
+	sch.MustCreateTable("table_test_capsule_copy", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Unique()
+		table.Text("bio").Null()
+		table.Decimal("amount", 10, 2).SetDefault(0)
+		table.Boolean("active").SetDefault(false)
+		table.Timestamp("created_at").Null()
+	})
+	defer sch.DropTableIfExists("table_test_capsule_copy")
+
+	qb := manager.queryOf(&src.DB, src.Config)
+	for i := 1; i <= 5; i++ {
+		qb.Table("table_test_capsule_copy").MustInsert(map[string]interface{}{
+			"name":       fmt.Sprintf("user-%d", i),
+			"bio":        fmt.Sprintf("the bio of user-%d", i),
+			"amount":     float64(i) + 0.25,
+			"active":     i%2 == 0,
+			"created_at": "2021-06-01 10:00:00",
+		})
+	}
+
+	copied, err := manager.CopyTable("test", "test_copy", "table_test_capsule_copy", CopyOption{ChunkSize: 2, Drop: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), copied)
+
+	defer manager.schemaOf(&dst.DB, dst.Config).DropTableIfExists("table_test_capsule_copy")
+	dstQuery := manager.queryOf(&dst.DB, dst.Config)
+	assert.Equal(t, int64(5), dstQuery.Table("table_test_capsule_copy").MustCount())
+	row := dstQuery.Table("table_test_capsule_copy").Where("name", "user-3").MustFirst()
+	assert.Equal(t, "the bio of user-3", row.Get("bio"))
+	assert.Equal(t, 3.25, row.GetFloat("amount", 2))
+
+	// and copy it back
+	copied, err = manager.CopyTable("test_copy", "test", "table_test_capsule_copy", CopyOption{To: "table_test_capsule_copy_back", Drop: true})
+	defer sch.DropTableIfExists("table_test_capsule_copy_back")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), copied)
+	assert.Equal(t, "user-5", qb.Table("table_test_capsule_copy_back").Where("id", 5).MustValue("name"))
+}
@@ -0,0 +1,332 @@
+package capsule
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/utils"
+)
+
+// identityInserter the grammar allows inserting the explicit values into the identity columns (Dameng)
+type identityInserter interface {
+	SetIdentityInsert(tableName string, enable bool) error
+}
+
+// sequenceResetter the grammar should reset the sequences after inserting the explicit ids (Postgres)
+type sequenceResetter interface {
+	ResetSequence(tableName string, column string) error
+}
+
+// CopyTable copy the structure and the rows of the table from the src connection to the dst connection,
+// the column types are translated by the destination grammar. The rows are read in keyset chunks ordered
+// by the key column, and by the primary key too when the key is not unique, then inserted in batches,
+// the sequences or the identities are kept in sync.
+// Return the number of the rows copied.
+//
+// The rows are not copied in a transaction, the destination table keeps the rows inserted before a failure.
+// The destination table is dropped on failure if the Drop option is true, so the copy can be run again.
+//
+//	copied, err := manager.CopyTable("mysql", "postgres", "users", capsule.CopyOption{ChunkSize: 5000})
+func (manager *Manager) CopyTable(src string, dst string, table string, option ...CopyOption) (int64, error) {
+	opt := CopyOption{}
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.To == "" {
+		opt.To = table
+	}
+	if opt.ChunkSize <= 0 {
+		opt.ChunkSize = 1000
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = 200
+	}
+
+	srcConn, err := manager.connection(src)
+	if err != nil {
+		return 0, err
+	}
+
+	dstConn, err := manager.connection(dst)
+	if err != nil {
+		return 0, err
+	}
+
+	// the structure
+	srcSchema := manager.schemaOf(&srcConn.DB, srcConn.Config)
+	doc, err := srcSchema.Export(table)
+	if err != nil {
+		return 0, err
+	}
+	tableDoc := doc.Tables[0]
+	tableDoc.Name = opt.To
+
+	keys, err := copyKeys(tableDoc, opt.Key)
+	if err != nil {
+		return 0, err
+	}
+
+	// the identity insert is enabled per session, use a dedicated connection
+	dstDB := &dstConn.DB
+	grammar, err := manager.grammarOf(dstDB, dstConn.Config)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := grammar.(identityInserter); ok {
+		dstDB, err = sqlx.Open(dstConn.Config.Driver, dstConn.Config.DSN)
+		if err != nil {
+			return 0, err
+		}
+		defer dstDB.Close()
+		dstDB.SetMaxOpenConns(1)
+		grammar, err = manager.grammarOf(dstDB, dstConn.Config)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	dstSchema := manager.schemaOf(dstDB, dstConn.Config)
+	if opt.Drop {
+		err = dstSchema.DropTableIfExists(opt.To)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = dstSchema.Import(&schema.Document{Version: doc.Version, Tables: []schema.TableDocument{tableDoc}})
+	if err != nil {
+		return 0, err
+	}
+
+	// the rows
+	fullName := fmt.Sprintf("%s%s", manager.option().Prefix, opt.To)
+	if inserter, ok := grammar.(identityInserter); ok && copyHasIdentity(tableDoc) {
+		err = inserter.SetIdentityInsert(fullName, true)
+		if err != nil {
+			return 0, err
+		}
+		defer inserter.SetIdentityInsert(fullName, false)
+	}
+
+	srcQuery := manager.queryOf(&srcConn.DB, srcConn.Config)
+	dstQuery := manager.queryOf(dstDB, dstConn.Config)
+	copied, err := copyRows(srcQuery, dstQuery, table, tableDoc, keys, opt)
+	if err != nil {
+		if opt.Drop {
+			dstSchema.DropTableIfExists(opt.To)
+		}
+		return copied, err
+	}
+
+	if resetter, ok := grammar.(sequenceResetter); ok {
+		for _, column := range tableDoc.Columns {
+			if column.AutoIncrement {
+				err = resetter.ResetSequence(fullName, column.Name)
+				if err != nil {
+					return copied, err
+				}
+			}
+		}
+	}
+
+	log.Info("copy table %s (%s) => %s (%s): %d rows", table, src, opt.To, dst, copied)
+	return copied, nil
+}
+
+// MustCopyTable copy the structure and the rows of the table from the src connection to the dst connection.
+func (manager *Manager) MustCopyTable(src string, dst string, table string, option ...CopyOption) int64 {
+	copied, err := manager.CopyTable(src, dst, table, option...)
+	utils.PanicIF(err)
+	return copied
+}
+
+// copyRows read the rows in keyset chunks and insert them in batches
+func copyRows(src query.Query, dst query.Query, table string, doc schema.TableDocument, keys []string, opt CopyOption) (int64, error) {
+	total, err := src.New().Table(table).Count()
+	if err != nil {
+		return 0, err
+	}
+
+	columns := []interface{}{}
+	binaries := map[string]bool{}
+	for _, column := range doc.Columns {
+		columns = append(columns, column.Name)
+		binaries[column.Name] = column.Type == "binary"
+	}
+
+	var copied int64 = 0
+	var last xun.R = nil
+	for {
+		qb := src.New().Table(table).Select(columns...)
+		if last != nil {
+			qb.Where(copyAfter(keys, last))
+		}
+		for _, key := range keys {
+			qb.OrderBy(key)
+		}
+		rows, err := qb.Limit(opt.ChunkSize).Get()
+		if err != nil {
+			return copied, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for start := 0; start < len(rows); start += opt.BatchSize {
+			end := start + opt.BatchSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+
+			values := [][]interface{}{}
+			for _, row := range rows[start:end] {
+				values = append(values, copyValues(row, doc, binaries))
+			}
+
+			err = dst.New().Table(opt.To).Insert(values, columns...)
+			if err != nil {
+				return copied, err
+			}
+
+			copied = copied + int64(end-start)
+			if opt.Progress != nil {
+				opt.Progress(table, copied, total)
+			}
+		}
+
+		last = rows[len(rows)-1]
+		if len(rows) < opt.ChunkSize {
+			break
+		}
+	}
+	return copied, nil
+}
+
+// copyValues get the values of the row in the column order, the text read as []byte (MySQL) is converted to string
+func copyValues(row xun.R, doc schema.TableDocument, binaries map[string]bool) []interface{} {
+	values := []interface{}{}
+	for _, column := range doc.Columns {
+		value := row[column.Name]
+		if bytes, ok := value.([]byte); ok && !binaries[column.Name] {
+			value = string(bytes)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// copyAfter get the condition of the rows after the last row in the keys order
+// (k1 > v1) OR (k1 = v1 AND k2 > v2)
+func copyAfter(keys []string, last xun.R) func(query.Query) {
+	return func(qb query.Query) {
+		qb.Where(keys[0], ">", last[keys[0]])
+		if len(keys) > 1 {
+			qb.OrWhere(func(qb query.Query) {
+				qb.Where(keys[0], last[keys[0]])
+				qb.Where(copyAfter(keys[1:], last))
+			})
+		}
+	}
+}
+
+// copyKeys get the columns for reading the rows in keyset chunks. A key which is neither the primary key
+// nor a unique column is paged together with the primary key, so the rows sharing a key value are not skipped.
+func copyKeys(doc schema.TableDocument, key string) ([]string, error) {
+	primary := ""
+	if len(doc.Primary) == 1 {
+		primary = doc.Primary[0]
+	}
+
+	if key == "" {
+		if primary == "" {
+			return nil, fmt.Errorf("the table %s has no single-column primary key, the key option is required", doc.Name)
+		}
+		return []string{primary}, nil
+	}
+
+	for _, column := range doc.Columns {
+		if column.Name == key && column.Nullable {
+			return nil, fmt.Errorf("the key %s is nullable, the rows with the null key can not be paged", key)
+		}
+	}
+
+	if key == primary || copyIsUnique(doc, key) {
+		return []string{key}, nil
+	}
+
+	if primary == "" {
+		return nil, fmt.Errorf("the key %s is not unique and the table %s has no single-column primary key", key, doc.Name)
+	}
+	return []string{key, primary}, nil
+}
+
+// copyIsUnique determine if the column has a single-column unique index without conditions
+func copyIsUnique(doc schema.TableDocument, column string) bool {
+	for _, index := range doc.Indexes {
+		if index.Type == "unique" && index.Where == "" && len(index.Columns) == 1 && index.Columns[0] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// copyHasIdentity determine if the table has an auto increment column
+func copyHasIdentity(doc schema.TableDocument) bool {
+	for _, column := range doc.Columns {
+		if column.AutoIncrement {
+			return true
+		}
+	}
+	return false
+}
+
+// connection get the registered connection by name
+func (manager *Manager) connection(name string) (*Connection, error) {
+	value, has := manager.Connections.Load(name)
+	if !has {
+		return nil, fmt.Errorf("the connection %s does not exist", name)
+	}
+	return value.(*Connection), nil
+}
+
+// option get the option of the manager
+func (manager *Manager) option() *dbal.Option {
+	if manager.Option == nil {
+		return &dbal.Option{}
+	}
+	return manager.Option
+}
+
+// schemaOf get a schema builder of the given database
+func (manager *Manager) schemaOf(db *sqlx.DB, config *dbal.Config) schema.Schema {
+	return schema.Use(&schema.Connection{
+		Write:       db,
+		WriteConfig: config,
+		Option:      manager.option(),
+	})
+}
+
+// queryOf get a query builder of the given database
+func (manager *Manager) queryOf(db *sqlx.DB, config *dbal.Config) query.Query {
+	return query.Use(&query.Connection{
+		Write:       db,
+		WriteConfig: config,
+		Read:        db,
+		ReadConfig:  config,
+		Option:      manager.option(),
+	})
+}
+
+// grammarOf get a grammar of the given database
+func (manager *Manager) grammarOf(db *sqlx.DB, config *dbal.Config) (dbal.Grammar, error) {
+	grammar, has := dbal.Grammars[config.Driver]
+	if !has {
+		return nil, fmt.Errorf("The %s driver not import", config.Driver)
+	}
+	return grammar.NewWith(db, config, manager.option())
+}
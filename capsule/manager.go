@@ -16,7 +16,7 @@ func (manager *Manager) Add(name string, driver string, datasource string, reado
 	config := dbal.Config{
 		Name:     name,
 		Driver:   driver,
-		DSN:      datasource,
+		DSN:      manager.dsn(driver, datasource),
 		ReadOnly: readonly,
 	}
 
@@ -95,6 +95,54 @@ func (manager *Manager) Query() query.Query {
 		})
 }
 
+// SetRetry set the retry policy of the deadlocks and the serialization failures.
+// The query builders created by the manager re-run the failed statements using the policy.
+func (manager *Manager) SetRetry(policy dbal.RetryPolicy) *Manager {
+	if manager.Option == nil {
+		manager.Option = &dbal.Option{}
+	}
+	manager.Option.Retry = &policy
+	return manager
+}
+
+// Retry Call the given closure, and call it again using the retry policy of the manager when it returns a deadlock or a serialization failure.
+func (manager *Manager) Retry(fn func() error) error {
+	if manager.Option == nil {
+		return fn()
+	}
+	return manager.Option.Retry.Run(fn)
+}
+
+// Transaction Execute the closure within a transaction on a primary connection.
+// The transaction is committed when the closure returns nil, otherwise it is rolled back.
+// The whole transaction is re-run using the retry policy of the manager when it fails with a deadlock or a serialization failure.
+func (manager *Manager) Transaction(fn func(tx *sqlx.Tx) error) error {
+	write, err := manager.Primary()
+	if err != nil {
+		return err
+	}
+
+	grammar, has := dbal.Grammars[write.Config.Driver]
+	if !has {
+		return fmt.Errorf("The %s driver not import", write.Config.Driver)
+	}
+
+	return manager.Retry(func() error {
+		tx, err := write.Beginx()
+		if err != nil {
+			return grammar.WrapError(err)
+		}
+
+		err = fn(tx)
+		if err != nil {
+			tx.Rollback()
+			return grammar.WrapError(err)
+		}
+
+		return grammar.WrapError(tx.Commit())
+	})
+}
+
 // Close the connections
 func (manager *Manager) Close() error {
 
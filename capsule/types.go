@@ -25,3 +25,13 @@ type Connection struct {
 	sqlx.DB
 	Config *dbal.Config
 }
+
+// CopyOption the option of copying a table between the connections
+type CopyOption struct {
+	To        string                                        // the destination table name, the source table name by default
+	Key       string                                        // the not null column for reading the rows in keyset chunks, the primary key by default
+	ChunkSize int                                           // the number of the rows read per query, 1000 by default
+	BatchSize int                                           // the number of the rows inserted per statement, 200 by default
+	Drop      bool                                          // drop the destination table if it exists, and drop it again if the copy fails
+	Progress  func(table string, copied int64, total int64) // called after each batch is inserted
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/yaoapp/xun/utils"
 )
 
 // Grammars loaded grammar driver
@@ -28,11 +30,27 @@ var Operators = []string{
 	"not similar to", "not ilike", "~~*", "!~~*",
 }
 
+// ForeignKeyActions the referential actions of the foreign key
+var ForeignKeyActions = map[string]bool{
+	"CASCADE":     true,
+	"SET NULL":    true,
+	"SET DEFAULT": true,
+	"RESTRICT":    true,
+	"NO ACTION":   true,
+}
+
 // Register register the grammar driver
 func Register(name string, grammar Grammar) {
 	Grammars[name] = grammar
 }
 
+// Add collect the given statement
+func (pretend *Pretend) Add(sql string) {
+	pretend.mutex.Lock()
+	defer pretend.mutex.Unlock()
+	pretend.Statements = append(pretend.Statements, sql)
+}
+
 // GetName get the table name
 func (table *Table) GetName() string {
 	return table.TableName
@@ -60,6 +78,11 @@ func NewTable(name string, schemaName string, dbName string) *Table {
 		Indexes:    []*Index{},
 		IndexMap:   map[string]*Index{},
 		Commands:   []*Command{},
+
+		ForeignKeys:   []*ForeignKey{},
+		ForeignKeyMap: map[string]*ForeignKey{},
+		Constraints:   []*Constraint{},
+		ConstraintMap: map[string]*Constraint{},
 	}
 }
 
@@ -217,6 +240,67 @@ func (table *Table) GetIndex(name string) *Index {
 	return table.IndexMap[name]
 }
 
+// NewForeignKey create a new foreign key intstance
+func (table *Table) NewForeignKey(name string, columns ...*Column) *ForeignKey {
+	return &ForeignKey{
+		DBName:            table.DBName,
+		TableName:         table.TableName,
+		Table:             table,
+		Name:              name,
+		Columns:           columns,
+		ReferencedColumns: []string{},
+	}
+}
+
+// PushForeignKey push a foreign key instance to the table foreign keys
+func (table *Table) PushForeignKey(foreign *ForeignKey) *Table {
+	table.ForeignKeyMap[foreign.Name] = foreign
+	table.ForeignKeys = append(table.ForeignKeys, foreign)
+	return table
+}
+
+// HasForeignKey checking if the given name foreign key exists
+func (table *Table) HasForeignKey(name string) bool {
+	_, has := table.ForeignKeyMap[name]
+	return has
+}
+
+// GetForeignKey get the given name foreign key instance
+func (table *Table) GetForeignKey(name string) *ForeignKey {
+	return table.ForeignKeyMap[name]
+}
+
+// NewConstraint create a new named constraint intstance
+func (table *Table) NewConstraint(name string, typ string, columns ...*Column) *Constraint {
+	return &Constraint{
+		SchemaName: table.SchemaName,
+		TableName:  table.TableName,
+		Table:      table,
+		Name:       name,
+		Type:       typ,
+		Args:       []string{},
+		Columns:    columns,
+	}
+}
+
+// PushConstraint push a constraint instance to the table constraints
+func (table *Table) PushConstraint(constraint *Constraint) *Table {
+	table.ConstraintMap[constraint.Name] = constraint
+	table.Constraints = append(table.Constraints, constraint)
+	return table
+}
+
+// HasConstraint checking if the given name constraint exists
+func (table *Table) HasConstraint(name string) bool {
+	_, has := table.ConstraintMap[name]
+	return has
+}
+
+// GetConstraint get the given name constraint instance
+func (table *Table) GetConstraint(name string) *Constraint {
+	return table.ConstraintMap[name]
+}
+
 // AddCommand Add a new command to the table.
 //
 // The commands must be:
@@ -227,6 +311,10 @@ func (table *Table) GetIndex(name string) *Index {
 //    CreateIndex(index *Index) for creating a index
 //    DropIndex( name string) for  dropping a index
 //    RenameIndex(old string,new string)  for renaming a index
+//    CreateForeign(foreign *ForeignKey) for creating a foreign key
+//    DropForeign(name string) for dropping a foreign key
+//    CreateConstraint(constraint *Constraint) for creating a unique or check constraint
+//    DropConstraint(name string) for dropping a unique or check constraint
 func (table *Table) AddCommand(name string, success func(), fail func(), params ...interface{}) {
 	table.Commands = append(table.Commands, &Command{
 		Name:    name,
@@ -245,6 +333,18 @@ func (command *Command) Callback(err error) {
 	}
 }
 
+// IsGenerated determine if the column is a generated (computed) column
+func (column *Column) IsGenerated() bool {
+	extra := utils.StringVal(column.Extra)
+	return extra == "StoredGenerated" || extra == "VirtualGenerated"
+}
+
+// OnUpdateTriggerName get the name of the trigger that sets the column to the current time when the row is updated,
+// Postgres and Dameng have no ON UPDATE CURRENT_TIMESTAMP, the column is maintained by the trigger.
+func OnUpdateTriggerName(tableName string, columnName string) string {
+	return fmt.Sprintf("%s_%s_on_update", tableName, columnName)
+}
+
 // AddColumn add column to index
 func (index *Index) AddColumn(column *Column) {
 	for _, col := range index.Columns {
@@ -255,8 +355,53 @@ func (index *Index) AddColumn(column *Column) {
 	index.Columns = append(index.Columns, column)
 }
 
-// Fullname get the name name with prefix
+// AddPart add the key part to the index, the column of the part is added to the index columns as well
+func (index *Index) AddPart(part *IndexPart) {
+	part.Order = strings.ToUpper(part.Order)
+	index.Parts = append(index.Parts, part)
+	if part.Column != nil {
+		index.AddColumn(part.Column)
+	}
+}
+
+// KeyParts get the key parts of the index, the indexes without the key parts use the columns
+func (index *Index) KeyParts() []*IndexPart {
+	if len(index.Parts) > 0 {
+		return index.Parts
+	}
+	parts := []*IndexPart{}
+	for _, column := range index.Columns {
+		parts = append(parts, &IndexPart{Column: column})
+	}
+	return parts
+}
+
+// AddColumn add column to constraint
+func (constraint *Constraint) AddColumn(column *Column) {
+	for _, col := range constraint.Columns {
+		if col.Name == column.Name {
+			return
+		}
+	}
+	constraint.Columns = append(constraint.Columns, column)
+}
+
+// AddColumn add a local column and the column it references to the foreign key
+func (foreign *ForeignKey) AddColumn(column *Column, referenced string) {
+	for _, col := range foreign.Columns {
+		if col.Name == column.Name {
+			return
+		}
+	}
+	foreign.Columns = append(foreign.Columns, column)
+	foreign.ReferencedColumns = append(foreign.ReferencedColumns, referenced)
+}
+
+// Fullname get the name name with prefix, the prefix of the schema-qualified name is added to the table name (reporting.xun_daily)
 func (name Name) Fullname() string {
+	if i := strings.LastIndex(name.Name, "."); i > 0 && name.Prefix != "" {
+		return fmt.Sprintf("%s.%s%s", name.Name[:i], name.Prefix, name.Name[i+1:])
+	}
 	return fmt.Sprintf("%s%s", name.Prefix, name.Name)
 }
 
@@ -300,6 +445,7 @@ func (query *Query) Clone() *Query {
 		DistinctColumns:    query.CopyDistinctColumns(), // Indicates if the query returns distinct results. Occasionally contains the columns that should be distinct.
 		IsJoinClause:       query.IsJoinClause,          // Determine if the query is a join clause.
 		BindingOffset:      query.BindingOffset,         // The Binding offset before select
+		Retry:              query.Retry,                 // The retry policy of the query
 	}
 
 	// // new := NewQuery()
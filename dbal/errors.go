@@ -0,0 +1,53 @@
+package dbal
+
+import "errors"
+
+// The classes of the database execution errors.
+// Check the class of an error returned by the query builder using errors.Is:
+//
+//	if errors.Is(err, dbal.ErrUniqueViolation) { ... }
+var (
+	ErrUniqueViolation     = errors.New("unique constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+	ErrNotNullViolation    = errors.New("not null constraint violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
+	ErrDeadlock            = errors.New("deadlock detected")
+	ErrSerialization       = errors.New("serialization failure")
+	ErrLockTimeout         = errors.New("lock wait timeout")
+)
+
+// Error the classified database execution error, it wraps the original driver error.
+// Get the constraint and column name using errors.As:
+//
+//	var e *dbal.Error
+//	if errors.As(err, &e) { fmt.Println(e.Constraint, e.Column) }
+type Error struct {
+	Class      error  // The error class, one of ErrUniqueViolation, ErrForeignKeyViolation ...
+	Constraint string // The name of the violated constraint (or index). empty if the driver does not report it.
+	Table      string // The name of the table. empty if the driver does not report it.
+	Column     string // The name of the column. empty if the driver does not report it.
+	Err        error  // The original driver error
+}
+
+// NewError make a new classified error instance
+func NewError(class error, err error) *Error {
+	return &Error{
+		Class: class,
+		Err:   err,
+	}
+}
+
+// Error the message of the original driver error
+func (err *Error) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap return the original driver error
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is determine if the error belongs to the given class
+func (err *Error) Is(target error) bool {
+	return err.Class == target
+}
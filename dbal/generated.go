@@ -0,0 +1,39 @@
+package dbal
+
+import (
+	"strings"
+	"sync"
+)
+
+// the generated column names of the tables, the key is DSN#table
+var generatedColumns = sync.Map{}
+
+// SetGeneratedColumns cache the generated column names of the table
+func SetGeneratedColumns(dsn string, table string, columns []string) {
+	generatedColumns.Store(dsn+"#"+table, columns)
+}
+
+// GetGeneratedColumns get the cached generated column names of the table, returns false when they are not cached
+func GetGeneratedColumns(dsn string, table string) ([]string, bool) {
+	columns, has := generatedColumns.Load(dsn + "#" + table)
+	if !has {
+		return nil, false
+	}
+	return columns.([]string), true
+}
+
+// ForgetGeneratedColumns remove the cached generated column names of the table,
+// it should be called when the table is changed outside the schema builder.
+func ForgetGeneratedColumns(dsn string, table string) {
+	generatedColumns.Delete(dsn + "#" + table)
+}
+
+// ForgetAllGeneratedColumns remove the cached generated column names of all tables of the DSN
+func ForgetAllGeneratedColumns(dsn string) {
+	generatedColumns.Range(func(key, value interface{}) bool {
+		if strings.HasPrefix(key.(string), dsn+"#") {
+			generatedColumns.Delete(key)
+		}
+		return true
+	})
+}
@@ -4,9 +4,33 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// CreateTableOption the option of creating a table, the options not supported by the driver are ignored
 type CreateTableOption struct {
-	Temporary bool   `json:"temporary,omitempty"` // If true, the table will be created as a temporary table (in memory)
-	Engine    string `json:"engine,omitempty"`    // The engine of the temporary table
+	Temporary          bool   `json:"temporary,omitempty"`            // If true, the table will be created as a temporary table (in memory)
+	Engine             string `json:"engine,omitempty"`               // The engine of the table (MySQL)
+	Comment            string `json:"comment,omitempty"`              // The comment of the table
+	Charset            string `json:"charset,omitempty"`              // The default character set of the table (MySQL)
+	Collation          string `json:"collation,omitempty"`            // The default collation of the table (MySQL)
+	RowFormat          string `json:"row_format,omitempty"`           // The row format of the table, e.g. DYNAMIC, COMPRESSED (MySQL)
+	AutoIncrementStart int    `json:"auto_increment_start,omitempty"` // The start value of the auto-increment column
+	Tablespace         string `json:"tablespace,omitempty"`           // The tablespace of the table
+	FillFactor         int    `json:"fillfactor,omitempty"`           // The fill factor percentage of the table (Postgres, Dameng)
+
+	Partition *PartitionOption `json:"partition,omitempty"` // The partitioning of the table (MySQL, Postgres 10+)
+}
+
+// PartitionOption the partitioning of the table, the partition key is the columns or the expression
+type PartitionOption struct {
+	Method     string       `json:"method"`               // RANGE, LIST or HASH
+	Columns    []string     `json:"columns,omitempty"`    // The columns of the partition key
+	Expression string       `json:"expression,omitempty"` // The expression of the partition key if no column given, e.g. YEAR(created_at)
+	Partitions []*Partition `json:"partitions,omitempty"` // The partitions created with the table
+}
+
+// ViewOption the option of creating a view
+type ViewOption struct {
+	Replace      bool `json:"replace,omitempty"`      // If true, the existing view will be replaced
+	Materialized bool `json:"materialized,omitempty"` // If true, the view will be created as a materialized view (Postgres only)
 }
 
 // Grammar the SQL Grammar inteface
@@ -36,6 +60,33 @@ type Grammar interface {
 	RenameTable(old string, new string) error
 	GetColumnListing(dbName string, tableName string) ([]*Column, error)
 
+	// Describe the column as the driver creates and introspects it, the schema diff compares the introspected columns with them
+	NormalizeColumn(column *Column) *Column
+
+	// Grammar for the views
+	GetViews() ([]*View, error)
+	CreateView(view *View, options ...ViewOption) error
+	DropView(name string) error
+	DropViewIfExists(name string) error
+	RefreshMaterializedView(name string) error
+
+	// Grammar for the schemas (namespaces)
+	GetSchemas() ([]string, error)
+	CreateSchema(name string) error
+	DropSchema(name string, cascade bool) error
+
+	// Grammar for the databases
+	GetDatabases() ([]string, error)
+	DatabaseExists(name string) (bool, error)
+	CreateDatabase(name string, charset string, collation string) error
+	DropDatabase(name string) error
+
+	// Grammar for the partitions
+	GetPartitions(tableName string) ([]*Partition, error)
+	AddPartition(tableName string, partitions []*Partition) error
+	DropPartition(tableName string, names []string) error
+	DetachPartition(tableName string, name string) error
+
 	// Grammar for querying
 	CompileInsert(query *Query, columns []interface{}, values [][]interface{}) (string, []interface{})
 	CompileInsertOrIgnore(query *Query, columns []interface{}, values [][]interface{}) (string, []interface{})
@@ -48,8 +99,12 @@ type Grammar interface {
 	CompileSelect(query *Query) string
 	CompileSelectOffset(query *Query, offset *int) string
 	CompileExists(query *Query) string
+	CompileDistance(column interface{}, point string) string
 
 	ProcessInsertGetID(sql string, bindings []interface{}, sequence string) (int64, error)
+
+	// Classify the driver error, returns a *Error when the error is a known constraint violation or locking failure
+	WrapError(err error) error
 }
 
 // Quoter the database quoting query text intrface
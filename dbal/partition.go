@@ -0,0 +1,35 @@
+package dbal
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonthlyPartitions get the RANGE partitions of the months from the month of the given time, the partitions are named
+// as p{YYYYMM} and bounded by the first days of the months, e.g. p202101 FROM '2021-01-01' TO '2021-02-01'.
+func MonthlyPartitions(from time.Time, months int) []*Partition {
+	partitions := []*Partition{}
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < months; i++ {
+		end := start.AddDate(0, 1, 0)
+		partitions = append(partitions, &Partition{
+			Name: fmt.Sprintf("p%s", start.Format("200601")),
+			From: start.Format("2006-01-02"),
+			To:   end.Format("2006-01-02"),
+		})
+		start = end
+	}
+	return partitions
+}
+
+// PartitionByMonth get the option of partitioning the table by the months of the date column,
+// the partitions of the months from the month of the given time are created with the table.
+//
+//	builder.CreateTable("events", callback, dbal.CreateTableOption{Partition: dbal.PartitionByMonth("created_at", time.Now(), 3)})
+func PartitionByMonth(column string, from time.Time, months int) *PartitionOption {
+	return &PartitionOption{
+		Method:     "RANGE",
+		Columns:    []string{column},
+		Partitions: MonthlyPartitions(from, months),
+	}
+}
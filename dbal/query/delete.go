@@ -1,16 +1,23 @@
 package query
 
 import (
+	"database/sql"
+
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/xun/utils"
 )
 
 // Delete Delete records from the database.
 func (builder *Builder) Delete() (int64, error) {
-	sql, bindings := builder.Grammar.CompileDelete(builder.Query)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
+	stmt, bindings := builder.Grammar.CompileDelete(builder.Query)
+	defer log.With(log.F{"bindings": bindings}).Debug(stmt)
 
-	res, err := builder.UseWrite().DB().Exec(sql, bindings...)
+	var res sql.Result
+	err := builder.retry(func() error {
+		var err error
+		res, err = builder.UseWrite().DB().Exec(stmt, bindings...)
+		return builder.Grammar.WrapError(err)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -28,14 +35,16 @@ func (builder *Builder) MustDelete() int64 {
 // Truncate Run a truncate statement on the table.
 func (builder *Builder) Truncate() error {
 	sqls, bindings := builder.Grammar.CompileTruncate(builder.Query)
-	for i, sql := range sqls {
-		defer log.With(log.F{"bindings": bindings}).Debug(sql)
-		_, err := builder.UseWrite().DB().Exec(sql, bindings[i]...)
-		if err != nil {
-			return err
+	return builder.retry(func() error {
+		for i, sql := range sqls {
+			defer log.With(log.F{"bindings": bindings}).Debug(sql)
+			_, err := builder.UseWrite().DB().Exec(sql, bindings[i]...)
+			if err != nil {
+				return builder.Grammar.WrapError(err)
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // MustTruncate Run a truncate statement on the table.
@@ -45,6 +45,109 @@ func TestDeleteMustDeleteWithJoin(t *testing.T) {
 	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
 }
 
+func TestDeleteMustDeleteWithJoinOn(t *testing.T) {
+	NewTableForDeleteTest()
+	NewTableForDeleteJoinTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_delete").
+		Join("table_test_delete_join", "table_test_delete_join.delete_id", "=", "table_test_delete.id").
+		Where("table_test_delete_join.status", "VIP")
+
+	// checking sql
+	sql, bindings := qb.Builder().Grammar.CompileDelete(qb.Builder().Query.Clone())
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `delete from "table_test_delete" using "table_test_delete_join" where ("table_test_delete_join"."delete_id" = "table_test_delete"."id") and ("table_test_delete_join"."status" = $1)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "delete `table_test_delete` from `table_test_delete` inner join `table_test_delete_join` on `table_test_delete_join`.`delete_id` = `table_test_delete`.`id` where `table_test_delete_join`.`status` = ?", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "delete from `table_test_delete` where `rowid` in (select `table_test_delete`.`rowid` from `table_test_delete` inner join `table_test_delete_join` on `table_test_delete_join`.`delete_id` = `table_test_delete`.`id` where `table_test_delete_join`.`status` = ?)", sql, "the query sql not equal")
+	}
+	assert.Equal(t, []interface{}{"VIP"}, bindings, "the bindings should be [VIP]")
+
+	// checking result
+	affected := qb.MustDelete()
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+	assert.Equal(t, int64(2), qb.Table("table_test_delete").MustCount(), "The rows count should be 2")
+}
+
+func TestDeleteMustDeleteWithLeftJoin(t *testing.T) {
+	NewTableForDeleteTest()
+	NewTableForDeleteJoinTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_delete as t1").
+		LeftJoin("table_test_delete_join as t2", "t2.delete_id", "=", "t1.id").
+		WhereNull("t2.id")
+
+	// checking sql
+	sql, _ := qb.Builder().Grammar.CompileDelete(qb.Builder().Query.Clone())
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `delete from "table_test_delete" as "t1" where "ctid" in (select "t1"."ctid" from "table_test_delete" as "t1" left join "table_test_delete_join" as "t2" on "t2"."delete_id" = "t1"."id" where "t2"."id" is null)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "delete `t1` from `table_test_delete` as `t1` left join `table_test_delete_join` as `t2` on `t2`.`delete_id` = `t1`.`id` where `t2`.`id` is null", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "delete from `table_test_delete` as `t1` where `rowid` in (select `t1`.`rowid` from `table_test_delete` as `t1` left join `table_test_delete_join` as `t2` on `t2`.`delete_id` = `t1`.`id` where `t2`.`id` is null)", sql, "the query sql not equal")
+	}
+
+	// checking result
+	affected := qb.MustDelete()
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+	assert.False(t, qb.Table("table_test_delete").Where("email", "lee@yao.run").MustExists(), "the row of lee@yao.run should be deleted")
+}
+
+func TestDeleteMustDeleteWithOrderByLimit(t *testing.T) {
+	NewTableForDeleteTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_delete").
+		Where("vote", ">", 5).
+		OrderBy("vote", "desc").
+		Limit(1)
+
+	// checking sql
+	sql, _ := qb.Builder().Grammar.CompileDelete(qb.Builder().Query.Clone())
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `delete from "table_test_delete" where "ctid" in (select "table_test_delete"."ctid" from "table_test_delete" where "vote" > $1 order by "vote" desc limit 1)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "delete from `table_test_delete` where `vote` > ? order by `vote` desc limit 1", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "delete from `table_test_delete` where `rowid` in (select `table_test_delete`.`rowid` from `table_test_delete` where `vote` > ? order by `vote` desc limit 1)", sql, "the query sql not equal")
+	}
+
+	// checking result
+	affected := qb.MustDelete()
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+	assert.False(t, qb.Table("table_test_delete").Where("email", "ken@yao.run").MustExists(), "the row of ken@yao.run should be deleted")
+}
+
+func TestDeleteMustDeleteWithJoinLimitError(t *testing.T) {
+	if unit.DriverNot("mysql") {
+		return
+	}
+	NewTableForDeleteTest()
+	NewTableForDeleteJoinTest()
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, "MySQL does not support delete statements with both joins and limit", func() {
+		qb.Table("table_test_delete as t1").
+			Join("table_test_delete_join as t2", "t2.delete_id", "=", "t1.id").
+			Limit(1).
+			MustDelete()
+	})
+}
+
+func TestDeleteMustDeleteWithJoinOrderByError(t *testing.T) {
+	if unit.DriverNot("mysql") {
+		return
+	}
+	NewTableForDeleteTest()
+	NewTableForDeleteJoinTest()
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, "MySQL does not support delete statements with both joins and order by", func() {
+		qb.Table("table_test_delete as t1").
+			Join("table_test_delete_join as t2", "t2.delete_id", "=", "t1.id").
+			OrderBy("t1.vote", "desc").
+			MustDelete()
+	})
+}
+
 func TestDeleteMustTruncate(t *testing.T) {
 	NewTableForDeleteTest()
 	qb := getTestBuilder()
@@ -70,6 +173,7 @@ func TestDeleteMustTruncateError(t *testing.T) {
 func TestDeleteClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
 	builder.DropTableIfExists("table_test_delete")
+	builder.DropTableIfExists("table_test_delete_join")
 }
 
 func NewTableForDeleteTest() {
@@ -96,3 +200,21 @@ func NewTableForDeleteTest() {
 		{"email": "ben@yao.run", "name": "Ben", "vote": 6, "score": 48.12, "score_grade": 99.27, "status": "DONE", "created_at": "2021-03-25 18:15:29"},
 	})
 }
+
+func NewTableForDeleteJoinTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_delete_join")
+	builder.MustCreateTable("table_test_delete_join", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("delete_id").Index()
+		table.String("status", 20)
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_delete_join").Insert([]xun.R{
+		{"delete_id": 1, "status": "VIP"},
+		{"delete_id": 3, "status": "VIP"},
+		{"delete_id": 4, "status": "NORMAL"},
+	})
+}
@@ -1,23 +1,39 @@
 package query
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
 
 // Exec Use the current connection to execute the sql, return the result
 func (builder *Builder) Exec(sql string, bindings ...interface{}) (sql.Result, error) {
-	stmt, err := builder.DB().Prepare(sql)
-	if err != nil {
-		return nil, err
-	}
-	defer stmt.Close()
-	return stmt.Exec(bindings...)
+	return builder.exec(builder.DB(), sql, bindings)
 }
 
 // ExecWrite Use the write connection to execute the sql, return the result
 func (builder *Builder) ExecWrite(sql string, bindings ...interface{}) (sql.Result, error) {
-	stmt, err := builder.DB(true).Prepare(sql)
+	return builder.exec(builder.DB(true), sql, bindings)
+}
+
+// exec prepare and execute the statement with the retry policy, return the result
+func (builder *Builder) exec(db *sqlx.DB, stmt string, bindings []interface{}) (sql.Result, error) {
+	var res sql.Result
+	err := builder.retry(func() error {
+		prepared, err := db.Prepare(stmt)
+		if err != nil {
+			return builder.Grammar.WrapError(err)
+		}
+		defer prepared.Close()
+
+		res, err = prepared.Exec(bindings...)
+		if err != nil {
+			return builder.Grammar.WrapError(err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
-	return stmt.Exec(bindings...)
+	return res, nil
 }
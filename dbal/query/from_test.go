@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,6 +75,30 @@ func TestFromFromSub(t *testing.T) {
 	}
 }
 
+func TestFromSchemaQualified(t *testing.T) {
+	NewTableFoFromTest()
+	schema := getTestSchemaBuilder().Builder().Schema
+	if unit.DriverIs("sqlite3") {
+		schema = "main"
+	}
+
+	qb := getTestBuilder()
+	qb.Table(schema+".table_test_from").
+		Where(schema+".table_test_from.cate", "dog").
+		Select("id").
+		OrderBy("id")
+
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, fmt.Sprintf(`select "id" from "%s"."table_test_from" where "%s"."table_test_from"."cate" = $1 order by "id" asc`, schema, schema), sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, fmt.Sprintf("select `id` from `%s`.`table_test_from` where `%s`.`table_test_from`.`cate` = ? order by `id` asc", schema, schema), sql, "the query sql not equal")
+	}
+
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should be have 2 rows")
+}
+
 // clean the test data
 func TestFromClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
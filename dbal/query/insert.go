@@ -7,19 +7,18 @@ import (
 	"github.com/yaoapp/xun/utils"
 )
 
-// Insert Insert new records into the database.
+// Insert Insert new records into the database, the generated columns are excluded.
+// The generated columns of the table are read from the catalog at the first insert (or update) and cached,
+// the cache is removed when the table is changed by the schema builder, or by dbal.ForgetGeneratedColumns.
 func (builder *Builder) Insert(v interface{}, columns ...interface{}) error {
-	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileInsert(builder.Query, columns, values)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	columns, values, err := builder.prepareInsertValues(v, columns...)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	sql, bindings := builder.Grammar.CompileInsert(builder.Query, columns, values)
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	_, err = stmt.Exec(bindings...)
+	_, err = builder.exec(builder.UseWrite().DB(), sql, bindings)
 	return err
 }
 
@@ -31,17 +30,14 @@ func (builder *Builder) MustInsert(v interface{}, columns ...interface{}) {
 
 // InsertOrIgnore Insert new records into the database while ignoring errors.
 func (builder *Builder) InsertOrIgnore(v interface{}, columns ...interface{}) (int64, error) {
-	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileInsertOrIgnore(builder.Query, columns, values)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	columns, values, err := builder.prepareInsertValues(v, columns...)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
+	sql, bindings := builder.Grammar.CompileInsertOrIgnore(builder.Query, columns, values)
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	res, err := stmt.Exec(bindings...)
+	res, err := builder.exec(builder.UseWrite().DB(), sql, bindings)
 	if err != nil {
 		return 0, err
 	}
@@ -66,10 +62,22 @@ func (builder *Builder) InsertGetID(v interface{}, args ...interface{}) (int64,
 		columns = args[1:]
 	}
 
-	columns, values := builder.prepareInsertValues(v, columns...)
+	columns, values, err := builder.prepareInsertValues(v, columns...)
+	if err != nil {
+		return 0, err
+	}
 	sql, bindings := builder.Grammar.CompileInsertGetID(builder.Query, columns, values, seq)
 	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-	return builder.Grammar.ProcessInsertGetID(sql, bindings, seq)
+	var lastID int64
+	err = builder.retry(func() error {
+		var err error
+		lastID, err = builder.Grammar.ProcessInsertGetID(sql, bindings, seq)
+		return builder.Grammar.WrapError(err)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return lastID, nil
 }
 
 // MustInsertGetID Insert a new record and get the value of the primary key.
@@ -87,13 +95,7 @@ func (builder *Builder) InsertUsing(qb interface{}, columns ...interface{}) (int
 	sql := builder.parseSub(sub)
 	sql = builder.Grammar.CompileInsertUsing(builder.Query, columns, sql)
 
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
-	if err != nil {
-		return 0, err
-	}
-	defer stmt.Close()
-
-	res, err := stmt.Exec(bindings...)
+	res, err := builder.exec(builder.UseWrite().DB(), sql, bindings)
 	if err != nil {
 		return 0, err
 	}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -313,10 +314,115 @@ func TestInsertMustInsertUsingWithArray(t *testing.T) {
 
 }
 
+func TestInsertInsertUniqueViolation(t *testing.T) {
+	NewTableForInsertTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_insert").MustInsert(xun.R{"email": "kayla@example.com", "vote": 1})
+
+	err := qb.Table("table_test_insert").Insert(xun.R{"email": "kayla@example.com", "vote": 2})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation), "the error should be a unique violation")
+	assert.False(t, errors.Is(err, dbal.ErrNotNullViolation), "the error should not be a not null violation")
+
+	var dbalErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbalErr), "the error should be a *dbal.Error") {
+		assert.NotNil(t, dbalErr.Unwrap(), "the original error should be kept")
+		assert.Equal(t, dbalErr.Unwrap().Error(), err.Error(), "the message should be the original one")
+		if unit.DriverIs("sqlite3") {
+			assert.Equal(t, "table_test_insert", dbalErr.Table)
+			assert.Equal(t, "email", dbalErr.Column)
+		} else if unit.DriverIs("postgres") {
+			assert.Equal(t, "table_test_insert_email_unique", dbalErr.Constraint)
+		} else if unit.DriverIs("mysql") {
+			assert.Equal(t, "email_unique", dbalErr.Constraint)
+		}
+	}
+
+	_, err = qb.Table("table_test_insert").InsertGetID(xun.R{"email": "kayla@example.com", "vote": 3})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation), "the error should be a unique violation")
+
+	_, err = qb.Table("table_test_insert").Where("vote", 1).Update(xun.R{"email": "kayla@example.com"})
+	assert.Nil(t, err, "updating the same value should not be an error")
+}
+
+func TestInsertInsertNotNullViolation(t *testing.T) {
+	NewTableForInsertNotNullTest()
+	qb := getTestBuilder()
+
+	err := qb.Table("table_test_insert_notnull").Insert(xun.R{"email": "kayla@example.com", "vote": nil})
+	assert.True(t, errors.Is(err, dbal.ErrNotNullViolation), "the error should be a not null violation")
+
+	var dbalErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbalErr), "the error should be a *dbal.Error") {
+		if unit.DriverIs("sqlite3") || unit.DriverIs("postgres") || unit.DriverIs("mysql") {
+			assert.Equal(t, "vote", dbalErr.Column)
+		}
+	}
+}
+
+func TestInsertMustInsertGenerated(t *testing.T) {
+	NewTableForGeneratedTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_generated").MustInsert([]xun.R{
+		{"price": 5, "quantity": 3, "total": 100},
+		{"price": 2, "quantity": 4, "total": 100},
+	})
+	qb.Table("table_test_generated").MustInsert([][]interface{}{{3, 3, 100}}, []string{"price", "quantity", "total"})
+
+	totals := []int64{}
+	for _, row := range qb.Table("table_test_generated").OrderBy("id").MustGet() {
+		totals = append(totals, row["total"].(int64))
+	}
+	assert.Equal(t, []int64{15, 8, 9}, totals, "the generated column should be excluded and computed")
+}
+
+func TestInsertInsertGeneratedTableNotFound(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_generated_raw")
+	defer builder.DropTableIfExists("table_test_generated_raw")
+
+	qb := getTestBuilder()
+	err := qb.Table("table_test_generated_raw").Insert(xun.R{"price": 5, "total": 100})
+	assert.NotNil(t, err, "the table does not exist")
+
+	// the table is created outside the schema builder, the missing table should not be cached
+	_, err = qb.DB().Exec("CREATE TABLE table_test_generated_raw (price INT, total INT GENERATED ALWAYS AS (price * 2) STORED)")
+	assert.Nil(t, err, "the table should be created")
+	err = qb.Table("table_test_generated_raw").Insert(xun.R{"price": 5, "total": 100})
+	assert.Nil(t, err, "the generated column should be excluded")
+	assert.Equal(t, 10, qb.Table("table_test_generated_raw").MustFirst().GetInt("total"), "the generated column should be computed")
+}
+
+func TestInsertMustInsertPoint(t *testing.T) {
+	if !NewTableForSpatialTest() {
+		return
+	}
+
+	type Shop struct {
+		Name     string    `json:"name"`
+		Location xun.Point `json:"location"`
+	}
+
+	qb := getTestBuilder()
+	qb.Table("table_test_where_spatial").MustInsert(Shop{Name: "Bund", Location: xun.MakePoint(121.490317, 31.241701)})
+
+	shops := []Shop{}
+	qb.Table("table_test_where_spatial").Select("name", "location").OrderBy("id").MustGet(&shops)
+	assert.Equal(t, 4, len(shops), "the return rows should have 4 items")
+	if len(shops) == 4 {
+		assert.Equal(t, xun.MakePoint(116.397128, 39.916527), shops[0].Location, "the point should be scanned")
+		assert.Equal(t, xun.MakePoint(121.490317, 31.241701), shops[3].Location, "the point of the struct should be inserted")
+	}
+
+	row := qb.Table("table_test_where_spatial").Where("name", "Bund").MustFirst()
+	assert.Equal(t, xun.MakePoint(121.490317, 31.241701), row.GetPoint("location"), "the value should be cast to the point")
+}
+
 // clean the test data
 func TestInsertClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
 	builder.DropTableIfExists("table_test_insert")
+	builder.DropTableIfExists("table_test_insert_notnull")
+	builder.DropTableIfExists("table_test_generated")
 }
 
 func NewTableForInsertTest() {
@@ -330,6 +436,17 @@ func NewTableForInsertTest() {
 	})
 }
 
+func NewTableForInsertNotNullTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_insert_notnull")
+	builder.MustCreateTable("table_test_insert_notnull", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("vote").SetDefault(0).NotNull()
+	})
+}
+
 func checkInsertWithColumns(t *testing.T, qb Query) {
 	users := qb.Select("email", "vote").OrderBy("vote").MustGet()
 	assert.Equal(t, 2, len(users), "The return users should be 2")
@@ -340,3 +457,15 @@ func checkInsertWithColumns(t *testing.T, qb Query) {
 		assert.Equal(t, int64(2), users[1]["vote"].(int64), "The vote of the second row should be 2")
 	}
 }
+
+func NewTableForGeneratedTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_generated")
+	builder.MustCreateTable("table_test_generated", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("price")
+		table.Integer("quantity")
+		table.Integer("total").StoredAs("price * quantity")
+	})
+}
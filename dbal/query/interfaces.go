@@ -2,6 +2,7 @@ package query
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun"
@@ -25,6 +26,18 @@ type Query interface {
 	MustFirst(v ...interface{}) xun.R
 	Find(id interface{}, args ...interface{}) (xun.R, error)
 	MustFind(id interface{}, args ...interface{}) xun.R
+	FirstOrFail(v ...interface{}) (xun.R, error)
+	MustFirstOrFail(v ...interface{}) xun.R
+	FindOrFail(id interface{}, args ...interface{}) (xun.R, error)
+	MustFindOrFail(id interface{}, args ...interface{}) xun.R
+	Sole(v ...interface{}) (xun.R, error)
+	MustSole(v ...interface{}) xun.R
+	FirstOr(callback func() (xun.R, error), v ...interface{}) (xun.R, error)
+	MustFirstOr(callback func() (xun.R, error), v ...interface{}) xun.R
+	FirstOrNew(attributes interface{}, values ...interface{}) (xun.R, error)
+	MustFirstOrNew(attributes interface{}, values ...interface{}) xun.R
+	FirstOrCreate(attributes interface{}, values ...interface{}) (xun.R, error)
+	MustFirstOrCreate(attributes interface{}, values ...interface{}) xun.R
 	Value(column string, v ...interface{}) (interface{}, error)
 	MustValue(column string, v ...interface{}) interface{}
 	Exists() (bool, error)
@@ -65,6 +78,7 @@ type Query interface {
 	SelectAppend(columns ...interface{}) Query
 	SelectRaw(expression string, bindings ...interface{}) Query
 	SelectSub(qb interface{}, alias string) Query
+	SelectDistance(column interface{}, point xun.Point, alias string) Query
 	Distinct(args ...interface{}) Query
 
 	// defined in the from.go file
@@ -122,6 +136,10 @@ type Query interface {
 	OrWhereMonth(column interface{}, args ...interface{}) Query
 	WhereDay(column interface{}, args ...interface{}) Query
 	OrWhereDay(column interface{}, args ...interface{}) Query
+	WhereDistanceWithin(column interface{}, point xun.Point, meters float64) Query
+	OrWhereDistanceWithin(column interface{}, point xun.Point, meters float64) Query
+	WhereMorphedTo(name string, typ string, id interface{}) Query
+	OrWhereMorphedTo(name string, typ string, id interface{}) Query
 	When(value bool, callback func(qb Query, value bool), defaults ...func(qb Query, value bool)) Query
 	Unless(value bool, callback func(qb Query, value bool), defaults ...func(qb Query, value bool)) Query
 
@@ -167,6 +185,8 @@ type Query interface {
 	MustUpdateOrInsert(attributes interface{}, values ...interface{}) bool
 	Update(v interface{}) (int64, error)
 	MustUpdate(v interface{}) int64
+	UpdateWithVersion(v interface{}, column string, expected interface{}) (int64, error)
+	MustUpdateWithVersion(v interface{}, column string, expected interface{}) int64
 	Increment(column interface{}, amount interface{}, extra ...interface{}) (int64, error)
 	MustIncrement(column interface{}, amount interface{}, extra ...interface{}) int64
 	Decrement(column interface{}, amount interface{}, extra ...interface{}) (int64, error)
@@ -182,6 +202,9 @@ type Query interface {
 	Exec(sql string, bindings ...interface{}) (sql.Result, error)
 	ExecWrite(sql string, bindings ...interface{}) (sql.Result, error)
 
+	// defined in the retry.go file
+	Retry(times int, backoff time.Duration) Query
+
 	// defined in the debug.go file
 	DD()
 	Dump()
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -19,32 +20,57 @@ func (builder *Builder) Table(name string) Query {
 
 // Get Execute the query as a "select" statement.
 func (builder *Builder) Get(v ...interface{}) ([]xun.R, error) {
+	res, _, err := builder.fetch(v...)
+	return res, err
+}
+
+// fetch execute the select statement with the retry policy, returns the rows and the number of the rows
+func (builder *Builder) fetch(v ...interface{}) ([]xun.R, int, error) {
+	var res []xun.R
+	var count int
+	err := builder.retry(func() error {
+		var err error
+		res, count, err = builder.get(v...)
+		return builder.Grammar.WrapError(err)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, count, nil
+}
+
+// get execute the select statement and scan the rows
+func (builder *Builder) get(v ...interface{}) ([]xun.R, int, error) {
 	db := builder.DB()
 	stmt, err := db.Prepare(builder.ToSQL())
 	if err != nil {
 		defer log.With(log.F{"bindings": builder.GetBindings()}).Error(builder.ToSQL())
-		return nil, err
+		return nil, 0, err
 	}
 
 	defer stmt.Close()
 
 	rows, err := stmt.Query(builder.GetBindings()...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if len(v) == 1 && v[0] != nil {
 		if reflect.TypeOf(v[0]).Kind() != reflect.Ptr {
-			return nil, fmt.Errorf("The input param is %s, it should be a pointer", reflect.TypeOf(v[0]).Kind().String())
+			return nil, 0, fmt.Errorf("The input param is %s, it should be a pointer", reflect.TypeOf(v[0]).Kind().String())
 		}
-		err := builder.structScan(rows, v[0])
+		count, err := builder.structScan(rows, v[0])
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		return nil, nil
+		return nil, count, nil
 	}
 
-	return builder.mapScan(rows)
+	res, err := builder.mapScan(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, len(res), nil
 }
 
 // MustGet Execute the query as a "select" statement.
@@ -85,6 +111,20 @@ func (builder *Builder) GetBindings() []interface{} {
 
 // Exists Determine if any rows exist for the current query.
 func (builder *Builder) Exists() (bool, error) {
+	var exists bool
+	err := builder.retry(func() error {
+		var err error
+		exists, err = builder.exists()
+		return builder.Grammar.WrapError(err)
+	})
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// exists execute the exists statement
+func (builder *Builder) exists() (bool, error) {
 	sql := builder.Grammar.CompileExists(builder.Query)
 
 	db := builder.DB()
@@ -142,6 +182,129 @@ func (builder *Builder) MustFind(id interface{}, args ...interface{}) xun.R {
 	return res
 }
 
+// FirstOrFail Execute the query and get the first result, returns xun.ErrNotFound if no record matches.
+func (builder *Builder) FirstOrFail(v ...interface{}) (xun.R, error) {
+	rows, count, err := builder.Take(1).Builder().fetch(v...)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, xun.ErrNotFound
+	}
+	if len(rows) > 0 {
+		return rows[0], nil
+	}
+	return xun.MakeR(), nil
+}
+
+// MustFirstOrFail Execute the query and get the first result, panic with xun.ErrNotFound if no record matches.
+func (builder *Builder) MustFirstOrFail(v ...interface{}) xun.R {
+	res, err := builder.FirstOrFail(v...)
+	utils.PanicIF(err)
+	return res
+}
+
+// FindOrFail Execute a query for a single record by ID, returns xun.ErrNotFound if no record matches.
+func (builder *Builder) FindOrFail(id interface{}, args ...interface{}) (xun.R, error) {
+	key, v := builder.prepareFindArgs(args...)
+	return builder.Where(key, id).FirstOrFail(v)
+}
+
+// MustFindOrFail Execute a query for a single record by ID, panic with xun.ErrNotFound if no record matches.
+func (builder *Builder) MustFindOrFail(id interface{}, args ...interface{}) xun.R {
+	res, err := builder.FindOrFail(id, args...)
+	utils.PanicIF(err)
+	return res
+}
+
+// Sole Get the only record matching the query, returns xun.ErrNotFound if no record matches, xun.ErrMultipleRecords if more than one record matches.
+func (builder *Builder) Sole(v ...interface{}) (xun.R, error) {
+	rows, count, err := builder.Take(2).Builder().fetch(v...)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, xun.ErrNotFound
+	}
+	if count > 1 {
+		return nil, xun.ErrMultipleRecords
+	}
+	if len(rows) > 0 {
+		return rows[0], nil
+	}
+	return xun.MakeR(), nil
+}
+
+// MustSole Get the only record matching the query, panic with xun.ErrNotFound or xun.ErrMultipleRecords.
+func (builder *Builder) MustSole(v ...interface{}) xun.R {
+	res, err := builder.Sole(v...)
+	utils.PanicIF(err)
+	return res
+}
+
+// FirstOr Execute the query and get the first result, call the callback and return its result if no record matches.
+func (builder *Builder) FirstOr(callback func() (xun.R, error), v ...interface{}) (xun.R, error) {
+	row, err := builder.FirstOrFail(v...)
+	if errors.Is(err, xun.ErrNotFound) {
+		return callback()
+	}
+	return row, err
+}
+
+// MustFirstOr Execute the query and get the first result, call the callback and return its result if no record matches.
+func (builder *Builder) MustFirstOr(callback func() (xun.R, error), v ...interface{}) xun.R {
+	res, err := builder.FirstOr(callback, v...)
+	utils.PanicIF(err)
+	return res
+}
+
+// FirstOrNew Get the first record matching the attributes, or make a new one filled with the attributes and values without saving it.
+func (builder *Builder) FirstOrNew(attributes interface{}, values ...interface{}) (xun.R, error) {
+	row, err := builder.Where(attributes).FirstOrFail()
+	if errors.Is(err, xun.ErrNotFound) {
+		return builder.prepareFirstOrValues(attributes, values...), nil
+	}
+	return row, err
+}
+
+// MustFirstOrNew Get the first record matching the attributes, or make a new one filled with the attributes and values without saving it.
+func (builder *Builder) MustFirstOrNew(attributes interface{}, values ...interface{}) xun.R {
+	res, err := builder.FirstOrNew(attributes, values...)
+	utils.PanicIF(err)
+	return res
+}
+
+// FirstOrCreate Get the first record matching the attributes, or insert a new one filled with the attributes and values.
+func (builder *Builder) FirstOrCreate(attributes interface{}, values ...interface{}) (xun.R, error) {
+	row, err := builder.Where(attributes).FirstOrFail()
+	if !errors.Is(err, xun.ErrNotFound) {
+		return row, err
+	}
+
+	err = builder.Insert(builder.prepareFirstOrValues(attributes, values...))
+	if err != nil && !errors.Is(err, dbal.ErrUniqueViolation) { // created by others at the same time
+		return nil, err
+	}
+
+	return builder.FirstOrFail()
+}
+
+// MustFirstOrCreate Get the first record matching the attributes, or insert a new one filled with the attributes and values.
+func (builder *Builder) MustFirstOrCreate(attributes interface{}, values ...interface{}) xun.R {
+	res, err := builder.FirstOrCreate(attributes, values...)
+	utils.PanicIF(err)
+	return res
+}
+
+// prepareFirstOrValues merge the attributes and the values
+func (builder *Builder) prepareFirstOrValues(attributes interface{}, values ...interface{}) xun.R {
+	row := xun.MakeR(attributes)
+	if len(values) > 0 {
+		row.Merge(values[0])
+	}
+	return row
+}
+
 // Value Get a single column's value from the first result of a query.
 func (builder *Builder) Value(column string, v ...interface{}) (interface{}, error) {
 	row, err := builder.Select(column).First(v...)
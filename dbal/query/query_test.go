@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -279,6 +280,124 @@ func TestQueryMustValueBind(t *testing.T) {
 
 }
 
+func TestQueryFirstOrFail(t *testing.T) {
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row, err := qb.Table("table_test_query").Where("email", "like", "%@yao.run").OrderBy("id").FirstOrFail()
+	assert.Nil(t, err)
+	assert.Equal(t, "john@yao.run", row.Get("email"), "the email should be john@yao.run")
+
+	row, err = qb.Table("table_test_query").Where("email", "like", "%@yaorun").FirstOrFail()
+	assert.True(t, errors.Is(err, xun.ErrNotFound), "the error should be xun.ErrNotFound")
+	assert.Nil(t, row)
+
+	assert.PanicsWithError(t, xun.ErrNotFound.Error(), func() {
+		qb.Table("table_test_query").Where("email", "like", "%@yaorun").MustFirstOrFail()
+	})
+}
+
+func TestQueryFirstOrFailBind(t *testing.T) {
+	type Item struct {
+		ID    int64
+		Email string
+	}
+
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := Item{}
+	_, err := qb.Table("table_test_query").Select("id", "email").Where("email", "ken@yao.run").FirstOrFail(&row)
+	assert.Nil(t, err)
+	assert.Equal(t, "ken@yao.run", row.Email, "the email should be ken@yao.run")
+
+	row = Item{}
+	_, err = qb.Table("table_test_query").Select("id", "email").Where("email", "nobody@yao.run").FirstOrFail(&row)
+	assert.True(t, errors.Is(err, xun.ErrNotFound), "the error should be xun.ErrNotFound")
+	assert.Equal(t, "", row.Email, "the row should not be filled")
+}
+
+func TestQueryFindOrFail(t *testing.T) {
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_query").MustFindOrFail(3)
+	assert.Equal(t, "ken@yao.run", row.Get("email"), "the email should be ken@yao.run")
+
+	row = qb.Table("table_test_query").MustFindOrFail("ken@yao.run", "email")
+	assert.Equal(t, int64(3), row.Get("id"), "the id should be 3")
+
+	_, err := qb.Table("table_test_query").FindOrFail(100)
+	assert.True(t, errors.Is(err, xun.ErrNotFound), "the error should be xun.ErrNotFound")
+}
+
+func TestQuerySole(t *testing.T) {
+	type Item struct {
+		ID    int64
+		Email string
+	}
+
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_query").Where("email", "lee@yao.run").MustSole()
+	assert.Equal(t, "Lee", row.Get("name"), "the name should be Lee")
+
+	_, err := qb.Table("table_test_query").Where("status", "DONE").Sole()
+	assert.True(t, errors.Is(err, xun.ErrMultipleRecords), "the error should be xun.ErrMultipleRecords")
+
+	_, err = qb.Table("table_test_query").Where("status", "UNKNOWN").Sole()
+	assert.True(t, errors.Is(err, xun.ErrNotFound), "the error should be xun.ErrNotFound")
+
+	item := Item{}
+	_, err = qb.Table("table_test_query").Select("id", "email").Where("status", "DONE").Sole(&item)
+	assert.True(t, errors.Is(err, xun.ErrMultipleRecords), "the error should be xun.ErrMultipleRecords")
+
+	item = Item{}
+	_, err = qb.Table("table_test_query").Select("id", "email").Where("status", "WAITING").Sole(&item)
+	assert.Nil(t, err)
+	assert.Equal(t, "john@yao.run", item.Email, "the email should be john@yao.run")
+}
+
+func TestQueryFirstOr(t *testing.T) {
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_query").Where("email", "ken@yao.run").MustFirstOr(func() (xun.R, error) {
+		return xun.R{"email": "default@yao.run"}, nil
+	})
+	assert.Equal(t, "ken@yao.run", row.Get("email"), "the email should be ken@yao.run")
+
+	row = qb.Table("table_test_query").Where("email", "nobody@yao.run").MustFirstOr(func() (xun.R, error) {
+		return xun.R{"email": "default@yao.run"}, nil
+	})
+	assert.Equal(t, "default@yao.run", row.Get("email"), "the email should be default@yao.run")
+
+	_, err := qb.Table("table_test_query").Where("email", "nobody@yao.run").FirstOr(func() (xun.R, error) {
+		return nil, fmt.Errorf("callback error")
+	})
+	assert.Equal(t, "callback error", err.Error())
+}
+
+func TestQueryFirstOrNew(t *testing.T) {
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_query").MustFirstOrNew(xun.R{"email": "ken@yao.run"}, xun.R{"name": "Someone"})
+	assert.Equal(t, "Ken", row.Get("name"), "the name should be Ken")
+
+	row = qb.Table("table_test_query").MustFirstOrNew(xun.R{"email": "max@yao.run"}, xun.R{"name": "Max"})
+	assert.Equal(t, xun.R{"email": "max@yao.run", "name": "Max"}, row)
+	assert.Equal(t, int64(4), qb.Table("table_test_query").MustCount(), "the new row should not be saved")
+}
+
+func TestQueryFirstOrCreate(t *testing.T) {
+	NewTableForQueryTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_query").MustFirstOrCreate(xun.R{"email": "ken@yao.run"}, xun.R{"name": "Someone", "vote": 1})
+	assert.Equal(t, "Ken", row.Get("name"), "the name should be Ken")
+	assert.Equal(t, int64(4), qb.Table("table_test_query").MustCount())
+
+	row = qb.Table("table_test_query").MustFirstOrCreate(xun.R{"email": "max@yao.run"}, xun.R{"name": "Max", "vote": 1, "score": 1, "score_grade": 1})
+	assert.Equal(t, "Max", row.Get("name"), "the name should be Max")
+	assert.Equal(t, int64(5), row.Get("id"), "the id should be 5")
+	assert.Equal(t, int64(5), qb.Table("table_test_query").MustCount())
+}
+
 // clean the test data
 func TestQueryClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"time"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// Retry Re-run the statement up to the given times when it fails with a deadlock or a serialization failure.
+// The wait duration before the retry starts from the backoff and doubles on every retry, the retry hook of the connection is kept.
+func (builder *Builder) Retry(times int, backoff time.Duration) Query {
+	policy := dbal.RetryPolicy{}
+	if builder.Conn != nil && builder.Conn.Option != nil && builder.Conn.Option.Retry != nil {
+		policy = *builder.Conn.Option.Retry
+	}
+	policy.Times = times
+	policy.Backoff = backoff
+	builder.Query.Retry = &policy
+	return builder
+}
+
+// retry run the statement using the retry policy of the query, or the connection's one.
+func (builder *Builder) retry(fn func() error) error {
+	policy := builder.Query.Retry
+	if policy == nil && builder.Conn != nil && builder.Conn.Option != nil {
+		policy = builder.Conn.Option.Retry
+	}
+	return policy.Run(fn)
+}
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestRetryRetry(t *testing.T) {
+	NewTableForRetryTest()
+	qb := getTestBuilder()
+
+	qb.Table("table_test_retry").Retry(3, time.Millisecond)
+	assert.Equal(t, 3, qb.Builder().Query.Retry.Times)
+	assert.Equal(t, time.Millisecond, qb.Builder().Query.Retry.Backoff)
+
+	affected := qb.Table("table_test_retry").Retry(3, time.Millisecond).Where("id", 1).MustIncrement("counter", 1)
+	assert.Equal(t, int64(1), affected)
+	assert.Equal(t, int64(2), qb.Table("table_test_retry").Where("id", 1).MustFirst().Get("counter").(int64))
+
+	// the retry policy is reset by the next statement
+	qb.Table("table_test_retry")
+	assert.Nil(t, qb.Builder().Query.Retry)
+}
+
+func TestRetryRetryDeadlock(t *testing.T) {
+	qb := getTestBuilder()
+	builder := qb.Table("table_test_retry").Retry(2, time.Millisecond).Builder()
+
+	calls := 0
+	err := builder.retry(func() error {
+		calls++
+		return dbal.NewError(dbal.ErrDeadlock, errors.New("deadlock"))
+	})
+	assert.True(t, errors.Is(err, dbal.ErrDeadlock))
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = builder.retry(func() error {
+		calls++
+		return errors.New("syntax error")
+	})
+	assert.Equal(t, "syntax error", err.Error())
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryRetryInheritHook(t *testing.T) {
+	qb := getTestBuilder()
+	conn := *qb.Builder().Conn
+	option := dbal.Option{}
+	conn.Option = &option
+
+	attempts := []int{}
+	option.Retry = &dbal.RetryPolicy{
+		Times:   1,
+		Backoff: 10 * time.Millisecond,
+		OnRetry: func(attempt int, err error, wait time.Duration) { attempts = append(attempts, attempt) },
+	}
+
+	builder := qb.Builder().NewBuilder()
+	builder.Conn = &conn
+
+	// use the connection's policy
+	calls := 0
+	builder.retry(func() error {
+		calls++
+		if calls == 1 {
+			return dbal.NewError(dbal.ErrSerialization, errors.New("serialization failure"))
+		}
+		return nil
+	})
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []int{1}, attempts)
+
+	// override the times and the backoff, keep the hook
+	builder.Retry(2, time.Millisecond)
+	assert.Equal(t, 1, option.Retry.Times)
+	calls = 0
+	builder.retry(func() error {
+		calls++
+		return dbal.NewError(dbal.ErrSerialization, errors.New("serialization failure"))
+	})
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 1, 2}, attempts)
+}
+
+// clean the test data
+func TestRetryClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_retry")
+}
+
+func NewTableForRetryTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_retry")
+	builder.MustCreateTable("table_test_retry", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("counter")
+	})
+	qb := getTestBuilder()
+	qb.Table("table_test_retry").MustInsert([][]interface{}{{1, 1}}, []interface{}{"id", "counter"})
+}
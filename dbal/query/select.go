@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/yaoapp/xun"
 	"github.com/yaoapp/xun/dbal"
 )
 
@@ -66,6 +67,19 @@ func (builder *Builder) SelectSub(qb interface{}, as string) Query {
 	return builder
 }
 
+// SelectDistance Add the distance in meters between the column and the point to the query.
+// The distance is the spherical distance of the longitude and latitude (MySQL 5.7.6+ and PostGIS), the method panics on SQLite.
+//
+//	qb.Table("shops").Select("id", "name").SelectDistance("location", xun.MakePoint(116.39, 39.91), "distance")
+func (builder *Builder) SelectDistance(column interface{}, point xun.Point, alias string) Query {
+	sql := builder.Grammar.CompileDistance(column, point.WKT())
+	if alias != "" {
+		sql = fmt.Sprintf("%s as %s", sql, builder.Grammar.Wrap(alias))
+	}
+	builder.addSelect(dbal.Raw(sql))
+	return builder
+}
+
 // Distinct Force the query to only return distinct results.
 func (builder *Builder) Distinct(args ...interface{}) Query {
 	if len(args) > 0 {
@@ -256,6 +256,41 @@ func TestSelectDistinctColumnsStyle3(t *testing.T) {
 	checktestSelectDistinctColumns(t, qb)
 }
 
+func TestSelectSelectDistance(t *testing.T) {
+	supported := NewTableForSpatialTest()
+	qb := getTestBuilder()
+	if unit.DriverIs("sqlite3") {
+		assert.Panics(t, func() {
+			qb.Table("table_test_where_spatial").SelectDistance("location", xun.MakePoint(116.397128, 39.916527), "distance")
+		}, "the spatial functions are not supported")
+		return
+	}
+
+	qb.Table("table_test_where_spatial").
+		Select("name").
+		SelectDistance("location", xun.MakePoint(116.397128, 39.916527), "distance").
+		OrderBy("id")
+
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "name", ST_Distance("location"::geography, ST_GeogFromText('POINT(116.397128 39.916527)')) as "distance" from "table_test_where_spatial" order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `name`, ST_Distance_Sphere(`location`, ST_GeomFromText('POINT(116.397128 39.916527)')) as `distance` from `table_test_where_spatial` order by `id` asc", sql, "the query sql not equal")
+	}
+
+	if !supported || unit.Is("mysql5.6") {
+		return
+	}
+
+	rows := qb.MustGet()
+	assert.Equal(t, 3, len(rows), "the return value should be have 3 rows")
+	if len(rows) == 3 {
+		assert.Less(t, rows[0].GetFloat("distance", 2), 1.0, "the distance of the 1st row should be 0")
+		assert.InDelta(t, 1700, rows[1].GetFloat("distance", 2), 300, "the distance of the 2nd row should be about 1.7km")
+		assert.Greater(t, rows[2].GetFloat("distance", 2), 1000000.0, "the distance of the 3rd row should be more than 1000km")
+	}
+}
+
 // clean the test data
 func TestSelectClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
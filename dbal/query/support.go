@@ -47,12 +47,12 @@ func (builder *Builder) prepareWhereArgs(args ...interface{}) (string, interface
 	return operator, value, boolean, offset
 }
 
-// prepareInsertValues prepare the insert values
-func (builder *Builder) prepareInsertValues(v interface{}, columns ...interface{}) ([]interface{}, [][]interface{}) {
+// prepareInsertValues prepare the insert values, the generated columns are excluded
+func (builder *Builder) prepareInsertValues(v interface{}, columns ...interface{}) ([]interface{}, [][]interface{}, error) {
 
 	if _, ok := v.([][]interface{}); len(columns) > 0 && ok {
 		columns = builder.prepareColumns(columns...)
-		return columns, v.([][]interface{})
+		return builder.excludeGenerated(columns, v.([][]interface{}))
 	}
 
 	values := xun.MakeRows(v)
@@ -65,7 +65,84 @@ func (builder *Builder) prepareInsertValues(v interface{}, columns ...interface{
 		}
 		insertValues = append(insertValues, insertValue)
 	}
-	return columns, insertValues
+	return builder.excludeGenerated(columns, insertValues)
+}
+
+// excludeGenerated remove the generated columns and their values, they can not be written
+func (builder *Builder) excludeGenerated(columns []interface{}, values [][]interface{}) ([]interface{}, [][]interface{}, error) {
+	generated, err := builder.generatedColumns()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(generated) == 0 {
+		return columns, values, nil
+	}
+
+	keeps := []int{}
+	for i, column := range columns {
+		name, ok := column.(string)
+		if !ok || !utils.StringHave(generated, name) {
+			keeps = append(keeps, i)
+		}
+	}
+	if len(keeps) == len(columns) {
+		return columns, values, nil
+	}
+
+	keepColumns := []interface{}{}
+	for _, i := range keeps {
+		keepColumns = append(keepColumns, columns[i])
+	}
+
+	keepValues := [][]interface{}{}
+	for _, row := range values {
+		keepRow := []interface{}{}
+		for _, i := range keeps {
+			if i < len(row) {
+				keepRow = append(keepRow, row[i])
+			}
+		}
+		keepValues = append(keepValues, keepRow)
+	}
+	return keepColumns, keepValues, nil
+}
+
+// generatedColumns get the generated column names of the table which the query is targeting.
+// They are read from the catalog at the first time and cached by the DSN and the table name (see dbal.SetGeneratedColumns),
+// the schema builder removes the cache when the table is changed. The tables not found are not cached.
+func (builder *Builder) generatedColumns() ([]string, error) {
+	name, ok := builder.Query.From.Name.(dbal.Name)
+	if builder.Query.From.Type != "basic" || !ok || builder.Conn.WriteConfig == nil {
+		return nil, nil
+	}
+
+	dsn := builder.Conn.WriteConfig.DSN
+	table := name.Fullname()
+	if generated, has := dbal.GetGeneratedColumns(dsn, table); has {
+		return generated, nil
+	}
+
+	// the schema-qualified table (reporting.daily) is listed in its schema
+	schema, tableName := builder.Grammar.GetSchema(), table
+	if i := strings.LastIndex(table, "."); i > 0 {
+		schema, tableName = table[:i], table[i+1:]
+	}
+	columns, err := builder.Grammar.GetColumnListing(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	generated := []string{}
+	for _, column := range columns {
+		if column.IsGenerated() {
+			generated = append(generated, column.Name)
+		}
+	}
+	dbal.SetGeneratedColumns(dsn, table, generated)
+	return generated, nil
 }
 
 // prepareColumns parepare the select columns
@@ -267,47 +344,53 @@ func (builder *Builder) mapScan(rows *sql.Rows) ([]xun.R, error) {
 	return res, nil
 }
 
-// structScan scan the result from sql.Rows
-func (builder *Builder) structScan(rows *sql.Rows, v interface{}) error {
+// structScan scan the result from sql.Rows, returns the number of the rows.
+// When scanning into a single value, it stops counting at the second row.
+func (builder *Builder) structScan(rows *sql.Rows, v interface{}) (int, error) {
 	defer rows.Close()
 
 	columns, err := rows.Columns()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	structType, vStruct, err := builder.getStructType(v)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	var fieldMap map[string]reflect.StructField
 	if vStruct {
 		fieldMap, err = builder.getFieldMap(structType)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	vPtr := reflect.ValueOf(v)
 	vRows := reflect.Indirect(vPtr)
 	vSlice := vRows.Kind() == reflect.Slice
+	count := 0
 	for rows.Next() {
+		count++
 		dest := reflect.New(structType)
 		if vStruct {
 			values, err := builder.makeStructValues(dest, fieldMap, columns)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			if err := rows.Scan(values...); err != nil {
-				return err
+				return 0, err
 			}
 
 		} else {
 			if err := rows.Scan(v); err != nil {
-				return err
+				return 0, err
 			}
-			return nil
+			if rows.Next() {
+				count++
+			}
+			return count, nil
 		}
 
 		value := reflect.Indirect(dest)
@@ -315,18 +398,21 @@ func (builder *Builder) structScan(rows *sql.Rows, v interface{}) error {
 			vRows = reflect.Append(vRows, value)
 		} else {
 			vPtr.Elem().Set(value)
+			if rows.Next() {
+				count++
+			}
 			break
 		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return err
+		return 0, err
 	}
 
 	if vSlice {
 		vPtr.Elem().Set(vRows)
 	}
-	return nil
+	return count, nil
 }
 
 func (builder *Builder) getStructType(v interface{}) (reflect.Type, bool, error) {
@@ -9,20 +9,23 @@ import (
 	"github.com/yaoapp/xun/utils"
 )
 
-// Update Update records in the database.
+// Update Update records in the database, the generated columns are excluded.
+// The generated columns of the table are read from the catalog at the first update (or insert) and cached,
+// the cache is removed when the table is changed by the schema builder, or by dbal.ForgetGeneratedColumns.
 func (builder *Builder) Update(v interface{}) (int64, error) {
 
 	values := xun.MakeR(v).ToMap()
-	sql, bindings := builder.Grammar.CompileUpdate(builder.Query, values)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	generated, err := builder.generatedColumns()
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
+	for _, name := range generated {
+		delete(values, name)
+	}
+	sql, bindings := builder.Grammar.CompileUpdate(builder.Query, values)
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	res, err := stmt.Exec(bindings...)
+	res, err := builder.exec(builder.UseWrite().DB(), sql, bindings)
 	if err != nil {
 		return 0, err
 	}
@@ -37,6 +40,35 @@ func (builder *Builder) MustUpdate(v interface{}) int64 {
 	return affected
 }
 
+// UpdateWithVersion Update records whose version column equals the expected one, and increment the version in the same statement.
+// Returns xun.ErrStaleRecord when no record is updated, the record has been changed by others or does not exist.
+// The version column given in the values is ignored.
+func (builder *Builder) UpdateWithVersion(v interface{}, column string, expected interface{}) (int64, error) {
+	wrapped := builder.Grammar.Wrap(column)
+	values := xun.MakeR(v).ToMap()
+
+	// the version is always incremented, the given version value is ignored
+	delete(values, column)
+	values[wrapped] = dbal.Raw(fmt.Sprintf("%s+1", wrapped))
+
+	affected, err := builder.Where(column, expected).Update(values)
+	if err != nil {
+		return 0, err
+	}
+
+	if affected == 0 {
+		return 0, xun.ErrStaleRecord
+	}
+	return affected, nil
+}
+
+// MustUpdateWithVersion Update records whose version column equals the expected one, and increment the version in the same statement.
+func (builder *Builder) MustUpdateWithVersion(v interface{}, column string, expected interface{}) int64 {
+	affected, err := builder.UpdateWithVersion(v, column, expected)
+	utils.PanicIF(err)
+	return affected
+}
+
 // UpdateOrInsert Insert or update a record matching the attributes, and fill it with values.
 func (builder *Builder) UpdateOrInsert(attributes interface{}, values ...interface{}) (bool, error) {
 
@@ -78,17 +110,14 @@ func (builder *Builder) MustUpdateOrInsert(attributes interface{}, values ...int
 // Upsert new records or update the existing ones.
 func (builder *Builder) Upsert(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) (int64, error) {
 
-	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileUpsert(builder.Query, columns, values, utils.Flatten(uniqueBy), update)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	columns, values, err := builder.prepareInsertValues(v, columns...)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
+	sql, bindings := builder.Grammar.CompileUpsert(builder.Query, columns, values, utils.Flatten(uniqueBy), update)
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	res, err := stmt.Exec(bindings...)
+	res, err := builder.exec(builder.UseWrite().DB(), sql, bindings)
 	if err != nil {
 		return 0, err
 	}
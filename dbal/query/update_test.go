@@ -1,6 +1,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -103,6 +104,17 @@ func TestUpdateMustUpdate(t *testing.T) {
 	// utils.Println(qb.Table("table_test_update").Select("id", "vote", "score").MustGet())
 }
 
+func TestUpdateMustUpdateGenerated(t *testing.T) {
+	NewTableForGeneratedTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_generated").MustInsert(xun.R{"price": 5, "quantity": 3})
+	affected := qb.Table("table_test_generated").Where("price", 5).MustUpdate(xun.R{"quantity": 4, "total": 100})
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+
+	row := qb.Table("table_test_generated").MustFirst()
+	assert.Equal(t, int64(20), row["total"].(int64), "the generated column should be excluded and computed")
+}
+
 func TestUpdateMustUpdateError(t *testing.T) {
 	NewTableForUpdateTest()
 	assert.Panics(t, func() {
@@ -135,6 +147,109 @@ func TestUpdateMustUpdateWithJoin(t *testing.T) {
 	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
 }
 
+func TestUpdateMustUpdateWithJoinOn(t *testing.T) {
+	NewTableForUpdateTest()
+	NewTableForUpdateJoinTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_update as t1").
+		Join("table_test_update_join as t2", "t2.update_id", "=", "t1.id").
+		Where("t2.status", "VIP")
+
+	// checking sql
+	sql, bindings := qb.Builder().Grammar.CompileUpdate(qb.Builder().Query.Clone(), map[string]interface{}{"vote": 99})
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `update "table_test_update" as "t1" set "vote"=$1 from "table_test_update_join" as "t2" where ("t2"."update_id" = "t1"."id") and ("t2"."status" = $2)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "update `table_test_update` as `t1` inner join `table_test_update_join` as `t2` on `t2`.`update_id` = `t1`.`id` set `vote`=? where `t2`.`status` = ?", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "update `table_test_update` as `t1` set `vote`=? where `rowid` in (select `t1`.`rowid` from `table_test_update` as `t1` inner join `table_test_update_join` as `t2` on `t2`.`update_id` = `t1`.`id` where `t2`.`status` = ?)", sql, "the query sql not equal")
+	}
+	assert.Equal(t, []interface{}{99, "VIP"}, bindings, "the bindings should be [99, VIP]")
+
+	// checking result
+	affected := qb.MustUpdate(xun.R{"vote": 99})
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+	assert.Equal(t, int64(2), qb.Table("table_test_update").Where("vote", 99).MustCount(), "the rows count should be 2")
+}
+
+func TestUpdateMustUpdateWithLeftJoin(t *testing.T) {
+	NewTableForUpdateTest()
+	NewTableForUpdateJoinTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_update as t1").
+		LeftJoin("table_test_update_join as t2", "t2.update_id", "=", "t1.id").
+		WhereNull("t2.id")
+
+	// checking sql
+	sql, _ := qb.Builder().Grammar.CompileUpdate(qb.Builder().Query.Clone(), map[string]interface{}{"vote": 0})
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `update "table_test_update" as "t1" set "vote"=$1 where "ctid" in (select "t1"."ctid" from "table_test_update" as "t1" left join "table_test_update_join" as "t2" on "t2"."update_id" = "t1"."id" where "t2"."id" is null)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "update `table_test_update` as `t1` left join `table_test_update_join` as `t2` on `t2`.`update_id` = `t1`.`id` set `vote`=? where `t2`.`id` is null", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "update `table_test_update` as `t1` set `vote`=? where `rowid` in (select `t1`.`rowid` from `table_test_update` as `t1` left join `table_test_update_join` as `t2` on `t2`.`update_id` = `t1`.`id` where `t2`.`id` is null)", sql, "the query sql not equal")
+	}
+
+	// checking result
+	affected := qb.MustUpdate(xun.R{"vote": 0})
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+	assert.Equal(t, "lee@yao.run", qb.Table("table_test_update").Where("vote", 0).MustValue("email"), "the email of the updated row should be lee@yao.run")
+}
+
+func TestUpdateMustUpdateWithOrderByLimit(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_update").
+		Where("vote", ">", 5).
+		OrderBy("vote", "desc").
+		Limit(1)
+
+	// checking sql
+	sql, _ := qb.Builder().Grammar.CompileUpdate(qb.Builder().Query.Clone(), map[string]interface{}{"vote": 0})
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `update "table_test_update" set "vote"=$1 where "ctid" in (select "table_test_update"."ctid" from "table_test_update" where "vote" > $2 order by "vote" desc limit 1)`, sql, "the query sql not equal")
+	} else if unit.DriverIs("mysql") {
+		assert.Equal(t, "update `table_test_update` set `vote`=? where `vote` > ? order by `vote` desc limit 1", sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "update `table_test_update` set `vote`=? where `rowid` in (select `table_test_update`.`rowid` from `table_test_update` where `vote` > ? order by `vote` desc limit 1)", sql, "the query sql not equal")
+	}
+
+	// checking result
+	affected := qb.MustUpdate(xun.R{"vote": 0})
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+	assert.Equal(t, "ken@yao.run", qb.Table("table_test_update").Where("vote", 0).MustValue("email"), "the email of the updated row should be ken@yao.run")
+}
+
+func TestUpdateMustUpdateWithJoinLimitError(t *testing.T) {
+	if unit.DriverNot("mysql") {
+		return
+	}
+	NewTableForUpdateTest()
+	NewTableForUpdateJoinTest()
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, "MySQL does not support update statements with both joins and limit", func() {
+		qb.Table("table_test_update as t1").
+			Join("table_test_update_join as t2", "t2.update_id", "=", "t1.id").
+			Limit(1).
+			MustUpdate(xun.R{"vote": 0})
+	})
+}
+
+func TestUpdateMustUpdateWithJoinOrderByError(t *testing.T) {
+	if unit.DriverNot("mysql") {
+		return
+	}
+	NewTableForUpdateTest()
+	NewTableForUpdateJoinTest()
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, "MySQL does not support update statements with both joins and order by", func() {
+		qb.Table("table_test_update as t1").
+			Join("table_test_update_join as t2", "t2.update_id", "=", "t1.id").
+			OrderBy("t1.vote", "desc").
+			MustUpdate(xun.R{"vote": 0})
+	})
+}
+
 func TestUpdateMustIncrement(t *testing.T) {
 	NewTableForUpdateTest()
 	qb := getTestBuilder()
@@ -284,10 +399,72 @@ func TestUpdateMustUpdateOrInsertUpdateScore(t *testing.T) {
 	}
 }
 
+func TestUpdateMustUpdateWithVersion(t *testing.T) {
+	NewTableForUpdateVersionTest()
+	qb := getTestBuilder()
+
+	row := qb.Table("table_test_update_version").Where("id", 1).MustFirst()
+	assert.Equal(t, int64(1), row.Get("version"), "The version of the new row should be 1")
+
+	affected := qb.Table("table_test_update_version").
+		Where("id", 1).
+		MustUpdateWithVersion(xun.R{"title": "First Edit"}, "version", row.Get("version"))
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+
+	row = qb.Table("table_test_update_version").Where("id", 1).MustFirst()
+	assert.Equal(t, "First Edit", row.Get("title"), "The title should be First Edit")
+	assert.Equal(t, int64(2), row.Get("version"), "The version should be 2")
+
+	// the stale version
+	_, err := qb.Table("table_test_update_version").
+		Where("id", 1).
+		UpdateWithVersion(xun.R{"title": "Stale Edit"}, "version", 1)
+	assert.True(t, errors.Is(err, xun.ErrStaleRecord), "The error should be xun.ErrStaleRecord")
+
+	row = qb.Table("table_test_update_version").Where("id", 1).MustFirst()
+	assert.Equal(t, "First Edit", row.Get("title"), "The title should be First Edit")
+	assert.Equal(t, int64(2), row.Get("version"), "The version should be 2")
+
+	assert.PanicsWithError(t, xun.ErrStaleRecord.Error(), func() {
+		qb.Table("table_test_update_version").Where("id", 100).MustUpdateWithVersion(xun.R{"title": "Missing"}, "version", 1)
+	})
+}
+
+func TestUpdateMustUpdateWithVersionGiven(t *testing.T) {
+	NewTableForUpdateVersionTest()
+	qb := getTestBuilder()
+
+	// the given version should be ignored
+	affected := qb.Table("table_test_update_version").
+		Where("id", 1).
+		MustUpdateWithVersion(xun.R{"title": "Given Edit", "version": 10}, "version", 1)
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+
+	row := qb.Table("table_test_update_version").Where("id", 1).MustFirst()
+	assert.Equal(t, "Given Edit", row.Get("title"), "The title should be Given Edit")
+	assert.Equal(t, int64(2), row.Get("version"), "The version should be 2")
+}
+
 // clean the test data
 func TestUpdateClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
 	builder.DropTableIfExists("table_test_update")
+	builder.DropTableIfExists("table_test_update_join")
+	builder.DropTableIfExists("table_test_update_version")
+}
+
+func NewTableForUpdateVersionTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_update_version")
+	builder.MustCreateTable("table_test_update_version", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("title")
+		table.Version()
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_update_version").MustInsert(xun.R{"title": "Draft"})
 }
 
 func NewTableForUpdateTest() {
@@ -314,3 +491,21 @@ func NewTableForUpdateTest() {
 		{"email": "ben@yao.run", "name": "Ben", "vote": 6, "score": 48.12, "score_grade": 99.27, "status": "DONE", "created_at": "2021-03-25 18:15:29"},
 	})
 }
+
+func NewTableForUpdateJoinTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_update_join")
+	builder.MustCreateTable("table_test_update_join", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("update_id").Index()
+		table.String("status", 20)
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_update_join").Insert([]xun.R{
+		{"update_id": 1, "status": "VIP"},
+		{"update_id": 3, "status": "VIP"},
+		{"update_id": 4, "status": "NORMAL"},
+	})
+}
@@ -624,6 +624,53 @@ func (builder *Builder) whereDateTime(dateType string, column interface{}, opera
 	return builder
 }
 
+// WhereDistanceWithin Add a "where distance within" statement to the query, the distance to the point is not greater than the given meters.
+// The distance is the spherical distance of the longitude and latitude (MySQL 5.7.6+ and PostGIS).
+// SQLite stores the geometries as the WKT text and has no spatial functions, the method panics on SQLite.
+func (builder *Builder) WhereDistanceWithin(column interface{}, point xun.Point, meters float64) Query {
+	return builder.whereDistanceWithin(column, point, meters, "and")
+}
+
+// OrWhereDistanceWithin Add an "or where distance within" statement to the query, the method panics on SQLite (see WhereDistanceWithin).
+func (builder *Builder) OrWhereDistanceWithin(column interface{}, point xun.Point, meters float64) Query {
+	return builder.whereDistanceWithin(column, point, meters, "or")
+}
+
+// whereDistanceWithin Add a where distance within statement to the query, the point is inlined as the WKT text.
+// The distance is compiled at once, so the grammars without the spatial functions reject it here instead of at running.
+func (builder *Builder) whereDistanceWithin(column interface{}, point xun.Point, meters float64, boolean string) Query {
+	builder.Grammar.CompileDistance(column, point.WKT())
+	builder.Query.Wheres = append(builder.Query.Wheres, dbal.Where{
+		Type:    "distance",
+		Column:  column,
+		Value:   point.WKT(),
+		Values:  []interface{}{meters},
+		Boolean: boolean,
+		Offset:  1,
+	})
+	builder.Query.AddBinding("where", meters)
+	return builder
+}
+
+// WhereMorphedTo Add a where clause to the query for the polymorphic relation, the "{name}_type" and "{name}_id" columns should be matched.
+//
+//	qb.Table("comments").WhereMorphedTo("commentable", "post", 1)
+func (builder *Builder) WhereMorphedTo(name string, typ string, id interface{}) Query {
+	return builder.whereMorphedTo(name, typ, id, "and")
+}
+
+// OrWhereMorphedTo Add an "or where" clause to the query for the polymorphic relation.
+func (builder *Builder) OrWhereMorphedTo(name string, typ string, id interface{}) Query {
+	return builder.whereMorphedTo(name, typ, id, "or")
+}
+
+// whereMorphedTo Add a nested where clause for the polymorphic relation to the query.
+func (builder *Builder) whereMorphedTo(name string, typ string, id interface{}, boolean string) Query {
+	return builder.Where(func(qb Query) {
+		qb.Where(name+"_type", typ).Where(name+"_id", id)
+	}, boolean)
+}
+
 // When Apply the callback's query changes if the given "value" is true.
 func (builder *Builder) When(value bool, callback func(qb Query, value bool), defaults ...func(qb Query, value bool)) Query {
 	if value {
@@ -1519,10 +1519,103 @@ func TestWhereUnlessValueIsFalseWidthDefaults(t *testing.T) {
 	checkVoteGT(t, qb)
 }
 
+func TestWhereWhereDistanceWithin(t *testing.T) {
+	supported := NewTableForSpatialTest()
+	qb := getTestBuilder()
+	if unit.DriverIs("sqlite3") {
+		assert.PanicsWithError(t, "SQLite does not support the spatial functions, the distance can not be computed", func() {
+			qb.Table("table_test_where_spatial").WhereDistanceWithin("location", xun.MakePoint(116.397128, 39.916527), 5000)
+		}, "the condition should be rejected when it is added")
+		return
+	}
+
+	qb.Table("table_test_where_spatial").
+		WhereDistanceWithin("location", xun.MakePoint(116.397128, 39.916527), 5000).
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select * from "table_test_where_spatial" where ST_DWithin("location"::geography, ST_GeogFromText('POINT(116.397128 39.916527)'), $1) order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select * from `table_test_where_spatial` where ST_Distance_Sphere(`location`, ST_GeomFromText('POINT(116.397128 39.916527)')) <= ? order by `id` asc", sql, "the query sql not equal")
+	}
+
+	if !supported || unit.Is("mysql5.6") {
+		return
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should be have 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "Tiananmen", rows[0]["name"], "the name of the 1st row should be Tiananmen")
+		assert.Equal(t, "Wangfujing", rows[1]["name"], "the name of the 2nd row should be Wangfujing")
+	}
+}
+
+func TestWhereWhereMorphedTo(t *testing.T) {
+	NewTableForMorphsTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_where_morphs").
+		WhereMorphedTo("commentable", "post", 1).
+		OrWhereMorphedTo("commentable", "video", 2).
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select * from "table_test_where_morphs" where ("commentable_type" = $1 and "commentable_id" = $2) or ("commentable_type" = $3 and "commentable_id" = $4) order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select * from `table_test_where_morphs` where (`commentable_type` = ? and `commentable_id` = ?) or (`commentable_type` = ? and `commentable_id` = ?) order by `id` asc", sql, "the query sql not equal")
+	}
+
+	bindings := qb.GetBindings()
+	assert.Equal(t, []interface{}{"post", 1, "video", 2}, bindings, "the bindings should be the types and ids")
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should be have 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "Nice post", rows[0]["body"], "the body of the 1st row should be Nice post")
+		assert.Equal(t, "Nice video", rows[1]["body"], "the body of the 2nd row should be Nice video")
+	}
+}
+
 // clean the test data
 func TestWhereClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
 	builder.DropTableIfExists("table_test_where")
+	builder.DropTableIfExists("table_test_where_spatial")
+	builder.DropTableIfExists("table_test_where_morphs")
+}
+
+// NewTableForSpatialTest create the spatial test table, returns false if the spatial types are not supported (Postgres without PostGIS)
+func NewTableForSpatialTest() bool {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	if unit.DriverIs("postgres") {
+		count := 0
+		builder.DB().Get(&count, "SELECT COUNT(*) FROM pg_extension WHERE extname = 'postgis'")
+		if count == 0 {
+			return false
+		}
+	}
+
+	builder.DropTableIfExists("table_test_where_spatial")
+	builder.MustCreateTable("table_test_where_spatial", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+		table.Point("location")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_where_spatial").MustInsert([]xun.R{
+		{"name": "Tiananmen", "location": xun.MakePoint(116.397128, 39.916527)},
+		{"name": "Wangfujing", "location": xun.MakePoint(116.417, 39.915)},
+		{"name": "Shanghai", "location": xun.MakePoint(121.4737, 31.2304)},
+	})
+	return true
 }
 
 func NewTableForWhereTest() {
@@ -1550,6 +1643,25 @@ func NewTableForWhereTest() {
 	})
 }
 
+// NewTableForMorphsTest create the polymorphic relation test table
+func NewTableForMorphsTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_where_morphs")
+	builder.MustCreateTable("table_test_where_morphs", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("body")
+		table.Morphs("commentable")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_where_morphs").Insert([]xun.R{
+		{"body": "Nice post", "commentable_type": "post", "commentable_id": 1},
+		{"body": "Other post", "commentable_type": "post", "commentable_id": 2},
+		{"body": "Nice video", "commentable_type": "video", "commentable_id": 2},
+	})
+}
+
 func checkVoteGT(t *testing.T, qb Query) {
 	// checking sql
 	sql := qb.ToSQL()
@@ -0,0 +1,66 @@
+package dbal
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy the policy of re-running the statements (or the transaction closures) failed with a retryable error.
+// Only the deadlocks (ErrDeadlock) and the serialization failures (ErrSerialization) are retried.
+type RetryPolicy struct {
+	Times      int                                              // The maximum number of retries, 0 means no retry.
+	Backoff    time.Duration                                    // The base wait duration before the first retry, doubles on every retry.
+	MaxBackoff time.Duration                                    // The upper limit of the wait duration, 0 means no limit.
+	OnRetry    func(attempt int, err error, wait time.Duration) // The hook called before every retry, the attempt starts from 1.
+}
+
+// IsRetryable determine if the error could be fixed by running the statement again
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrDeadlock) || errors.Is(err, ErrSerialization)
+}
+
+// Run call the given function, and call it again when it returns a retryable error, until it success or the retries are exhausted.
+func (policy *RetryPolicy) Run(fn func() error) error {
+	err := fn()
+	if policy == nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= policy.Times && IsRetryable(err); attempt++ {
+		wait := policy.Wait(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, wait)
+		}
+		time.Sleep(wait)
+		err = fn()
+	}
+	return err
+}
+
+// Wait get the jittered exponential wait duration before the given attempt.
+// The duration is a random value between the half and the full of Backoff * 2^(attempt-1)
+func (policy *RetryPolicy) Wait(attempt int) time.Duration {
+	if policy.Backoff <= 0 {
+		return 0
+	}
+
+	wait := policy.Backoff
+	for i := 1; i < attempt && wait < math.MaxInt64/2; i++ {
+		if policy.MaxBackoff > 0 && wait >= policy.MaxBackoff {
+			break
+		}
+		wait = wait * 2
+	}
+
+	if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+		wait = policy.MaxBackoff
+	}
+
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
@@ -378,19 +378,62 @@ func (table *Table) Year(name string) *Column {
 	return column
 }
 
-// Timestamps Add nullable creation and update timestamps to the table.
+// Geometry Create a new geometry column on the table.
+// The spatial columns are the MySQL spatial types, the PostGIS geometry types on Postgres and the WKT text on SQLite.
+func (table *Table) Geometry(name string) *Column {
+	column := table.newColumn(name).SetType("geometry")
+	table.putColumn(column)
+	return column
+}
+
+// GeometryCollection Create a new geometry collection column on the table.
+func (table *Table) GeometryCollection(name string) *Column {
+	column := table.newColumn(name).SetType("geometryCollection")
+	table.putColumn(column)
+	return column
+}
+
+// Point Create a new point column on the table, the values could be written and scanned as xun.Point.
+func (table *Table) Point(name string) *Column {
+	column := table.newColumn(name).SetType("point")
+	table.putColumn(column)
+	return column
+}
+
+// MultiPoint Create a new multi-point column on the table.
+func (table *Table) MultiPoint(name string) *Column {
+	column := table.newColumn(name).SetType("multiPoint")
+	table.putColumn(column)
+	return column
+}
+
+// Polygon Create a new polygon column on the table.
+func (table *Table) Polygon(name string) *Column {
+	column := table.newColumn(name).SetType("polygon")
+	table.putColumn(column)
+	return column
+}
+
+// MultiPolygon Create a new multi-polygon column on the table.
+func (table *Table) MultiPolygon(name string) *Column {
+	column := table.newColumn(name).SetType("multiPolygon")
+	table.putColumn(column)
+	return column
+}
+
+// Timestamps Add nullable creation and update timestamps to the table, the "updated_at" is set to the current time when the row is updated.
 func (table *Table) Timestamps(args ...int) map[string]*Column {
 	return map[string]*Column{
 		"created_at": table.Timestamp("created_at", args...).NotNull().SetDefaultRaw("NOW()").Index(),
-		"updated_at": table.Timestamp("updated_at", args...).Null().Index(),
+		"updated_at": table.Timestamp("updated_at", args...).Null().UseCurrentOnUpdate().Index(),
 	}
 }
 
-// TimestampsTz Add creation and update timestampTz columns to the table.
+// TimestampsTz Add creation and update timestampTz columns to the table, the "updated_at" is set to the current time when the row is updated.
 func (table *Table) TimestampsTz(args ...int) map[string]*Column {
 	return map[string]*Column{
 		"created_at": table.TimestampTz("created_at", args...).NotNull().SetDefaultRaw("NOW()").Index(),
-		"updated_at": table.TimestampTz("updated_at", args...).Null().Index(),
+		"updated_at": table.TimestampTz("updated_at", args...).Null().UseCurrentOnUpdate().Index(),
 	}
 }
 
@@ -423,3 +466,75 @@ func (table *Table) DropSoftDeletes() {
 func (table *Table) DropSoftDeletesTz() {
 	table.DropSoftDeletes()
 }
+
+// Version Add a "version" counter column for the optimistic locking. the default name is "version".
+func (table *Table) Version(name ...string) *Column {
+	column := "version"
+	if len(name) > 0 && name[0] != "" {
+		column = name[0]
+	}
+	return table.UnsignedBigInteger(column).SetDefault(1).NotNull()
+}
+
+// Polymorphic types
+
+// Morphs Add the "{name}_type" and "{name}_id" columns and the composite index for a polymorphic relation, the id is an unsigned big integer.
+//
+//	table.Morphs("commentable") // commentable_type, commentable_id
+func (table *Table) Morphs(name string) map[string]*Column {
+	return table.morphs(name, table.UnsignedBigInteger, false)
+}
+
+// NullableMorphs Add the nullable "{name}_type" and "{name}_id" columns and the composite index for a polymorphic relation.
+func (table *Table) NullableMorphs(name string) map[string]*Column {
+	return table.morphs(name, table.UnsignedBigInteger, true)
+}
+
+// UUIDMorphs Add the "{name}_type" and "{name}_id" columns and the composite index for a polymorphic relation, the id is an uuid.
+func (table *Table) UUIDMorphs(name string) map[string]*Column {
+	return table.morphs(name, table.UUID, false)
+}
+
+// NullableUUIDMorphs Add the nullable "{name}_type" and "{name}_id" columns and the composite index, the id is an uuid.
+func (table *Table) NullableUUIDMorphs(name string) map[string]*Column {
+	return table.morphs(name, table.UUID, true)
+}
+
+// ULIDMorphs Add the "{name}_type" and "{name}_id" columns and the composite index for a polymorphic relation, the id is an ulid (char 26).
+func (table *Table) ULIDMorphs(name string) map[string]*Column {
+	return table.morphs(name, func(id string) *Column { return table.Char(id, 26) }, false)
+}
+
+// NullableULIDMorphs Add the nullable "{name}_type" and "{name}_id" columns and the composite index, the id is an ulid (char 26).
+func (table *Table) NullableULIDMorphs(name string) map[string]*Column {
+	return table.morphs(name, func(id string) *Column { return table.Char(id, 26) }, true)
+}
+
+// DropMorphs drop the "{name}_type" and "{name}_id" columns and the composite index of the polymorphic relation.
+func (table *Table) DropMorphs(name string) {
+	table.DropIndex(morphsIndexName(name))
+	table.DropColumn(name+"_type", name+"_id")
+}
+
+// morphs add the "{name}_type" column, then the "{name}_id" column created by newID, and the composite index on them
+func (table *Table) morphs(name string, newID func(name string) *Column, nullable bool) map[string]*Column {
+	typ := table.String(name+"_type", 200)
+	id := newID(name + "_id")
+	if nullable {
+		typ.Null()
+		id.Null()
+	} else {
+		typ.NotNull()
+		id.NotNull()
+	}
+	table.AddIndex(morphsIndexName(name), typ.Name, id.Name)
+	return map[string]*Column{
+		typ.Name: typ,
+		id.Name:  id,
+	}
+}
+
+// morphsIndexName get the name of the composite index of the polymorphic relation
+func morphsIndexName(name string) string {
+	return name + "_morph_index"
+}
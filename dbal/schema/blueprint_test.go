@@ -1044,6 +1044,82 @@ func TestBlueprintSoftDeletesWithP(t *testing.T) {
 	}
 }
 
+func TestBlueprintVersion(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_blueprint")
+	builder.CreateTable("table_test_blueprint", func(table Blueprint) {
+		table.ID("id")
+		table.Version()
+		table.Version("revision")
+	})
+
+	table := testGetTable()
+	for _, name := range []string{"version", "revision"} {
+		column := table.GetColumn(name)
+		assert.True(t, column != nil, "the column %s should be created", name)
+		if column != nil {
+			assert.Equal(t, "bigInteger", column.Type, "the column %s type should be bigInteger", name)
+			assert.False(t, column.Nullable, "the column %s nullable should be false", name)
+		}
+	}
+}
+
+func TestBlueprintMorphs(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_blueprint")
+	builder.CreateTable("table_test_blueprint", func(table Blueprint) {
+		table.ID("id")
+		table.Morphs("commentable")
+		table.NullableUUIDMorphs("taggable")
+		table.ULIDMorphs("attachable")
+	})
+
+	table := testGetTable()
+	for _, name := range []string{"commentable", "taggable", "attachable"} {
+		typ := table.GetColumn(name + "_type")
+		id := table.GetColumn(name + "_id")
+		assert.True(t, typ != nil && id != nil, "the columns of %s should be created", name)
+		assert.True(t, table.HasIndex(name+"_morph_index"), "the index of %s should be created", name)
+		if typ == nil || id == nil {
+			continue
+		}
+		assert.Equal(t, "string", typ.Type, "the column %s_type type should be string", name)
+
+		// SQLite creates the not null columns without the default value as nullable
+		nullable := name == "taggable" || unit.DriverIs("sqlite3")
+		assert.Equal(t, nullable, typ.Nullable, "the column %s_type nullable should be %v", name, nullable)
+		assert.Equal(t, nullable, id.Nullable, "the column %s_id nullable should be %v", name, nullable)
+	}
+
+	assert.Equal(t, "bigInteger", table.GetColumn("commentable_id").Type, "the column commentable_id type should be bigInteger")
+	assert.Equal(t, "char", table.GetColumn("attachable_id").Type, "the column attachable_id type should be char")
+
+	positions := map[string]int{}
+	for i, column := range table.Get().Table.Columns {
+		positions[column.Name] = i
+	}
+	for _, name := range []string{"commentable", "taggable", "attachable"} {
+		assert.Less(t, positions[name+"_type"], positions[name+"_id"], "the column %s_type should be created before %s_id", name, name)
+	}
+}
+
+func TestBlueprintDropMorphs(t *testing.T) {
+	if unit.DriverIs("sqlite3") {
+		return
+	}
+	TestBlueprintMorphs(t)
+	builder := getTestBuilder()
+	err := builder.AlterTable("table_test_blueprint", func(table Blueprint) {
+		table.DropMorphs("commentable")
+	})
+	assert.True(t, err == nil, "the alter method should be return nil")
+	table := testGetTable()
+	assert.True(t, table.GetColumn("commentable_type") == nil, "the column commentable_type should be nil")
+	assert.True(t, table.GetColumn("commentable_id") == nil, "the column commentable_id should be nil")
+	assert.False(t, table.HasIndex("commentable_morph_index"), "the index commentable_morph_index should be dropped")
+	assert.True(t, table.GetColumn("taggable_id") != nil, "the column taggable_id should be kept")
+}
+
 func TestBlueprintDropSoftDeletes(t *testing.T) {
 	if unit.DriverIs("sqlite3") {
 		return
@@ -1118,6 +1194,25 @@ func TestBlueprintDropSoftDeletesTz(t *testing.T) {
 	assert.True(t, table.GetColumn("deleted_at") == nil, "the column deleted_at should be nil")
 }
 
+func TestBlueprintSpatial(t *testing.T) {
+	if !testSpatialSupported() {
+		return
+	}
+
+	types := map[string]columnFunc{
+		"geometry":           func(table Blueprint, name string, args ...int) *Column { return table.Geometry(name) },
+		"geometryCollection": func(table Blueprint, name string, args ...int) *Column { return table.GeometryCollection(name) },
+		"point":              func(table Blueprint, name string, args ...int) *Column { return table.Point(name) },
+		"multiPoint":         func(table Blueprint, name string, args ...int) *Column { return table.MultiPoint(name) },
+		"polygon":            func(table Blueprint, name string, args ...int) *Column { return table.Polygon(name) },
+		"multiPolygon":       func(table Blueprint, name string, args ...int) *Column { return table.MultiPolygon(name) },
+	}
+	for typeName, create := range types {
+		testCreateTable(t, create, true)
+		testCheckColumnsAfterCreate(unit.Always, t, typeName, nil, true)
+	}
+}
+
 // clean the test data
 func TestBlueprintClean(t *testing.T) {
 	builder := getTestBuilder()
@@ -1201,6 +1296,16 @@ func testAlterTableSafe(executable bool, t *testing.T, create columnFunc, alter
 	}
 }
 
+// the spatial types of Postgres are provided by PostGIS
+func testSpatialSupported() bool {
+	if unit.DriverNot("postgres") {
+		return true
+	}
+	count := 0
+	getTestBuilder().DB().Get(&count, "SELECT COUNT(*) FROM pg_extension WHERE extname = 'postgis'")
+	return count > 0
+}
+
 func testGetTable() Blueprint {
 	builder := getTestBuilder()
 	return builder.MustGetTable("table_test_blueprint")
@@ -105,6 +105,16 @@ func (builder *Builder) table(name string) *Table {
 	return table
 }
 
+// forgetGenerated remove the cached generated columns of the table, the query builder reloads them
+func (builder *Builder) forgetGenerated(name string) {
+	dbal.ForgetGeneratedColumns(builder.Conn.WriteConfig.DSN, name)
+}
+
+// forgetAllGenerated remove the cached generated columns of all tables, used when the schemas or the databases are changed
+func (builder *Builder) forgetAllGenerated() {
+	dbal.ForgetAllGeneratedColumns(builder.Conn.WriteConfig.DSN)
+}
+
 // SetOption set the option of connection
 func (builder *Builder) SetOption(option *dbal.Option) {
 	builder.Conn.Option = option
@@ -219,6 +229,26 @@ func (builder *Builder) GetTable(name string) (Blueprint, error) {
 		}
 	}
 
+	// attaching foreign keys
+	for _, foreign := range table.Table.ForeignKeys {
+		name := foreign.Name
+		table.ForeignKeyNames = append(table.ForeignKeyNames, name)
+		table.ForeignKeyMap[name] = &ForeignKey{
+			ForeignKey: foreign,
+			Table:      table,
+		}
+	}
+
+	// attaching constraints
+	for _, constraint := range table.Table.Constraints {
+		name := constraint.Name
+		table.ConstraintNames = append(table.ConstraintNames, name)
+		table.ConstraintMap[name] = &Constraint{
+			Constraint: constraint,
+			Table:      table,
+		}
+	}
+
 	// attaching primary
 	if table.Table.Primary != nil {
 		table.Primary = &Primary{
@@ -240,7 +270,11 @@ func (builder *Builder) MustGetTable(name string) Blueprint {
 // CreateTable create a new table on the schema.
 func (builder *Builder) CreateTable(name string, callback func(table Blueprint), options ...dbal.CreateTableOption) error {
 	table := builder.table(name)
+	if len(options) > 0 {
+		table.setOption(options[0])
+	}
 	callback(table)
+	builder.forgetGenerated(table.GetFullName())
 	err := builder.Grammar.CreateTable(table.Table, options...)
 	if err != nil {
 		return err
@@ -258,6 +292,7 @@ func (builder *Builder) MustCreateTable(name string, callback func(table Bluepri
 func (builder *Builder) AlterTable(name string, callback func(table Blueprint)) error {
 	table := builder.MustGetTable(name)
 	callback(table)
+	builder.forgetGenerated(table.GetFullName())
 	err := builder.Grammar.AlterTable(table.Get().Table)
 	if err != nil {
 		return err
@@ -274,6 +309,7 @@ func (builder *Builder) MustAlterTable(name string, callback func(table Blueprin
 // DropTable Indicate that the table should be dropped.
 func (builder *Builder) DropTable(name string) error {
 	table := builder.table(name)
+	builder.forgetGenerated(table.GetFullName())
 	return builder.Grammar.DropTable(table.GetFullName())
 }
 
@@ -287,6 +323,7 @@ func (builder *Builder) MustDropTable(name string) {
 // DropTableIfExists Indicate that the table should be dropped if it exists.
 func (builder *Builder) DropTableIfExists(name string) error {
 	table := builder.table(name)
+	builder.forgetGenerated(table.GetFullName())
 	return builder.Grammar.DropTableIfExists(table.GetFullName())
 }
 
@@ -300,6 +337,8 @@ func (builder *Builder) MustDropTableIfExists(name string) {
 func (builder *Builder) RenameTable(old string, new string) error {
 	oldTab := builder.table(old)
 	newTab := builder.table(new)
+	builder.forgetGenerated(oldTab.GetFullName())
+	builder.forgetGenerated(newTab.GetFullName())
 	return builder.Grammar.RenameTable(oldTab.GetFullName(), newTab.GetFullName())
 }
 
@@ -310,6 +349,44 @@ func (builder *Builder) MustRenameTable(old string, new string) Blueprint {
 	return builder.table(new)
 }
 
+// Pretend run the callback in the pretend (dry-run) mode, return the schema statements generated in order.
+// The statements are not executed, but the GetTable, HasTable ... still read the live catalog.
+//
+//	stmts, err := builder.Pretend(func(schema Schema) {
+//		schema.MustAlterTable("users", func(table Blueprint) { table.String("nickname", 50) })
+//	})
+func (builder *Builder) Pretend(callback func(schema Schema)) (stmts []string, err error) {
+	option := dbal.Option{}
+	if builder.Conn.Option != nil {
+		option = *builder.Conn.Option
+	}
+	option.Pretend = &dbal.Pretend{Statements: []string{}}
+
+	grammar, err := builder.Grammar.NewWith(builder.Conn.Write, builder.Conn.WriteConfig, &option)
+	if err != nil {
+		return nil, err
+	}
+
+	pretend := *builder
+	pretend.Grammar = grammar
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+		stmts = option.Pretend.Statements
+	}()
+	callback(&pretend)
+	return option.Pretend.Statements, nil
+}
+
+// MustPretend run the callback in the pretend (dry-run) mode, return the schema statements generated in order.
+func (builder *Builder) MustPretend(callback func(schema Schema)) []string {
+	stmts, err := builder.Pretend(callback)
+	utils.PanicIF(err)
+	return stmts
+}
+
 // GetVersion get the version of the connection database
 func (builder *Builder) GetVersion() (*dbal.Version, error) {
 
@@ -550,6 +550,126 @@ func TestBuilderDB(t *testing.T) {
 	}
 }
 
+func TestBuilderPretend(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_pretend")
+	builder.DropTableIfExists("table_test_pretend_new")
+	builder.MustCreateTable("table_test_pretend", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index()
+	})
+
+	stmts, err := builder.Pretend(func(schema Schema) {
+		schema.MustCreateTable("table_test_pretend_new", func(table Blueprint) {
+			table.ID("id")
+			table.String("title", 80).Index()
+		})
+		schema.MustAlterTable("table_test_pretend", func(table Blueprint) {
+			table.String("nickname", 50)
+			table.DropColumn("name")
+		})
+		schema.MustDropTable("table_test_pretend")
+	})
+	assert.Nil(t, err, "the pretend should be succeed")
+	assert.True(t, len(stmts) > 4, "the statements should be collected")
+	if len(stmts) > 4 {
+		assert.Contains(t, stmts[0], "CREATE TABLE", "the first statement should create the table")
+		assert.Contains(t, stmts[len(stmts)-1], "DROP TABLE", "the last statement should drop the table")
+	}
+
+	assert.False(t, builder.MustHasTable("table_test_pretend_new"), "the table_test_pretend_new should not be created")
+	table := builder.MustGetTable("table_test_pretend")
+	assert.True(t, table.HasColumn("name"), "the name column should not be dropped")
+	assert.False(t, table.HasColumn("nickname"), "the nickname column should not be added")
+
+	_, err = builder.Pretend(func(schema Schema) {
+		schema.MustAlterTable("table_test_pretend_not_exists", func(table Blueprint) {})
+	})
+	assert.NotNil(t, err, "the pretend should return the error")
+
+	builder.MustCreateTable("table_test_pretend_new", func(table Blueprint) { table.ID("id") })
+	assert.True(t, builder.MustHasTable("table_test_pretend_new"), "the builder should execute the statements after pretending")
+	builder.MustDropTable("table_test_pretend_new")
+	builder.MustDropTable("table_test_pretend")
+}
+
+func TestBuilderTableOptions(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_options")
+	option := dbal.CreateTableOption{Comment: "the user's options", AutoIncrementStart: 1000, FillFactor: 70}
+	if unit.DriverIs("mysql") {
+		option.Engine = "InnoDB"
+		option.Charset = "utf8mb4"
+		option.Collation = "utf8mb4_bin"
+		option.RowFormat = "COMPACT"
+	}
+
+	err := builder.CreateTable("table_test_options", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+		code := table.String("code", 20)
+		if unit.DriverIs("mysql") {
+			code.SetCharset("latin1").SetCollation("latin1_bin")
+		} else if unit.DriverIs("postgres") {
+			code.SetCollation("C")
+		}
+	}, option)
+	assert.Nil(t, err, "the table should be created")
+
+	table := builder.MustGetTable("table_test_options").Get()
+	if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "", table.Table.Comment, "the table options are not supported")
+	} else {
+		assert.Equal(t, "the user's options", table.Table.Comment, "the comment should be read from the catalog")
+		assert.Equal(t, 1000, table.Table.AutoIncrement, "the auto-increment should start from 1000")
+	}
+
+	if unit.DriverIs("mysql") {
+		assert.Equal(t, "InnoDB", table.Table.Engine, "the engine should be InnoDB")
+		assert.Equal(t, "utf8mb4", table.Table.Charset, "the charset should be utf8mb4")
+		assert.Equal(t, "utf8mb4_bin", table.Table.Collation, "the collation should be utf8mb4_bin")
+		assert.Equal(t, "COMPACT", table.Table.RowFormat, "the row format should be COMPACT")
+		assert.Equal(t, "latin1", utils.StringVal(table.GetColumn("code").Charset), "the charset of the column should be latin1")
+		assert.Equal(t, "latin1_bin", utils.StringVal(table.GetColumn("code").Collation), "the collation of the column should be latin1_bin")
+	} else if unit.DriverIs("postgres") {
+		assert.Equal(t, 70, table.Table.FillFactor, "the fill factor should be 70")
+		assert.Equal(t, "C", utils.StringVal(table.GetColumn("code").Collation), "the collation of the column should be C")
+	}
+
+	err = builder.AlterTable("table_test_options", func(table Blueprint) {
+		table.String("nickname", 50)
+		table.SetComment("the changed options")
+		table.SetFillFactor(80)
+		table.SetAutoIncrementStart(2000)
+	})
+	assert.Nil(t, err, "the table options should be changed")
+
+	table = builder.MustGetTable("table_test_options").Get()
+	assert.True(t, table.HasColumn("nickname"), "the nickname column should be added")
+	if unit.DriverIs("mysql") {
+		assert.Equal(t, "the changed options", table.Table.Comment, "the comment should be changed")
+		assert.Equal(t, 2000, table.Table.AutoIncrement, "the auto-increment should start from 2000")
+	} else if unit.DriverIs("postgres") {
+		assert.Equal(t, "the changed options", table.Table.Comment, "the comment should be changed")
+		assert.Equal(t, 80, table.Table.FillFactor, "the fill factor should be 80")
+		assert.Equal(t, 2000, table.Table.AutoIncrement, "the auto-increment should start from 2000")
+	}
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustAlterTable("table_test_options", func(table Blueprint) {
+			table.SetComment("")
+		})
+	})
+	if unit.DriverIs("sqlite3") {
+		assert.Equal(t, 0, len(stmts), "the table options are not supported")
+	} else {
+		assert.Equal(t, 1, len(stmts), "the comment should be removed")
+	}
+	builder.MustDropTable("table_test_options")
+}
+
 // Utils..........
 
 func checkTableAlterTable(t *testing.T, table Blueprint) {
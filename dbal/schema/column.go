@@ -154,6 +154,51 @@ func (column *Column) AutoIncrement() *Column {
 	return column
 }
 
+// StoredAs set the column as a stored generated column, the value is computed by the expression when the row is written.
+// The generated columns are excluded from the insert and update statements of the query builder.
+//
+//	table.String("full_name", 160).StoredAs("first_name || ' ' || last_name")
+func (column *Column) StoredAs(expression string) *Column {
+	column.Extra = utils.StringPtr("StoredGenerated")
+	column.GenerationExpression = expression
+	return column
+}
+
+// VirtualAs set the column as a virtual generated column, the value is computed by the expression when the row is read.
+// Postgres does not support the virtual generated columns, the column is stored instead.
+func (column *Column) VirtualAs(expression string) *Column {
+	column.Extra = utils.StringPtr("VirtualGenerated")
+	column.GenerationExpression = expression
+	return column
+}
+
+// After place the column after the given one when it is added to an existing table (MySQL only)
+func (column *Column) After(name string) *Column {
+	column.Column.After = name
+	column.Column.First = false
+	return column
+}
+
+// First place the column as the first one when it is added to an existing table (MySQL only)
+func (column *Column) First() *Column {
+	column.Column.First = true
+	column.Column.After = ""
+	return column
+}
+
+// UseCurrent set the default value of the timestamp column to the current time
+func (column *Column) UseCurrent() *Column {
+	return column.SetDefaultRaw("NOW()")
+}
+
+// UseCurrentOnUpdate set the timestamp column to the current time when the row is updated.
+// MySQL uses ON UPDATE CURRENT_TIMESTAMP, the other drivers create a trigger for the column,
+// so the value is maintained even if the row is updated outside the query builder.
+func (column *Column) UseCurrentOnUpdate() *Column {
+	column.OnUpdateCurrent = true
+	return column
+}
+
 // SetLength set the column Length attribute to the given length
 func (column *Column) SetLength(length int) *Column {
 	if column.MaxLength == 0 {
@@ -178,6 +223,18 @@ func (column *Column) SetComment(comment string) *Column {
 	return column
 }
 
+// SetCharset set the character set of the column (MySQL)
+func (column *Column) SetCharset(charset string) *Column {
+	column.Charset = &charset
+	return column
+}
+
+// SetCollation set the collation of the column
+func (column *Column) SetCollation(collation string) *Column {
+	column.Collation = &collation
+	return column
+}
+
 // SetDefault set the column default attribute to the given type name
 func (column *Column) SetDefault(v interface{}) *Column {
 	column.Default = v
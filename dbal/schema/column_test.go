@@ -64,9 +64,6 @@ func TestColumnRenameColumnFail(t *testing.T) {
 }
 
 func TestColumnDropColumn(t *testing.T) {
-	if unit.DriverIs("sqlite3") {
-		return
-	}
 	defer unit.Catch()
 	builder := getTestBuilder()
 	NewTableForColumnTest()
@@ -78,6 +75,219 @@ func TestColumnDropColumn(t *testing.T) {
 	assert.False(t, table.HasColumn("field2"), "the table table_test_column should not have the field2 column")
 }
 
+func TestColumnDropColumnWithExpressionIndex(t *testing.T) {
+	defer unit.Catch()
+	if unit.Not("sqlite3") && unit.Not("postgres") {
+		return
+	}
+
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_column")
+	builder.MustCreateTable("table_test_column", func(table Blueprint) {
+		table.ID("id")
+		table.String("email", 80)
+		table.String("name", 80)
+		table.Timestamp("deleted_at").Null()
+		table.AddUnique("email_lower", "lower(email)")
+		table.AddIndex("name_active", "name").Where("deleted_at IS NULL")
+		table.AddIndex("name_lower", "lower(name)")
+	})
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_column (email, name) VALUES ('ada@example.com', 'Ada')")
+	assert.Nil(t, err, "the insert should be succeed")
+	if unit.DriverIs("sqlite3") {
+		_, err = db.Exec("CREATE TRIGGER table_test_column_email_audit AFTER UPDATE ON table_test_column BEGIN SELECT NEW.email; END")
+		assert.Nil(t, err, "the trigger should be created")
+	}
+
+	builder.MustAlterTable("table_test_column", func(table Blueprint) {
+		table.DropColumn("email")
+	})
+	table := builder.MustGetTable("table_test_column")
+	assert.False(t, table.HasColumn("email"), "the email column should be dropped")
+	assert.False(t, table.HasIndex("email_lower"), "the expression index should be dropped with the column")
+	assert.True(t, table.HasIndex("name_active", "name_lower"), "the indexes of the other columns should be kept")
+
+	builder.MustAlterTable("table_test_column", func(table Blueprint) {
+		table.DropColumn("deleted_at")
+	})
+	table = builder.MustGetTable("table_test_column")
+	assert.False(t, table.HasIndex("name_active"), "the partial index should be dropped with the column of the predicate")
+	assert.True(t, table.HasIndex("name_lower"), "the name_lower index should be kept")
+
+	if unit.DriverIs("sqlite3") {
+		var count int
+		db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type='trigger' AND name='table_test_column_email_audit'")
+		assert.Equal(t, 0, count, "the trigger uses the dropped column should not be recreated")
+	}
+
+	name := ""
+	err = db.Get(&name, "SELECT name FROM table_test_column")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, "Ada", name, "the name value should be kept")
+}
+
+func TestColumnAlterKeepsData(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForColumnTest()
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_column (field1, field2, field3) VALUES ('F1', 'F2', 'F3')")
+	assert.Nil(t, err, "the insert should be succeed")
+
+	builder.MustAlterTable("table_test_column", func(table Blueprint) {
+		table.Text("field2").Null()
+		table.AddIndex("field3", "field3")
+	})
+	builder.MustAlterTable("table_test_column", func(table Blueprint) {
+		table.DropColumn("field1")
+	})
+
+	table := builder.MustGetTable("table_test_column")
+	assert.False(t, table.HasColumn("field1"), "the table table_test_column should not have the field1 column")
+	assert.Equal(t, "text", table.GetColumn("field2").Type, "the field2 type should be text")
+	assert.True(t, table.HasIndex("field3"), "the field3 index should be kept")
+	assert.False(t, table.HasIndex("field1_field2"), "the field1_field2 index should be dropped with the column")
+	assert.True(t, table.GetPrimary() != nil, "the primary key should be kept")
+
+	rows := []struct {
+		Field2 string `db:"field2"`
+		Field3 string `db:"field3"`
+	}{}
+	err = db.Select(&rows, "SELECT field2, field3 FROM table_test_column")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, 1, len(rows), "the table should have 1 row")
+	if len(rows) == 1 {
+		assert.Equal(t, "F2", rows[0].Field2, "the field2 value should be kept")
+		assert.Equal(t, "F3", rows[0].Field3, "the field3 value should be kept")
+	}
+}
+
+func TestColumnGenerated(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_column_generated")
+	builder.MustCreateTable("table_test_column_generated", func(table Blueprint) {
+		table.ID("id")
+		table.Integer("price")
+		table.Integer("quantity")
+		table.Integer("total").StoredAs("price * quantity")
+		table.Integer("double_quantity").VirtualAs("quantity * 2")
+		table.String("note", 40).Null()
+	})
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_column_generated (price, quantity) VALUES (5, 3)")
+	assert.Nil(t, err, "the insert should be succeed")
+
+	table := builder.MustGetTable("table_test_column_generated")
+	total := table.GetColumn("total")
+	assert.Equal(t, "StoredGenerated", utils.StringVal(total.Extra), "the total column should be a stored generated column")
+	assert.Contains(t, total.GenerationExpression, "quantity", "the expression should be read from the catalog")
+
+	double := table.GetColumn("double_quantity")
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, "StoredGenerated", utils.StringVal(double.Extra), "the virtual generated column is stored on postgres")
+	} else {
+		assert.Equal(t, "VirtualGenerated", utils.StringVal(double.Extra), "the double_quantity column should be a virtual generated column")
+	}
+	assert.Equal(t, "", utils.StringVal(table.GetColumn("price").Extra), "the price column should not be generated")
+
+	builder.MustAlterTable("table_test_column_generated", func(table Blueprint) {
+		table.DropColumn("note")
+	})
+
+	row := struct {
+		Total  int `db:"total"`
+		Double int `db:"double_quantity"`
+	}{}
+	err = db.Get(&row, "SELECT total, double_quantity FROM table_test_column_generated")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, 15, row.Total, "the total should be computed")
+	assert.Equal(t, 6, row.Double, "the double_quantity should be computed")
+}
+
+func TestColumnUseCurrentOnUpdate(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_column_on_update")
+	builder.MustCreateTable("table_test_column_on_update", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+		table.DateTime("published_at").UseCurrent()
+		table.Timestamp("checked_at").Null().UseCurrentOnUpdate()
+		table.Timestamps()
+	})
+
+	table := builder.MustGetTable("table_test_column_on_update")
+	assert.True(t, table.GetColumn("updated_at").OnUpdateCurrent, "the updated_at should be set when the row is updated")
+	assert.True(t, table.GetColumn("checked_at").OnUpdateCurrent, "the checked_at should be set when the row is updated")
+	assert.False(t, table.GetColumn("created_at").OnUpdateCurrent, "the created_at should not be set when the row is updated")
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_column_on_update (name) VALUES ('Ada'), ('Bob')")
+	assert.Nil(t, err, "the insert should be succeed")
+
+	var count int
+	db.Get(&count, "SELECT COUNT(*) FROM table_test_column_on_update WHERE published_at IS NOT NULL AND updated_at IS NULL")
+	assert.Equal(t, 2, count, "the published_at should be the current time")
+
+	_, err = db.Exec("UPDATE table_test_column_on_update SET name = 'Ada Lovelace' WHERE name = 'Ada'")
+	assert.Nil(t, err, "the update should be succeed")
+	db.Get(&count, "SELECT COUNT(*) FROM table_test_column_on_update WHERE updated_at IS NOT NULL AND checked_at IS NOT NULL")
+	assert.Equal(t, 1, count, "the updated_at and checked_at of the updated row should be set")
+
+	_, err = db.Exec("UPDATE table_test_column_on_update SET name = 'Bob Marley', updated_at = '2021-03-27 07:16:16' WHERE name = 'Bob'")
+	assert.Nil(t, err, "the update should be succeed")
+	db.Get(&count, "SELECT COUNT(*) FROM table_test_column_on_update WHERE updated_at = '2021-03-27 07:16:16'")
+	assert.Equal(t, 1, count, "the given updated_at should be kept")
+
+	// the triggers follow the renamed and the dropped columns
+	builder.MustAlterTable("table_test_column_on_update", func(table Blueprint) {
+		table.RenameColumn("checked_at", "verified_at")
+	})
+	builder.MustAlterTable("table_test_column_on_update", func(table Blueprint) {
+		table.Timestamp("updated_at").Null()
+		table.DropColumn("verified_at")
+	})
+
+	table = builder.MustGetTable("table_test_column_on_update")
+	assert.False(t, table.HasColumn("verified_at"), "the verified_at should be dropped")
+	assert.False(t, table.GetColumn("updated_at").OnUpdateCurrent, "the updated_at should not be set when the row is updated")
+
+	_, err = db.Exec("UPDATE table_test_column_on_update SET name = 'Ada' WHERE name = 'Ada Lovelace'")
+	assert.Nil(t, err, "the update should be succeed")
+}
+
+func TestColumnPosition(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForColumnTest()
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustAlterTable("table_test_column", func(table Blueprint) {
+			table.String("field0", 20).First()
+			table.String("field4", 20).Null().After("field1")
+		})
+	})
+	assert.Equal(t, 2, len(stmts), "the columns should be added")
+	if unit.DriverIs("mysql") && len(stmts) == 2 {
+		assert.Contains(t, stmts[0], "FIRST", "the field0 should be the first column")
+		assert.Contains(t, stmts[1], "AFTER `field1`", "the field4 should be placed after the field1")
+	}
+
+	builder.MustAlterTable("table_test_column", func(table Blueprint) {
+		table.String("field4", 20).Null().After("field1")
+	})
+	table := builder.MustGetTable("table_test_column")
+	if unit.DriverIs("mysql") {
+		assert.Equal(t, table.GetColumn("field1").Position+1, table.GetColumn("field4").Position, "the field4 should be placed after the field1")
+	} else {
+		assert.True(t, table.HasColumn("field4"), "the placement should be ignored")
+	}
+}
+
 func TestColumnSetLength(t *testing.T) {
 	defer unit.Catch()
 	builder := getTestBuilderInstance()
@@ -222,6 +432,8 @@ func TestColumnUniqueAndIndex(t *testing.T) {
 func TestColumnClean(t *testing.T) {
 	builder := getTestBuilder()
 	builder.DropTableIfExists("table_test_column")
+	builder.DropTableIfExists("table_test_column_generated")
+	builder.DropTableIfExists("table_test_column_on_update")
 }
 
 func NewColumnsForTest(table *Table) {
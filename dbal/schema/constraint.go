@@ -1,3 +1,218 @@
 package schema
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
 // the constraint methods definition
+
+// GetForeign get the foreign key instance for the given name, if the foreign key does not exist return nil.
+func (table *Table) GetForeign(name string) *ForeignKey {
+	return table.ForeignKeyMap[name]
+}
+
+// HasForeign Determine if the table has a given foreign key.
+func (table *Table) HasForeign(name ...string) bool {
+	has := true
+	for _, n := range name {
+		_, has = table.ForeignKeyMap[n]
+		if !has {
+			return has
+		}
+	}
+	return has
+}
+
+// GetForeigns Get the foreign keys map of the table
+func (table *Table) GetForeigns() map[string]*ForeignKey {
+	return table.ForeignKeyMap
+}
+
+// Foreign Indicate that the given columns should reference the columns of another table.
+// The constraint is named <table>_<columns>_foreign, use SetName to change it.
+//
+//	table.Foreign("user_id").References("id").On("users").OnDelete("cascade")
+func (table *Table) Foreign(columnNames ...string) *ForeignKey {
+	name := fmt.Sprintf("%s_%s_foreign", table.GetFullName(), strings.Join(columnNames, "_"))
+	foreign := &ForeignKey{
+		ForeignKey: table.Table.NewForeignKey(name),
+		Table:      table,
+	}
+	for _, columnName := range columnNames {
+		foreign.Columns = append(foreign.Columns, table.mustGetColumn(columnName).Column)
+	}
+
+	table.Table.PushForeignKey(foreign.ForeignKey)
+	table.ForeignKeyMap[name] = foreign
+	table.createForeignCommand(foreign.ForeignKey, nil, func() {
+		delete(table.ForeignKeyMap, foreign.Name)
+	})
+	return foreign
+}
+
+// DropForeign Indicate that the given foreign keys should be dropped.
+func (table *Table) DropForeign(name ...string) {
+	for _, n := range name {
+		table.dropForeignCommand(n, func() {
+			delete(table.ForeignKeyMap, n)
+		}, nil)
+	}
+}
+
+// GetConstraint get the unique or check constraint instance for the given name, if the constraint does not exist return nil.
+func (table *Table) GetConstraint(name string) *Constraint {
+	return table.ConstraintMap[name]
+}
+
+// HasConstraint Determine if the table has a given unique or check constraint.
+func (table *Table) HasConstraint(name ...string) bool {
+	has := true
+	for _, n := range name {
+		_, has = table.ConstraintMap[n]
+		if !has {
+			return has
+		}
+	}
+	return has
+}
+
+// GetConstraints Get the unique and check constraints map of the table.
+// On MySQL a unique constraint is a unique index, so it is listed by GetIndexes instead.
+func (table *Table) GetConstraints() map[string]*Constraint {
+	return table.ConstraintMap
+}
+
+// AddUniqueConstraint Indicate that the given named unique constraint should be created.
+// The name should be unique in the schema (Postgres creates an index with the same name).
+func (table *Table) AddUniqueConstraint(name string, columnNames ...string) *Table {
+	constraint := table.newConstraint(name, "UNIQUE")
+	for _, columnName := range columnNames {
+		constraint.Columns = append(constraint.Columns, table.mustGetColumn(columnName).Column)
+	}
+	table.pushConstraint(constraint)
+	table.createConstraintCommand(constraint.Constraint, nil, func() {
+		delete(table.ConstraintMap, name)
+	})
+	return table
+}
+
+// AddCheck Indicate that the given named check constraint should be created.
+//
+//	table.AddCheck("price_check", "price >= 0")
+func (table *Table) AddCheck(name string, expression string) *Table {
+	constraint := table.newConstraint(name, "CHECK")
+	constraint.Args = append(constraint.Args, expression)
+	table.pushConstraint(constraint)
+	table.createConstraintCommand(constraint.Constraint, nil, func() {
+		delete(table.ConstraintMap, name)
+	})
+	return table
+}
+
+// DropConstraint Indicate that the given unique or check constraints should be dropped.
+func (table *Table) DropConstraint(name ...string) {
+	for _, n := range name {
+		table.dropConstraintCommand(n, func() {
+			delete(table.ConstraintMap, n)
+		}, nil)
+	}
+}
+
+// mustGetColumn get the column of the table, panic if the column does not exist
+func (table *Table) mustGetColumn(name string) *Column {
+	column := table.GetColumn(name)
+	if column == nil {
+		panic(fmt.Errorf("the column %s does not exist", name))
+	}
+	return column
+}
+
+// newConstraint Create a new constraint instance
+func (table *Table) newConstraint(name string, typ string) *Constraint {
+	return &Constraint{
+		Constraint: table.Table.NewConstraint(name, typ),
+		Table:      table,
+	}
+}
+
+// pushConstraint add a constraint to the table
+func (table *Table) pushConstraint(constraint *Constraint) *Table {
+	table.Table.PushConstraint(constraint.Constraint)
+	table.ConstraintMap[constraint.Name] = constraint
+	return table
+}
+
+// References set the referenced columns of the foreign key
+func (foreign *ForeignKey) References(columnNames ...string) *ForeignKey {
+	foreign.ReferencedColumns = columnNames
+	return foreign
+}
+
+// On set the referenced table of the foreign key (the table prefix will be added), the table can be schema-qualified (reporting.users)
+func (foreign *ForeignKey) On(name string) *ForeignKey {
+	foreign.ReferencedTable = dbal.NewName(name, foreign.Table.Prefix).Fullname()
+	return foreign
+}
+
+// OnDelete set the referential action when the referenced row is deleted.
+// The action must be one of cascade, set null, set default, restrict and no action, panic if it is not.
+func (foreign *ForeignKey) OnDelete(action string) *ForeignKey {
+	foreign.ForeignKey.OnDelete = foreignAction(action)
+	return foreign
+}
+
+// OnUpdate set the referential action when the referenced row is updated.
+// The action must be one of cascade, set null, set default, restrict and no action, panic if it is not.
+func (foreign *ForeignKey) OnUpdate(action string) *ForeignKey {
+	foreign.ForeignKey.OnUpdate = foreignAction(action)
+	return foreign
+}
+
+// foreignAction normalize the referential action, panic if the action is not supported
+func foreignAction(action string) string {
+	normalized, err := parseForeignAction(action)
+	if err != nil {
+		panic(err)
+	}
+	return normalized
+}
+
+// parseForeignAction normalize the referential action, e.g. set null -> SET NULL
+func parseForeignAction(action string) (string, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(action), " "))
+	if !dbal.ForeignKeyActions[normalized] {
+		return "", fmt.Errorf("the referential action %s is not supported, it should be one of cascade, set null, set default, restrict and no action", action)
+	}
+	return normalized, nil
+}
+
+// SetName set the constraint name of the foreign key
+func (foreign *ForeignKey) SetName(name string) *ForeignKey {
+	table := foreign.Table
+	delete(table.ForeignKeyMap, foreign.Name)
+	delete(table.Table.ForeignKeyMap, foreign.Name)
+	foreign.Name = name
+	table.ForeignKeyMap[name] = foreign
+	table.Table.ForeignKeyMap[name] = foreign.ForeignKey
+	return foreign
+}
+
+// Constrained create a foreign key that references the column of the given table.
+// The table defaults to the column name without the "_id" suffix followed by "s" (user_id => users),
+// and the referenced column defaults to "id".
+//
+//	table.ForeignID("user_id").Constrained()  // REFERENCES users(id)
+func (column *Column) Constrained(args ...string) *ForeignKey {
+	on := fmt.Sprintf("%ss", strings.TrimSuffix(column.Name, "_id"))
+	references := "id"
+	if len(args) > 0 && args[0] != "" {
+		on = args[0]
+	}
+	if len(args) > 1 && args[1] != "" {
+		references = args[1]
+	}
+	return column.Table.Foreign(column.Name).References(references).On(on)
+}
@@ -0,0 +1,219 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestConstraintForeign(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForConstraintTest(builder)
+
+	table := builder.MustGetTable("table_test_constraint_posts")
+	assert.True(t, table.HasForeign("table_test_constraint_posts_user_id_foreign"), "the table should have the user_id foreign key")
+	assert.True(t, table.HasForeign("posts_editor_foreign"), "the table should have the posts_editor_foreign foreign key")
+	assert.Equal(t, 2, len(table.GetForeigns()), "the table should have 2 foreign keys")
+
+	if table.HasForeign("table_test_constraint_posts_user_id_foreign") {
+		foreign := table.GetForeign("table_test_constraint_posts_user_id_foreign")
+		assert.Equal(t, "table_test_constraint_users", foreign.ReferencedTable, "the referenced table should be table_test_constraint_users")
+		assert.Equal(t, []string{"id"}, foreign.ReferencedColumns, "the referenced columns should be id")
+		assert.Equal(t, 1, len(foreign.Columns), "the foreign key should have 1 column")
+		assert.Equal(t, "user_id", foreign.Columns[0].Name, "the foreign key column should be user_id")
+		assert.Equal(t, "CASCADE", foreign.ForeignKey.OnDelete, "the on delete action should be CASCADE")
+	}
+
+	if table.HasForeign("posts_editor_foreign") {
+		foreign := table.GetForeign("posts_editor_foreign")
+		assert.Equal(t, "table_test_constraint_users", foreign.ReferencedTable, "the referenced table should be table_test_constraint_users")
+		assert.Equal(t, "editor_id", foreign.Columns[0].Name, "the foreign key column should be editor_id")
+		assert.Equal(t, "SET NULL", foreign.ForeignKey.OnDelete, "the on delete action should be SET NULL")
+	}
+}
+
+func TestConstraintForeignInvalid(t *testing.T) {
+	builder := getTestBuilder()
+	table := builder.(*Builder).table("table_test_constraint_invalid")
+	table.ID("id")
+	table.UnsignedBigInteger("user_id")
+
+	assert.PanicsWithError(t, "the column author_id does not exist", func() {
+		table.Foreign("author_id")
+	})
+
+	foreign := table.Foreign("user_id").References("id").On("table_test_constraint_users")
+	assert.PanicsWithError(t, "the referential action cascde is not supported, it should be one of cascade, set null, set default, restrict and no action", func() {
+		foreign.OnDelete("cascde")
+	})
+	assert.Panics(t, func() { foreign.OnUpdate("") })
+	assert.Equal(t, "SET NULL", foreign.OnDelete(" set  null ").ForeignKey.OnDelete, "the action should be normalized")
+	assert.Equal(t, "NO ACTION", foreign.OnUpdate("no action").ForeignKey.OnUpdate, "the action should be normalized")
+}
+
+func TestConstraintForeignEnforced(t *testing.T) {
+	defer unit.Catch()
+	if unit.DriverIs("sqlite3") {
+		// the foreign key constraints are disabled by default on sqlite3
+		return
+	}
+
+	builder := getTestBuilder()
+	NewTableForConstraintTest(builder)
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_constraint_posts (id, user_id) VALUES (1, 99)")
+	assert.NotNil(t, err, "the insert should violate the foreign key constraint")
+}
+
+func TestConstraintDropForeign(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForConstraintTest(builder)
+
+	builder.MustAlterTable("table_test_constraint_posts", func(table Blueprint) {
+		table.DropForeign("posts_editor_foreign")
+	})
+	table := builder.MustGetTable("table_test_constraint_posts")
+	assert.False(t, table.HasForeign("posts_editor_foreign"), "the posts_editor_foreign foreign key should be dropped")
+	assert.True(t, table.HasForeign("table_test_constraint_posts_user_id_foreign"), "the user_id foreign key should be kept")
+
+	builder.MustAlterTable("table_test_constraint_posts", func(table Blueprint) {
+		table.Foreign("editor_id").References("id").On("table_test_constraint_users").OnUpdate("cascade")
+	})
+	table = builder.MustGetTable("table_test_constraint_posts")
+	assert.True(t, table.HasForeign("table_test_constraint_posts_editor_id_foreign"), "the editor_id foreign key should be created")
+	if table.HasForeign("table_test_constraint_posts_editor_id_foreign") {
+		foreign := table.GetForeign("table_test_constraint_posts_editor_id_foreign")
+		assert.Equal(t, "CASCADE", foreign.ForeignKey.OnUpdate, "the on update action should be CASCADE")
+	}
+}
+
+func TestConstraintUniqueAndCheck(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForConstraintProductsTest(builder)
+
+	table := builder.MustGetTable("table_test_constraint_products")
+	assert.True(t, table.HasConstraint("products_price_check"), "the table should have the products_price_check constraint")
+	if unit.DriverIs("mysql") {
+		assert.False(t, table.HasConstraint("products_sku_unique"), "the unique constraint should be listed as an index only")
+		assert.True(t, table.HasIndex("products_sku_unique"), "the unique constraint should be listed as an index")
+		assert.Equal(t, 1, len(table.GetConstraints()), "the unique index should not be listed as a constraint")
+	} else {
+		assert.True(t, table.HasConstraint("products_sku_unique"), "the table should have the products_sku_unique constraint")
+	}
+	if table.HasConstraint("products_sku_unique") {
+		constraint := table.GetConstraint("products_sku_unique")
+		assert.Equal(t, "UNIQUE", constraint.Type, "the products_sku_unique constraint type should be UNIQUE")
+		assert.Equal(t, 2, len(constraint.Columns), "the products_sku_unique constraint should have 2 columns")
+		if len(constraint.Columns) == 2 {
+			assert.Equal(t, "sku", constraint.Columns[0].Name, "the first column of products_sku_unique should be sku")
+			assert.Equal(t, "region", constraint.Columns[1].Name, "the second column of products_sku_unique should be region")
+		}
+	}
+
+	if table.HasConstraint("products_price_check") {
+		constraint := table.GetConstraint("products_price_check")
+		assert.Equal(t, "CHECK", constraint.Type, "the products_price_check constraint type should be CHECK")
+		assert.Equal(t, 1, len(constraint.Args), "the products_price_check constraint should have the expression")
+		if len(constraint.Args) == 1 {
+			assert.Contains(t, constraint.Args[0], "price", "the products_price_check expression should contain price")
+		}
+	}
+
+	if unit.Not("mysql") {
+		assert.False(t, table.HasIndex("products_sku_unique"), "the unique constraint should not be listed as an index")
+	}
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_constraint_products (id, sku, region, price) VALUES (1, 'P1', 'CN', 10)")
+	assert.Nil(t, err, "the insert should be succeed")
+	_, err = db.Exec("INSERT INTO table_test_constraint_products (id, sku, region, price) VALUES (2, 'P1', 'CN', 10)")
+	assert.NotNil(t, err, "the insert should violate the unique constraint")
+	_, err = db.Exec("INSERT INTO table_test_constraint_products (id, sku, region, price) VALUES (3, 'P3', 'CN', -1)")
+	assert.NotNil(t, err, "the insert should violate the check constraint")
+}
+
+func TestConstraintDropConstraint(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	NewTableForConstraintProductsTest(builder)
+
+	builder.MustAlterTable("table_test_constraint_products", func(table Blueprint) {
+		table.DropConstraint("products_price_check", "products_sku_unique")
+	})
+	table := builder.MustGetTable("table_test_constraint_products")
+	assert.False(t, table.HasConstraint("products_price_check"), "the products_price_check constraint should be dropped")
+	assert.False(t, table.HasConstraint("products_sku_unique"), "the products_sku_unique constraint should be dropped")
+	assert.False(t, table.HasIndex("products_sku_unique"), "the products_sku_unique index should be dropped")
+
+	builder.MustAlterTable("table_test_constraint_products", func(table Blueprint) {
+		table.AddCheck("products_price_positive", "price > 0")
+		table.AddUniqueConstraint("products_sku_unique", "sku")
+	})
+	table = builder.MustGetTable("table_test_constraint_products")
+	assert.True(t, table.HasConstraint("products_price_positive"), "the check constraint should be created")
+	if unit.DriverIs("mysql") {
+		assert.True(t, table.HasIndex("products_sku_unique"), "the unique constraint should be created")
+	} else {
+		assert.True(t, table.HasConstraint("products_sku_unique"), "the unique constraint should be created")
+	}
+}
+
+func TestConstraintUnsignedPostgres(t *testing.T) {
+	defer unit.Catch()
+	if unit.Not("postgres") {
+		return
+	}
+
+	builder := getTestBuilder()
+	NewTableForConstraintProductsTest(builder)
+
+	table := builder.MustGetTable("table_test_constraint_products")
+	assert.True(t, table.GetColumn("stock").IsUnsigned, "the stock IsUnsigned should be true")
+	assert.False(t, table.GetColumn("price").IsUnsigned, "the price IsUnsigned should be false")
+	assert.Equal(t, 2, len(table.GetConstraints()), "the unsigned check should not be listed as a constraint")
+
+	_, err := builder.DB().Exec("INSERT INTO table_test_constraint_products (id, sku, region, price, stock) VALUES (1, 'P1', 'CN', 10, -1)")
+	assert.NotNil(t, err, "the insert should violate the unsigned check")
+}
+
+func TestConstraintClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_constraint_products")
+	builder.DropTableIfExists("table_test_constraint_posts")
+	builder.DropTableIfExists("table_test_constraint_users")
+}
+
+func NewTableForConstraintTest(builder Schema) {
+	defer unit.Catch()
+	builder.DropTableIfExists("table_test_constraint_posts")
+	builder.DropTableIfExists("table_test_constraint_users")
+	builder.MustCreateTable("table_test_constraint_users", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+	})
+	builder.MustCreateTable("table_test_constraint_posts", func(table Blueprint) {
+		table.ID("id")
+		table.ForeignID("user_id").Constrained("table_test_constraint_users").OnDelete("cascade")
+		table.ForeignID("editor_id").Null()
+		table.Foreign("editor_id").References("id").On("table_test_constraint_users").OnDelete("set null").SetName("posts_editor_foreign")
+	})
+}
+
+func NewTableForConstraintProductsTest(builder Schema) {
+	defer unit.Catch()
+	builder.DropTableIfExists("table_test_constraint_products")
+	builder.MustCreateTable("table_test_constraint_products", func(table Blueprint) {
+		table.ID("id")
+		table.String("sku", 40)
+		table.String("region", 20)
+		table.Integer("price")
+		table.UnsignedInteger("stock").SetDefault(0)
+		table.AddUniqueConstraint("products_sku_unique", "sku", "region")
+		table.AddCheck("products_price_check", "price >= 0")
+	})
+}
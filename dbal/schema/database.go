@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"github.com/yaoapp/xun/utils"
+)
+
+// GetDatabases get the database names of the server, the databases of sqlite3 are the files in the directory of the connected database.
+func (builder *Builder) GetDatabases() ([]string, error) {
+	return builder.Grammar.GetDatabases()
+}
+
+// MustGetDatabases get the database names of the server
+func (builder *Builder) MustGetDatabases() []string {
+	databases, err := builder.GetDatabases()
+	utils.PanicIF(err)
+	return databases
+}
+
+// DatabaseExists check if the database exists
+func (builder *Builder) DatabaseExists(name string) (bool, error) {
+	return builder.Grammar.DatabaseExists(name)
+}
+
+// MustDatabaseExists check if the database exists
+func (builder *Builder) MustDatabaseExists(name string) bool {
+	exists, err := builder.DatabaseExists(name)
+	utils.PanicIF(err)
+	return exists
+}
+
+// CreateDatabase create a new database, the server defaults are used if the charset or the collation is empty.
+//
+//	builder.CreateDatabase("tenant_1024", "utf8mb4", "utf8mb4_general_ci")
+func (builder *Builder) CreateDatabase(name string, charset string, collation string) error {
+	builder.forgetAllGenerated()
+	return builder.Grammar.CreateDatabase(name, charset, collation)
+}
+
+// MustCreateDatabase create a new database
+func (builder *Builder) MustCreateDatabase(name string, charset string, collation string) {
+	err := builder.CreateDatabase(name, charset, collation)
+	utils.PanicIF(err)
+}
+
+// DropDatabase drop the database and all of its tables
+func (builder *Builder) DropDatabase(name string) error {
+	builder.forgetAllGenerated()
+	return builder.Grammar.DropDatabase(name)
+}
+
+// MustDropDatabase drop the database and all of its tables
+func (builder *Builder) MustDropDatabase(name string) {
+	err := builder.DropDatabase(name)
+	utils.PanicIF(err)
+}
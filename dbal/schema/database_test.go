@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestDatabaseCreateDatabase(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestDatabaseClean(nil)
+	assert.False(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should not exist")
+	builder.MustCreateDatabase("table_test_database_tenant", "utf8mb4", "")
+	assert.True(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should be created")
+	assert.Contains(t, builder.MustGetDatabases(), "table_test_database_tenant", "the database should be listed")
+	if unit.DriverNot("dm") {
+		// the databases of dameng are the schemas owned by the current user
+		assert.Contains(t, builder.MustGetDatabases(), builder.Builder().Database, "the connected database should be listed")
+	}
+	assert.NotNil(t, builder.CreateDatabase("table_test_database_tenant", "", ""), "the database exists")
+}
+
+func TestDatabaseDropDatabase(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestDatabaseClean(nil)
+	builder.MustCreateDatabase("table_test_database_tenant", "", "")
+	builder.MustDropDatabase("table_test_database_tenant")
+	assert.False(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should be dropped")
+	assert.NotContains(t, builder.MustGetDatabases(), "table_test_database_tenant", "the database should not be listed")
+	assert.NotNil(t, builder.DropDatabase("table_test_database_tenant"), "the database does not exist")
+}
+
+func TestDatabaseCreateDatabaseInvalidCharset(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	if unit.DriverNot("mysql") {
+		return
+	}
+
+	TestDatabaseClean(nil)
+	assert.NotNil(t, builder.CreateDatabase("table_test_database_tenant", "utf8mb4; DROP DATABASE xun", ""), "the charset should be invalid")
+	assert.NotNil(t, builder.CreateDatabase("table_test_database_tenant", "utf8mb4", "utf8mb4_bin COMMENT 'x'"), "the collation should be invalid")
+	assert.False(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should not be created")
+}
+
+func TestDatabasePretend(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustCreateDatabase("table_test_database_tenant", "", "")
+	})
+	assert.NotEmpty(t, stmts, "the statements should be collected")
+	assert.False(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should not be created")
+	if unit.DriverIs("sqlite3") && len(stmts) > 0 {
+		assert.Contains(t, stmts[0], `ATTACH DATABASE`)
+	}
+
+	builder.MustCreateDatabase("table_test_database_tenant", "", "")
+	stmts = builder.MustPretend(func(schema Schema) {
+		schema.MustDropDatabase("table_test_database_tenant")
+	})
+	assert.Equal(t, 1, len(stmts), "the drop statement should be collected")
+	assert.True(t, builder.MustDatabaseExists("table_test_database_tenant"), "the database should not be dropped")
+	if len(stmts) > 0 {
+		assert.Contains(t, stmts[0], "DROP DATABASE")
+	}
+	builder.MustDropDatabase("table_test_database_tenant")
+}
+
+func TestDatabaseClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropDatabase("table_test_database_tenant")
+}
@@ -0,0 +1,290 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// the types stored as another type by the drivers, e.g. the JSON columns of SQLite are TEXT columns
+var storedTypes = map[string][]string{
+	"dateTimeTz":  {"dateTime"},
+	"timeTz":      {"time"},
+	"timestampTz": {"timestamp"},
+	"json":        {"text"},
+	"jsonb":       {"json", "text"},
+	"uuid":        {"string", "char"},
+	"ipAddress":   {"integer", "string"},
+	"macAddress":  {"bigInteger", "string"},
+	"year":        {"smallInteger"},
+}
+
+// Diff compare the current table with the desired one, return the commands that change the current table to the desired one.
+// The commands are ordered as: DropIndex, DropPrimary, ChangeColumn, AddColumn, DropColumn, CreatePrimary, CreateIndex.
+// The foreign keys and the constraints are not compared.
+//
+// The current table is introspected and the introspection is lossy, so the columns are compared as the drivers describe them:
+// the defaults are compared as they are exported (see Export), the comments are compared without the type hints,
+// the types stored as another type (e.g. json as text) are the same, and the sizes are compared only if both columns have them.
+// Sync describes the desired columns as the driver creates them (see Grammar.NormalizeColumn) before comparing them.
+func Diff(current *dbal.Table, desired *dbal.Table) []*dbal.Command {
+	return diff(current, desired, desired)
+}
+
+// diff compare the current table with the normalized desired one, the columns, indexes and the primary key
+// of the commands are taken from the source table (they have the same names as the normalized ones).
+func diff(current *dbal.Table, desired *dbal.Table, source *dbal.Table) []*dbal.Command {
+	dropIndexes := []*dbal.Command{}
+	createIndexes := []*dbal.Command{}
+	changeColumns := []*dbal.Command{}
+	addColumns := []*dbal.Command{}
+	dropColumns := []*dbal.Command{}
+	primaries := []*dbal.Command{}
+
+	// Indexes
+	for _, index := range current.Indexes {
+		if index.Primary || index.Type == "primary" {
+			continue
+		}
+		want, has := desired.IndexMap[index.Name]
+		if !has || !sameIndex(index, want) {
+			dropIndexes = append(dropIndexes, &dbal.Command{Name: "DropIndex", Params: []interface{}{index.Name}})
+		}
+	}
+	for _, index := range desired.Indexes {
+		if index.Primary || index.Type == "primary" {
+			continue
+		}
+		have, has := current.IndexMap[index.Name]
+		if !has || !sameIndex(have, index) {
+			createIndexes = append(createIndexes, &dbal.Command{Name: "CreateIndex", Params: []interface{}{source.GetIndex(index.Name)}})
+		}
+	}
+
+	// Primary key
+	if !samePrimary(current.Primary, desired.Primary) {
+		if current.Primary != nil {
+			primaries = append(primaries, &dbal.Command{Name: "DropPrimary", Params: []interface{}{current.Primary.Name, current.Primary.Columns}})
+		}
+		if desired.Primary != nil && source.Primary != nil {
+			primaries = append(primaries, &dbal.Command{Name: "CreatePrimary", Params: []interface{}{source.Primary}})
+		}
+	}
+
+	// Columns
+	for _, column := range desired.Columns {
+		have, has := current.ColumnMap[column.Name]
+		if !has {
+			addColumns = append(addColumns, &dbal.Command{Name: "AddColumn", Params: []interface{}{source.GetColumn(column.Name)}})
+		} else if !sameColumn(have, column) {
+			changeColumns = append(changeColumns, &dbal.Command{Name: "ChangeColumn", Params: []interface{}{source.GetColumn(column.Name)}})
+		}
+	}
+	for _, column := range current.Columns {
+		if !desired.HasColumn(column.Name) {
+			dropColumns = append(dropColumns, &dbal.Command{Name: "DropColumn", Params: []interface{}{column.Name}})
+		}
+	}
+
+	commands := []*dbal.Command{}
+	commands = append(commands, dropIndexes...)
+	if len(primaries) > 0 && primaries[0].Name == "DropPrimary" {
+		commands = append(commands, primaries[0])
+		primaries = primaries[1:]
+	}
+	commands = append(commands, changeColumns...)
+	commands = append(commands, addColumns...)
+	commands = append(commands, dropColumns...)
+	commands = append(commands, primaries...)
+	commands = append(commands, createIndexes...)
+	return commands
+}
+
+// sameColumn determine if the column definitions are the same, see Diff
+func sameColumn(current *dbal.Column, desired *dbal.Column) bool {
+	if current.Type != desired.Type {
+		if !storedAs(desired.Type, current.Type) {
+			return false
+		}
+	} else if current.IsUnsigned != desired.IsUnsigned {
+		return false
+	}
+
+	currentDefault, currentRaw := diffDefault(current)
+	desiredDefault, desiredRaw := diffDefault(desired)
+	return current.Nullable == desired.Nullable &&
+		sameSize(current.Length, desired.Length) &&
+		sameSize(current.Precision, desired.Precision) &&
+		sameSize(current.Scale, desired.Scale) &&
+		sameSize(current.DateTimePrecision, desired.DateTimePrecision) &&
+		fmt.Sprintf("%v", currentDefault) == fmt.Sprintf("%v", desiredDefault) &&
+		currentRaw == desiredRaw &&
+		current.OnUpdateCurrent == desired.OnUpdateCurrent &&
+		columnComment(current) == columnComment(desired) &&
+		diffExtra(current) == diffExtra(desired) &&
+		(len(current.Option) == 0 || len(desired.Option) == 0 || strings.Join(current.Option, ",") == strings.Join(desired.Option, ","))
+}
+
+// narrowColumn determine if changing the column may lose the data: the type is changed or the size is reduced
+func narrowColumn(current *dbal.Column, desired *dbal.Column) bool {
+	return (current.Type != desired.Type && !storedAs(desired.Type, current.Type)) ||
+		smallerSize(desired.Length, current.Length) ||
+		smallerSize(desired.Precision, current.Precision) ||
+		smallerSize(desired.Scale, current.Scale) ||
+		smallerSize(desired.DateTimePrecision, current.DateTimePrecision)
+}
+
+// storedAs determine if the driver stores the columns of the type as the other type
+func storedAs(typ string, stored string) bool {
+	for _, name := range storedTypes[typ] {
+		if name == stored {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSize determine if the sizes are the same, the sizes are not compared if one of them is not given
+func sameSize(current *int, desired *int) bool {
+	return utils.IntVal(current) == 0 || utils.IntVal(desired) == 0 || utils.IntVal(current) == utils.IntVal(desired)
+}
+
+// smallerSize determine if the size is reduced, the sizes are not compared if one of them is not given
+func smallerSize(desired *int, current *int) bool {
+	return utils.IntVal(current) != 0 && utils.IntVal(desired) != 0 && utils.IntVal(desired) < utils.IntVal(current)
+}
+
+// diffDefault get the default value of the column as it is exported, the current time functions of the raw values are NOW().
+// The defaults of the auto-increment and the generated columns are not compared, e.g. nextval('users_id_seq') (Postgres).
+func diffDefault(column *dbal.Column) (interface{}, string) {
+	if diffExtra(column) != "" {
+		return nil, ""
+	}
+	value, raw := exportDefault(column)
+	if raw != "" && isTimeType(column.Type) && reCurrentTime.MatchString(raw) {
+		raw = "NOW()"
+	}
+	return value, raw
+}
+
+// diffExtra get the extra attribute of the column described by the blueprint, the others are ignored, e.g. DEFAULT_GENERATED (MySQL)
+func diffExtra(column *dbal.Column) string {
+	switch extra := utils.StringVal(column.Extra); extra {
+	case "AutoIncrement", "StoredGenerated", "VirtualGenerated":
+		return extra
+	}
+	return ""
+}
+
+// sameIndex determine if the index definitions are the same
+func sameIndex(current *dbal.Index, desired *dbal.Index) bool {
+	return current.Type == desired.Type && columnNames(current.Columns) == columnNames(desired.Columns)
+}
+
+// samePrimary determine if the primary keys are the same
+func samePrimary(current *dbal.Primary, desired *dbal.Primary) bool {
+	if current == nil || desired == nil {
+		return current == desired
+	}
+	return columnNames(current.Columns) == columnNames(desired.Columns)
+}
+
+// columnNames get the names of the columns
+func columnNames(columns []*dbal.Column) string {
+	names := []string{}
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// Sync create the table or change it to the described one, only the differences are applied (see Diff).
+// The columns, the indexes and the primary key will not be dropped, and the columns will not be narrowed unless force is true.
+//
+//	err := builder.Sync("users", func(table Blueprint) {
+//		table.ID("id")
+//		table.String("name", 80).Index()
+//	})
+func (builder *Builder) Sync(name string, callback func(table Blueprint), force ...bool) error {
+	has, err := builder.HasTable(name)
+	if err != nil {
+		return err
+	}
+
+	if !has {
+		return builder.CreateTable(name, callback)
+	}
+
+	current, err := builder.GetTable(name)
+	if err != nil {
+		return err
+	}
+
+	desired := builder.table(name)
+	callback(desired)
+	if desired.Primary != nil {
+		desired.Table.Primary = desired.Primary.Primary
+	}
+
+	table := current.Get().Table
+	normalized := builder.normalize(desired.Table)
+	commands := diff(table, normalized, desired.Table)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if len(force) == 0 || !force[0] {
+		changes := lossyChanges(table, normalized, commands)
+		if len(changes) > 0 {
+			return fmt.Errorf("the table %s will %s, set force to true to allow it", name, strings.Join(changes, ", "))
+		}
+	}
+
+	table.Commands = commands
+	builder.forgetGenerated(table.TableName)
+	return builder.Grammar.AlterTable(table)
+}
+
+// MustSync create the table or change it to the described one, only the differences are applied (see Diff).
+// The columns, the indexes and the primary key will not be dropped, and the columns will not be narrowed unless force is true.
+func (builder *Builder) MustSync(name string, callback func(table Blueprint), force ...bool) {
+	err := builder.Sync(name, callback, force...)
+	utils.PanicIF(err)
+}
+
+// normalize describe the desired columns as the driver creates and introspects them (see Grammar.NormalizeColumn),
+// the desired table is not changed.
+func (builder *Builder) normalize(desired *dbal.Table) *dbal.Table {
+	normalized := *desired
+	normalized.Columns = []*dbal.Column{}
+	normalized.ColumnMap = map[string]*dbal.Column{}
+	for _, col := range desired.Columns {
+		column := builder.Grammar.NormalizeColumn(col)
+		normalized.Columns = append(normalized.Columns, column)
+		normalized.ColumnMap[column.Name] = column
+	}
+	return &normalized
+}
+
+// lossyChanges describe the commands that may lose the data or the indexes of the current table
+func lossyChanges(current *dbal.Table, desired *dbal.Table, commands []*dbal.Command) []string {
+	changes := []string{}
+	for _, command := range commands {
+		switch command.Name {
+		case "DropColumn":
+			changes = append(changes, fmt.Sprintf("drop the column %s", command.Params[0]))
+		case "DropIndex":
+			changes = append(changes, fmt.Sprintf("drop the index %s", command.Params[0]))
+		case "DropPrimary":
+			changes = append(changes, "drop the primary key")
+		case "ChangeColumn":
+			name := command.Params[0].(*dbal.Column).Name
+			if narrowColumn(current.ColumnMap[name], desired.ColumnMap[name]) {
+				changes = append(changes, fmt.Sprintf("narrow the column %s", name))
+			}
+		}
+	}
+	return changes
+}
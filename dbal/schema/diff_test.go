@@ -0,0 +1,262 @@
+package schema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/unit"
+	"github.com/yaoapp/xun/utils"
+)
+
+func TestDiffSameTable(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustSync("table_test_diff", testDiffUsers)
+	assert.True(t, builder.MustHasTable("table_test_diff"), "the table should be created")
+
+	table := builder.MustGetTable("table_test_diff").Get().Table
+	assert.Equal(t, 0, len(Diff(table, table)), "the same tables should have no differences")
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustSync("table_test_diff", testDiffUsers)
+	})
+	assert.Equal(t, 0, len(stmts), "the table should not be changed")
+}
+
+func TestDiffSync(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustSync("table_test_diff", testDiffUsers)
+	_, err := builder.DB().Exec("INSERT INTO table_test_diff (name, email) VALUES ('Ada', 'ada@example.com')")
+	assert.Nil(t, err, "the insert should be succeed")
+
+	builder.MustSync("table_test_diff", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 120)
+		table.String("email", 100).Unique()
+		table.String("nickname", 50).Null().Index()
+		table.Integer("score").SetDefault(0)
+	}, true)
+
+	table := builder.MustGetTable("table_test_diff")
+	assert.True(t, table.HasColumn("nickname", "score"), "the nickname and score columns should be added")
+	assert.Equal(t, 120, *table.GetColumn("name").Length, "the name length should be 120")
+	assert.False(t, table.HasIndex("name_index"), "the name_index should be dropped")
+	assert.True(t, table.HasIndex("nickname_index"), "the nickname_index should be created")
+	assert.Equal(t, "unique", table.GetIndex("email_unique").Type, "the email_unique should be kept")
+
+	row := struct {
+		Name  string `db:"name"`
+		Email string `db:"email"`
+	}{}
+	err = builder.DB().Get(&row, "SELECT name, email FROM table_test_diff")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, "Ada", row.Name, "the name value should be kept")
+}
+
+func TestDiffSyncSafeMode(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustSync("table_test_diff", testDiffUsers)
+
+	withoutEmail := func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index()
+	}
+	err := builder.Sync("table_test_diff", withoutEmail)
+	assert.NotNil(t, err, "the sync should refuse dropping the columns")
+	assert.True(t, builder.MustGetTable("table_test_diff").HasColumn("email"), "the email column should be kept")
+
+	err = builder.Sync("table_test_diff", withoutEmail, true)
+	assert.Nil(t, err, "the sync should be succeed")
+	assert.False(t, builder.MustGetTable("table_test_diff").HasColumn("email"), "the email column should be dropped")
+
+	err = builder.Sync("table_test_diff", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+	})
+	assert.NotNil(t, err, "the sync should refuse dropping the indexes")
+	assert.True(t, builder.MustGetTable("table_test_diff").HasIndex("name_index"), "the name_index should be kept")
+
+	err = builder.Sync("table_test_diff", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 40).Index()
+	})
+	assert.NotNil(t, err, "the sync should refuse narrowing the columns")
+	assert.Equal(t, 80, *builder.MustGetTable("table_test_diff").GetColumn("name").Length, "the name length should be kept")
+
+	err = builder.Sync("table_test_diff", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 120).Index()
+	})
+	assert.Nil(t, err, "the sync should widen the columns")
+	assert.Equal(t, 120, *builder.MustGetTable("table_test_diff").GetColumn("name").Length, "the name length should be 120")
+}
+
+func TestDiffIntrospectedTable(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustCreateTable("table_test_diff", testDiffProfiles)
+
+	desired := func(callback func(table Blueprint)) *dbal.Table {
+		table := builder.(*Builder).table("table_test_diff")
+		callback(table)
+		table.Table.Primary = table.Primary.Primary
+		return builder.(*Builder).normalize(table.Table)
+	}
+
+	current := builder.MustGetTable("table_test_diff").Get().Table
+	assert.Equal(t, 0, len(Diff(current, desired(testDiffProfiles))), "the introspected table should be the same as the blueprint")
+
+	commands := Diff(current, desired(func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index()
+		table.String("status", 20).SetDefault("active")
+		table.Integer("score").SetDefault(0)
+		table.UnsignedInteger("visits").SetDefault(0)
+		table.Decimal("balance", 10, 2).Null()
+		table.Boolean("enabled").SetDefault(true)
+		table.Enum("kind", []string{"person", "company"}).SetDefault("person")
+		table.JSON("meta").Null().SetComment("the profile meta")
+		table.UUID("uid").Null()
+		table.TimestampTz("created_at").SetDefaultRaw("NOW()")
+		table.DateTime("deleted_at").Null()
+		table.String("nickname", 50).Null()
+	}))
+	names := []string{}
+	for _, command := range commands {
+		switch param := command.Params[0].(type) {
+		case *dbal.Column:
+			names = append(names, command.Name+" "+param.Name)
+		default:
+			names = append(names, fmt.Sprintf("%s %v", command.Name, param))
+		}
+	}
+	assert.Equal(t, []string{"ChangeColumn status", "ChangeColumn balance", "AddColumn nickname"}, names, "only the changed columns should be returned")
+}
+
+func TestDiffSyncPretend(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustCreateTable("table_test_diff", testDiffProfiles)
+	tables := builder.MustGetTables()
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustSync("table_test_diff", func(table Blueprint) {
+			testDiffProfiles(table)
+			table.String("nickname", 50).Null()
+		})
+	})
+	assert.Equal(t, 1, len(stmts), "only the nickname column should be added")
+	assert.Equal(t, tables, builder.MustGetTables(), "the sync should not create any table in the pretend mode")
+	assert.False(t, builder.MustGetTable("table_test_diff").HasColumn("nickname"), "the table should not be changed in the pretend mode")
+}
+
+func TestDiffSyncUnchanged(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+	builder.MustSync("table_test_diff", testDiffTypes)
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustSync("table_test_diff", testDiffTypes)
+	})
+	assert.Equal(t, []string{}, stmts, "the unchanged blueprint should not change the table")
+	assert.Nil(t, builder.Sync("table_test_diff", testDiffTypes), "the unchanged blueprint should pass the force guard")
+}
+
+func TestDiffNormalizeColumn(t *testing.T) {
+	normalize := func(driver string, column *dbal.Column) *dbal.Column {
+		normalized := dbal.Grammars[driver].NormalizeColumn(column)
+		assert.NotSame(t, column, normalized, "the %s grammar should not change the given column", driver)
+		return normalized
+	}
+
+	// MySQL
+	column := normalize("mysql", &dbal.Column{Name: "enabled", Type: "boolean", Default: true})
+	assert.Equal(t, "tinyInteger", column.Type, "the mysql booleans should be TINYINT(1)")
+	assert.Equal(t, 1, column.Default, "the mysql boolean defaults should be numbers")
+	assert.Equal(t, "dateTime", normalize("mysql", &dbal.Column{Type: "dateTimeTz"}).Type, "the mysql dateTimeTz should be DATETIME")
+	assert.Equal(t, "json", normalize("mysql", &dbal.Column{Type: "json"}).Type, "the mysql json should be restored from the type hint")
+	assert.True(t, normalize("mysql", &dbal.Column{Type: "integer", IsUnsigned: true}).IsUnsigned, "the mysql unsigned should be kept")
+
+	// Postgres
+	column = normalize("postgres", &dbal.Column{Type: "tinyInteger", IsUnsigned: true})
+	assert.Equal(t, "smallInteger", column.Type, "the postgres tinyInteger should be SMALLINT")
+	assert.False(t, column.IsUnsigned, "the postgres unsigned should be a check constraint")
+	assert.Equal(t, "text", normalize("postgres", &dbal.Column{Type: "longText"}).Type, "the postgres longText should be TEXT")
+	assert.Equal(t, "timestamp", normalize("postgres", &dbal.Column{Type: "dateTime"}).Type, "the postgres dateTime should be TIMESTAMP")
+	assert.Equal(t, "year", normalize("postgres", &dbal.Column{Type: "year"}).Type, "the postgres year should be restored from the type hint")
+	column = normalize("postgres", &dbal.Column{Type: "integer", Extra: utils.StringPtr("VirtualGenerated")})
+	assert.Equal(t, "StoredGenerated", utils.StringVal(column.Extra), "the postgres generated columns should be stored")
+
+	// SQLite
+	column = normalize("sqlite3", &dbal.Column{Type: "bigInteger", IsUnsigned: true, Extra: utils.StringPtr("AutoIncrement")})
+	assert.Equal(t, "integer", column.Type, "the sqlite auto-increment columns should be INTEGER")
+	column = normalize("sqlite3", &dbal.Column{Type: "string", Comment: utils.StringPtr("the name")})
+	assert.True(t, column.Nullable, "the sqlite NOT NULL columns without default should be NULL")
+	assert.Nil(t, column.Comment, "the sqlite comments should be ignored")
+	assert.False(t, normalize("sqlite3", &dbal.Column{Type: "integer", IsUnsigned: true}).IsUnsigned, "the sqlite unsigned integers should not be kept")
+
+	// Dameng
+	column = normalize("dameng", &dbal.Column{Type: "dateTime", IsUnsigned: true})
+	assert.Equal(t, "timestamp", column.Type, "the dameng dateTime should be TIMESTAMP")
+	assert.False(t, column.IsUnsigned, "the dameng unsigned should not be supported")
+	assert.Equal(t, "text", normalize("dameng", &dbal.Column{Type: "mediumText"}).Type, "the dameng mediumText should be CLOB")
+}
+
+func TestDiffClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_diff")
+}
+
+func testDiffProfiles(table Blueprint) {
+	table.ID("id")
+	table.String("name", 80).Index()
+	table.String("status", 20).SetDefault("unknown")
+	table.Integer("score").SetDefault(0)
+	table.UnsignedInteger("visits").SetDefault(0)
+	table.Decimal("balance", 10, 2).SetDefault(0)
+	table.Boolean("enabled").SetDefault(true)
+	table.Enum("kind", []string{"person", "company"}).SetDefault("person")
+	table.JSON("meta").Null().SetComment("the profile meta")
+	table.UUID("uid").Null()
+	table.TimestampTz("created_at").SetDefaultRaw("NOW()")
+	table.DateTime("deleted_at").Null()
+}
+
+func testDiffTypes(table Blueprint) {
+	table.ID("id")
+	table.TinyInteger("level").SetDefault(1)
+	table.UnsignedSmallInteger("rank").SetDefault(0)
+	table.UnsignedInteger("visits").SetDefault(0)
+	table.BigInteger("views").Null()
+	table.Boolean("enabled").SetDefault(false)
+	table.Decimal("balance", 10, 2).SetDefault(0)
+	table.Double("rate", 8, 2).Null()
+	table.String("name", 80).Index()
+	table.Char("code", 8).Null().SetComment("the short code")
+	table.Text("bio").Null()
+	table.LongText("content").Null()
+	table.JSON("meta").Null()
+	table.UUID("uid").Null()
+	table.Year("since").Null()
+	table.Enum("kind", []string{"person", "company"}).SetDefault("person")
+	table.Date("birthday").Null()
+	table.DateTime("logged_at").Null()
+	table.DateTimeTz("verified_at").Null()
+	table.Timestamps()
+}
+
+func testDiffUsers(table Blueprint) {
+	table.ID("id")
+	table.String("name", 80).Index()
+	table.String("email", 100).Unique()
+}
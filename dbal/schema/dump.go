@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// Dump write the CREATE TABLE, CREATE INDEX and COMMENT statements of the given tables (all tables if no table given)
+// using the grammar of the given driver, the current driver is used if the driver is empty.
+// The target driver does not need to be connected, but its grammar must be imported.
+// The referenced tables are written first.
+//
+//	err := builder.Dump(os.Stdout, "postgres", "users", "posts")
+func (builder *Builder) Dump(w io.Writer, driver string, tables ...string) error {
+	doc, err := builder.Export(tables...)
+	if err != nil {
+		return err
+	}
+
+	grammar, pretend, err := builder.dumpGrammar(driver)
+	if err != nil {
+		return err
+	}
+
+	sorted, err := sortTableDocuments(doc.Tables)
+	if err != nil {
+		return err
+	}
+
+	for _, tableDoc := range sorted {
+		err = validateTableDocument(tableDoc)
+		if err != nil {
+			return err
+		}
+		table := NewTable(tableDoc.Name, builder)
+		table.Table.SchemaName = grammar.GetSchema()
+		table.Table.DBName = grammar.GetDatabase()
+		importTable(table, tableDoc)
+		err = grammar.CreateTable(table.Table)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, stmt := range pretend.Statements {
+		_, err = fmt.Fprintf(w, "%s;\n\n", strings.TrimRight(strings.TrimSpace(stmt), ";"))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MustDump write the CREATE TABLE, CREATE INDEX and COMMENT statements of the given tables using the grammar of the given driver.
+func (builder *Builder) MustDump(w io.Writer, driver string, tables ...string) {
+	err := builder.Dump(w, driver, tables...)
+	utils.PanicIF(err)
+}
+
+// dumpGrammar create a grammar of the given driver in the pretend mode, so the statements are collected instead of executed.
+// The grammar of another driver is not bound to a database, the statements are compiled without any catalog lookups.
+func (builder *Builder) dumpGrammar(driver string) (dbal.Grammar, *dbal.Pretend, error) {
+	db := builder.Conn.Write
+	config := builder.Conn.WriteConfig
+	if driver != "" && driver != config.Driver {
+		db = nil
+		config = &dbal.Config{Driver: driver}
+	}
+
+	grammar, has := dbal.Grammars[config.Driver]
+	if !has {
+		return nil, nil, fmt.Errorf("the %s driver not import", config.Driver)
+	}
+
+	option := dbal.Option{}
+	if builder.Conn.Option != nil {
+		option = *builder.Conn.Option
+	}
+	option.Pretend = &dbal.Pretend{Statements: []string{}}
+
+	grammar, err := grammar.NewWith(db, config, &option)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grammar, option.Pretend, nil
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestDumpDump(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testExportCreateTables(builder)
+
+	buf := &bytes.Buffer{}
+	err := builder.Dump(buf, "", "table_test_export_posts", "table_test_export_users")
+	assert.Nil(t, err, "the dump should be succeed")
+	sql := buf.String()
+	assert.Contains(t, sql, "CREATE TABLE", "the dump should have the create table statements")
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("table_test_export_users")), bytes.Index(buf.Bytes(), []byte("table_test_export_posts")), "the referenced table should be dumped first")
+	assert.False(t, builder.MustHasTable("table_test_dump_none"), "the dump should not change the database")
+
+	// the dumped statements should create the same tables
+	TestExportClean(t)
+	_, err = builder.DB().Exec(sql)
+	assert.Nil(t, err, "the dumped statements should be executed")
+	users := builder.MustGetTable("table_test_export_users")
+	assert.True(t, users.HasColumn("id", "name", "email", "status", "score"), "the users columns should be created")
+	assert.True(t, users.HasIndex("name_index", "email_unique"), "the users indexes should be created")
+	state := exportColumn(users.GetColumn("state").Column)
+	assert.Equal(t, "unknown", state.Default, "the string default should be kept")
+	assert.Equal(t, "", state.DefaultRaw, "the string default should not be a time function")
+	if unit.Not("sqlite3") {
+		assert.Equal(t, "the user meta", columnComment(users.GetColumn("meta").Column), "the column comment should be kept")
+		assert.NotContains(t, sql, "T:json|T:json|", "the type hint should not be written twice")
+	}
+}
+
+func TestDumpOtherDriver(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testExportCreateTables(builder)
+
+	buf := &bytes.Buffer{}
+	builder.MustDump(buf, "postgres", "table_test_export_users", "table_test_export_posts")
+	sql := buf.String()
+	assert.Contains(t, sql, `CREATE TABLE "table_test_export_users"`, "the postgres table name should be quoted")
+	assert.Contains(t, sql, "CHARACTER VARYING(80)", "the postgres type should be used")
+	assert.Contains(t, sql, `CREATE INDEX "table_test_export_users_name_index"`, "the postgres index should be created")
+	assert.Contains(t, sql, `"state" CHARACTER VARYING(20)  NOT NULL DEFAULT 'unknown'`, "the string default should be kept")
+	assert.NotRegexp(t, `"state"[^\n]*NOW\(\)`, sql, "the string default should not be a time function")
+	assert.NotContains(t, sql, "T:json|", "the type hint of the source should not be dumped")
+	if unit.Not("sqlite3") { // the column comment is not supported by SQLite
+		assert.Contains(t, sql, `"meta" is 'the user meta'`, "the column comment should be dumped")
+	}
+
+	buf.Reset()
+	builder.MustDump(buf, "mysql", "table_test_export_users")
+	sql = buf.String()
+	assert.Contains(t, sql, "CREATE TABLE `table_test_export_users`", "the mysql table name should be quoted")
+	assert.Contains(t, sql, "AUTO_INCREMENT", "the mysql auto increment should be used")
+	assert.Contains(t, sql, "`state` VARCHAR(20)  NOT NULL DEFAULT 'unknown'", "the string default should be kept")
+	assert.NotRegexp(t, "`state`[^\n]*NOW\\(\\)|`state`[^\n]*CURRENT_TIMESTAMP", sql, "the string default should not be a time function")
+	assert.NotContains(t, sql, "T:json|T:json|", "the type hint should not be written twice")
+	if unit.Not("sqlite3") {
+		assert.Contains(t, sql, "the user meta'", "the column comment should be dumped")
+	}
+
+	// the dump is written from the source connection only, e.g. MySQL => Postgres and SQLite => MySQL
+	for _, driver := range []string{"mysql", "postgres", "sqlite3"} {
+		if unit.DriverIs(driver) {
+			continue
+		}
+		grammar, pretend, err := builder.(*Builder).dumpGrammar(driver)
+		assert.Nil(t, err, "the %s grammar should be created without a connection", driver)
+		assert.Equal(t, "", grammar.GetDatabase(), "the %s grammar should not use the database of the source", driver)
+		assert.Equal(t, 0, len(pretend.Statements), "the %s grammar should collect the statements", driver)
+		if driver == "postgres" {
+			assert.Equal(t, "public", grammar.GetSchema(), "the postgres grammar should use the default schema")
+		}
+
+		buf.Reset()
+		err = builder.Dump(buf, driver, "table_test_export_users", "table_test_export_posts")
+		assert.Nil(t, err, "the %s dump should be succeed", driver)
+		assert.Contains(t, buf.String(), "CREATE TABLE", "the %s dump should have the create table statements", driver)
+		assert.NotContains(t, buf.String(), `"."`, "the %s tables should not be in the schema of the source", driver)
+	}
+
+	_, err := dbal.Grammars["postgres"].NewWith(nil, &dbal.Config{Driver: "postgres"}, &dbal.Option{})
+	assert.NotNil(t, err, "the grammar without a connection should be in the pretend mode")
+
+	err = builder.Dump(buf, "unknown", "table_test_export_users")
+	assert.NotNil(t, err, "the unknown driver should be failed")
+}
+
+func TestDumpClean(t *testing.T) {
+	TestExportClean(t)
+}
@@ -0,0 +1,425 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentVersion the version of the schema document format
+const DocumentVersion = "1"
+
+// the type hint of the column comment, e.g. T:json|the comment
+var reTypeHint = regexp.MustCompile(`^T:[a-zA-Z]+\|`)
+
+// the current time functions of the drivers, e.g. now(), CURRENT_TIMESTAMP(6), (datetime('now','localtime'))
+var reCurrentTime = regexp.MustCompile(`(?i)^\(?\s*(now\(\s*\)|current_timestamp(\(\s*\d*\s*\))?|localtimestamp(\(\s*\d*\s*\))?|sysdate(\(\s*\))?|datetime\(\s*'now'[^)]*\))\s*\)?$`)
+
+// NewDocument parse the schema document from the JSON or YAML data
+func NewDocument(data []byte) (*Document, error) {
+	doc := &Document{}
+	err := yaml.Unmarshal(data, doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// JSON encode the schema document as JSON
+func (doc *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML encode the schema document as YAML
+func (doc *Document) YAML() ([]byte, error) {
+	return yaml.Marshal(doc)
+}
+
+// Export describe the given tables (all tables if no table given) as a dialect-neutral document.
+// The table names are without the prefix, the columns are described by the Blueprint types.
+//
+//	doc, err := builder.Export("users", "posts")
+//	data, err := doc.YAML()
+func (builder *Builder) Export(tables ...string) (*Document, error) {
+	if len(tables) == 0 {
+		names, err := builder.GetTables()
+		if err != nil {
+			return nil, err
+		}
+		tables = names
+	}
+
+	doc := &Document{Version: DocumentVersion, Tables: []TableDocument{}}
+	for _, name := range tables {
+		table, err := builder.GetTable(name)
+		if err != nil {
+			return nil, err
+		}
+		doc.Tables = append(doc.Tables, builder.exportTable(name, table.Get()))
+	}
+	return doc, nil
+}
+
+// MustExport describe the given tables (all tables if no table given) as a dialect-neutral document.
+func (builder *Builder) MustExport(tables ...string) *Document {
+	doc, err := builder.Export(tables...)
+	utils.PanicIF(err)
+	return doc
+}
+
+// Import create the tables described by the document, the referenced tables are created first.
+//
+//	doc, err := schema.NewDocument(data)
+//	err = builder.Import(doc)
+func (builder *Builder) Import(doc *Document) error {
+	if doc.Version != DocumentVersion {
+		return fmt.Errorf("the schema document version %s is not supported", doc.Version)
+	}
+
+	tables, err := sortTableDocuments(doc.Tables)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		err = validateTableDocument(table)
+		if err != nil {
+			return err
+		}
+		err = builder.CreateTable(table.Name, func(blueprint Blueprint) {
+			importTable(blueprint.Get(), table)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MustImport create the tables described by the document, the referenced tables are created first.
+func (builder *Builder) MustImport(doc *Document) {
+	err := builder.Import(doc)
+	utils.PanicIF(err)
+}
+
+// exportTable describe the introspected table
+func (builder *Builder) exportTable(name string, table *Table) TableDocument {
+	doc := TableDocument{
+		Name:     name,
+		Columns:  []ColumnDocument{},
+		Primary:  []string{},
+		Indexes:  []IndexDocument{},
+		Foreigns: []ForeignDocument{},
+	}
+
+	for _, column := range table.Table.Columns {
+		doc.Columns = append(doc.Columns, exportColumn(column))
+	}
+
+	if table.Table.Primary != nil {
+		doc.Primary = strings.Split(columnNames(table.Table.Primary.Columns), ",")
+	}
+
+	for _, index := range table.Table.Indexes {
+		if index.Primary || index.Type == "primary" {
+			continue
+		}
+		doc.Indexes = append(doc.Indexes, IndexDocument{
+			Name:    index.Name,
+			Type:    index.Type,
+			Columns: exportIndexParts(index),
+			Where:   index.Predicate,
+			Using:   exportIndexMethod(index),
+			Include: index.Include,
+		})
+	}
+
+	for _, foreign := range table.Table.ForeignKeys {
+		doc.Foreigns = append(doc.Foreigns, ForeignDocument{
+			Name:       foreign.Name,
+			Columns:    strings.Split(columnNames(foreign.Columns), ","),
+			On:         strings.TrimPrefix(foreign.ReferencedTable, table.Prefix),
+			References: foreign.ReferencedColumns,
+			OnDelete:   foreign.OnDelete,
+			OnUpdate:   foreign.OnUpdate,
+		})
+	}
+
+	// the order of the introspected indexes and foreign keys depends on the driver
+	sort.Slice(doc.Indexes, func(i, j int) bool { return doc.Indexes[i].Name < doc.Indexes[j].Name })
+	sort.Slice(doc.Foreigns, func(i, j int) bool { return doc.Foreigns[i].Name < doc.Foreigns[j].Name })
+	return doc
+}
+
+// exportIndexParts describe the key parts of the index in the AddIndex form, e.g. name(10) DESC, lower(email)
+func exportIndexParts(index *dbal.Index) []string {
+	parts := []string{}
+	for _, part := range index.KeyParts() {
+		spec := part.Expression
+		if part.Column != nil {
+			spec = part.Column.Name
+			if part.Length > 0 {
+				spec = fmt.Sprintf("%s(%d)", spec, part.Length)
+			}
+		}
+		if part.OpClass != "" {
+			spec = spec + " " + part.OpClass
+		}
+		if part.Order != "" {
+			spec = spec + " " + part.Order
+		}
+		parts = append(parts, spec)
+	}
+	return parts
+}
+
+// exportIndexMethod get the index method, only the Postgres methods other than btree are kept
+func exportIndexMethod(index *dbal.Index) string {
+	switch strings.ToUpper(index.IndexType) {
+	case "GIN", "GIST", "BRIN", "HASH", "SPGIST":
+		return strings.ToLower(index.IndexType)
+	}
+	return ""
+}
+
+// exportColumn describe the introspected column, only the attributes of the column type are kept
+func exportColumn(column *dbal.Column) ColumnDocument {
+	doc := ColumnDocument{
+		Name:            column.Name,
+		Type:            column.Type,
+		Nullable:        column.Nullable,
+		Unsigned:        column.IsUnsigned,
+		AutoIncrement:   utils.StringVal(column.Extra) == "AutoIncrement",
+		Comment:         columnComment(column),
+		Option:          column.Option,
+		OnUpdateCurrent: column.OnUpdateCurrent,
+	}
+
+	switch column.Type {
+	case "string", "char", "binary":
+		doc.Length = column.Length
+	case "decimal", "unsignedDecimal", "float", "unsignedFloat", "double", "unsignedDouble":
+		doc.Precision = column.Precision
+		doc.Scale = column.Scale
+	case "dateTime", "dateTimeTz", "time", "timeTz", "timestamp", "timestampTz":
+		doc.DateTimePrecision = column.DateTimePrecision
+	}
+
+	switch utils.StringVal(column.Extra) {
+	case "StoredGenerated":
+		doc.StoredAs = column.GenerationExpression
+	case "VirtualGenerated":
+		doc.VirtualAs = column.GenerationExpression
+	}
+
+	if !doc.AutoIncrement && !column.IsGenerated() {
+		doc.Default, doc.DefaultRaw = exportDefault(column)
+	}
+	return doc
+}
+
+// exportDefault normalize the introspected default value of the column.
+// The current time functions of the date and time columns are described as the NOW() raw value, the quotes and the casts are removed.
+func exportDefault(column *dbal.Column) (interface{}, string) {
+	if column.DefaultRaw != "" {
+		return nil, column.DefaultRaw
+	}
+	if column.Default == nil {
+		return nil, ""
+	}
+
+	value := fmt.Sprintf("%v", column.Default)
+	if bytes, ok := column.Default.([]byte); ok {
+		value = string(bytes)
+	}
+
+	if isTimeType(column.Type) && reCurrentTime.MatchString(value) {
+		return nil, "NOW()"
+	}
+
+	// 'value'::character varying
+	if pos := strings.LastIndex(value, "::"); pos > 0 && strings.HasPrefix(value, "'") {
+		value = value[:pos]
+	}
+
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+		value = strings.ReplaceAll(value, "''", "'")
+	}
+
+	switch {
+	case strings.Contains(strings.ToLower(column.Type), "integer") || column.Type == "year":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v, ""
+		}
+	case strings.Contains(column.Type, "ecimal") || strings.Contains(column.Type, "loat") || strings.Contains(column.Type, "ouble"):
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v, ""
+		}
+	case column.Type == "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v, ""
+		}
+	}
+	return value, ""
+}
+
+// columnComment get the comment of the column without the type hint (T:json|the comment) written by the grammars
+func columnComment(column *dbal.Column) string {
+	return reTypeHint.ReplaceAllString(utils.StringVal(column.Comment), "")
+}
+
+// isTimeType check if the column type is a date or time type
+func isTimeType(typ string) bool {
+	return strings.HasPrefix(typ, "date") || strings.HasPrefix(typ, "time")
+}
+
+// validateTableDocument check the table document before creating the table, the blueprint panics on the invalid definitions
+func validateTableDocument(doc TableDocument) error {
+	columns := map[string]bool{}
+	for _, column := range doc.Columns {
+		columns[column.Name] = true
+	}
+
+	for _, foreign := range doc.Foreigns {
+		for _, name := range foreign.Columns {
+			if !columns[name] {
+				return fmt.Errorf("the column %s of the foreign key %s does not exist in the table %s", name, foreign.Name, doc.Name)
+			}
+		}
+		for _, action := range []string{foreign.OnDelete, foreign.OnUpdate} {
+			if action == "" {
+				continue
+			}
+			if _, err := parseForeignAction(action); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importTable add the columns, the primary key, the indexes and the foreign keys to the blueprint
+func importTable(table *Table, doc TableDocument) {
+	for _, col := range doc.Columns {
+		column := table.newColumn(col.Name).SetType(col.Type)
+		column.Length = col.Length
+		column.Precision = col.Precision
+		column.Scale = col.Scale
+		column.DateTimePrecision = col.DateTimePrecision
+		column.Option = col.Option
+		column.Nullable = col.Nullable
+		column.IsUnsigned = col.Unsigned
+		if col.AutoIncrement {
+			column.AutoIncrement()
+		}
+		if col.StoredAs != "" {
+			column.StoredAs(col.StoredAs)
+		} else if col.VirtualAs != "" {
+			column.VirtualAs(col.VirtualAs)
+		}
+		if col.Default != nil {
+			column.SetDefault(col.Default)
+		}
+		if col.DefaultRaw != "" {
+			column.SetDefaultRaw(col.DefaultRaw)
+		}
+		if col.OnUpdateCurrent {
+			column.UseCurrentOnUpdate()
+		}
+		if col.Comment != "" {
+			column.SetComment(col.Comment)
+		}
+		table.putColumn(column)
+	}
+
+	if len(doc.Primary) > 0 {
+		table.AddPrimary(doc.Primary...)
+	}
+
+	for _, index := range doc.Indexes {
+		var idx *Index
+		switch index.Type {
+		case "unique":
+			idx = table.AddUnique(index.Name, index.Columns...)
+		case "spatial":
+			idx = table.AddSpatialIndex(index.Name, index.Columns...)
+		default:
+			idx = table.AddIndex(index.Name, index.Columns...)
+		}
+		idx.Where(index.Where)
+		if index.Using != "" {
+			idx.Using(index.Using)
+		}
+		if len(index.Include) > 0 {
+			idx.Include(index.Include...)
+		}
+	}
+
+	for _, foreign := range doc.Foreigns {
+		key := table.Foreign(foreign.Columns...).References(foreign.References...).On(foreign.On)
+		if foreign.OnDelete != "" {
+			key.OnDelete(foreign.OnDelete)
+		}
+		if foreign.OnUpdate != "" {
+			key.OnUpdate(foreign.OnUpdate)
+		}
+		if foreign.Name != "" {
+			key.SetName(foreign.Name)
+		}
+	}
+}
+
+// sortTableDocuments sort the tables by the foreign keys, the referenced tables come first
+func sortTableDocuments(tables []TableDocument) ([]TableDocument, error) {
+	names := map[string]int{}
+	for i, table := range tables {
+		names[table.Name] = i
+	}
+
+	sorted := []TableDocument{}
+	state := map[string]int{} // 1: visiting, 2: visited
+	var visit func(table TableDocument) error
+	visit = func(table TableDocument) error {
+		switch state[table.Name] {
+		case 1:
+			return fmt.Errorf("the table %s has circular foreign keys", table.Name)
+		case 2:
+			return nil
+		}
+		state[table.Name] = 1
+
+		refs := []string{}
+		for _, foreign := range table.Foreigns {
+			refs = append(refs, foreign.On)
+		}
+		sort.Strings(refs)
+		for _, ref := range refs {
+			i, has := names[ref]
+			if !has || ref == table.Name {
+				continue
+			}
+			if err := visit(tables[i]); err != nil {
+				return err
+			}
+		}
+
+		state[table.Name] = 2
+		sorted = append(sorted, table)
+		return nil
+	}
+
+	for _, table := range tables {
+		if err := visit(table); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestExportExport(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testExportCreateTables(builder)
+
+	doc := builder.MustExport("table_test_export_users", "table_test_export_posts")
+	assert.Equal(t, DocumentVersion, doc.Version, "the version should be %s", DocumentVersion)
+	assert.Equal(t, 2, len(doc.Tables), "the document should have 2 tables")
+
+	users := doc.Tables[0]
+	assert.Equal(t, "table_test_export_users", users.Name, "the first table should be users")
+	assert.Equal(t, []string{"id"}, users.Primary, "the primary key should be id")
+	for _, column := range users.Columns {
+		switch column.Name {
+		case "id":
+			assert.True(t, column.AutoIncrement, "the id should be auto increment")
+		case "name":
+			assert.Equal(t, "string", column.Type, "the name type should be string")
+			assert.Equal(t, 80, *column.Length, "the name length should be 80")
+			if unit.Not("sqlite3") { // the column comment is not supported by SQLite
+				assert.Equal(t, "the user name", column.Comment, "the name comment should be kept")
+			}
+		case "status":
+			assert.Equal(t, "active", column.Default, "the status default should be unquoted")
+		case "state":
+			assert.Equal(t, "unknown", column.Default, "the state default should be kept")
+			assert.Equal(t, "", column.DefaultRaw, "the state default should not be a time function")
+		case "meta":
+			if unit.Not("sqlite3") {
+				assert.Equal(t, "the user meta", column.Comment, "the meta comment should be without the type hint")
+			}
+		case "created_at":
+			assert.Equal(t, "NOW()", column.DefaultRaw, "the created_at default should be the current time")
+		case "score":
+			assert.EqualValues(t, 0, column.Default, "the score default should be 0")
+		}
+	}
+
+	posts := doc.Tables[1]
+	assert.Equal(t, 1, len(posts.Foreigns), "the posts table should have a foreign key")
+	if len(posts.Foreigns) == 1 {
+		assert.Equal(t, "table_test_export_users", posts.Foreigns[0].On, "the foreign key should reference users")
+		assert.Equal(t, []string{"user_id"}, posts.Foreigns[0].Columns, "the foreign key columns should be user_id")
+		assert.Equal(t, "CASCADE", posts.Foreigns[0].OnDelete, "the on delete action should be cascade")
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testExportCreateTables(builder)
+	doc := builder.MustExport("table_test_export_users", "table_test_export_posts")
+
+	data, err := doc.JSON()
+	assert.Nil(t, err, "the JSON encoding should be succeed")
+	fromJSON, err := NewDocument(data)
+	assert.Nil(t, err, "the JSON decoding should be succeed")
+
+	data, err = fromJSON.YAML()
+	assert.Nil(t, err, "the YAML encoding should be succeed")
+	fromYAML, err := NewDocument(data)
+	assert.Nil(t, err, "the YAML decoding should be succeed")
+
+	// the posts table references the users table, it should be created last
+	fromYAML.Tables[0], fromYAML.Tables[1] = fromYAML.Tables[1], fromYAML.Tables[0]
+	TestExportClean(t)
+	err = builder.Import(fromYAML)
+	assert.Nil(t, err, "the import should be succeed")
+
+	users := builder.MustGetTable("table_test_export_users")
+	assert.True(t, users.HasColumn("id", "name", "status", "score"), "the users columns should be created")
+	assert.Equal(t, 80, *users.GetColumn("name").Length, "the name length should be 80")
+	assert.True(t, users.HasIndex("name_index"), "the name_index should be created")
+	assert.Equal(t, "unique", users.GetIndex("email_unique").Type, "the email_unique should be unique")
+
+	posts := builder.MustGetTable("table_test_export_posts")
+	assert.Equal(t, 1, len(posts.Get().Table.ForeignKeys), "the posts foreign key should be created")
+
+	imported := builder.MustExport("table_test_export_users", "table_test_export_posts")
+	assert.Equal(t, doc, imported, "the imported tables should be the same as the exported ones")
+
+	err = builder.Import(&Document{Version: "0"})
+	assert.NotNil(t, err, "the unknown version should be failed")
+
+	invalid := &Document{Version: DocumentVersion, Tables: []TableDocument{{
+		Name:     "table_test_export_invalid",
+		Columns:  []ColumnDocument{{Name: "user_id", Type: "bigInteger"}},
+		Foreigns: []ForeignDocument{{Columns: []string{"user_id"}, On: "table_test_export_users", References: []string{"id"}, OnDelete: "cascde"}},
+	}}}
+	err = builder.Import(invalid)
+	assert.NotNil(t, err, "the unknown referential action should be failed")
+	assert.False(t, builder.MustHasTable("table_test_export_invalid"), "the invalid table should not be created")
+
+	invalid.Tables[0].Foreigns[0].OnDelete = "cascade"
+	invalid.Tables[0].Foreigns[0].Columns = []string{"author_id"}
+	err = builder.Import(invalid)
+	assert.NotNil(t, err, "the unknown column should be failed")
+}
+
+func TestExportClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_export_posts")
+	builder.DropTableIfExists("table_test_export_users")
+}
+
+func testExportCreateTables(builder Schema) {
+	builder.DropTableIfExists("table_test_export_posts")
+	builder.DropTableIfExists("table_test_export_users")
+	builder.MustCreateTable("table_test_export_users", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index().SetComment("the user name")
+		table.String("email", 100).Unique()
+		table.String("status", 20).SetDefault("active")
+		table.String("state", 20).SetDefault("unknown")
+		table.Integer("score").SetDefault(0)
+		table.Decimal("balance", 10, 2).Null()
+		table.JSON("meta").Null().SetComment("the user meta")
+		table.Timestamps()
+	})
+	builder.MustCreateTable("table_test_export_posts", func(table Blueprint) {
+		table.ID("id")
+		table.ForeignID("user_id")
+		table.Text("content").Null()
+		table.Foreign("user_id").References("id").On("table_test_export_users").OnDelete("cascade")
+	})
+}
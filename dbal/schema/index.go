@@ -1,9 +1,20 @@
 package schema
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/yaoapp/xun/dbal"
 )
 
+// the column key part with the prefix length, e.g. name(10)
+var reIndexPrefix = regexp.MustCompile(`^(\w+)\s*\((\d+)\)$`)
+
+// the key part with the operator class, e.g. data jsonb_path_ops
+var reIndexOpClass = regexp.MustCompile(`^(.+?)\s+(\w+_ops)$`)
+
 // GetIndex get the index instance for the given name,if the index does not exist return nil.
 func (table *Table) GetIndex(name string) *Index {
 	return table.IndexMap[name]
@@ -22,18 +33,18 @@ func (table *Table) HasIndex(name ...string) bool {
 }
 
 // AddIndex Indicate that the given index should be created.
-func (table *Table) AddIndex(key string, columnNames ...string) *Table {
-	columns := []*Column{}
-	for _, name := range columnNames {
-		columns = append(columns, table.GetColumn(name))
-	}
-	index := table.newIndex(key, columns...)
+// The key part is a column, a column with the prefix length or an expression, followed by the order optionally.
+//
+//	table.AddIndex("name_created_at", "name(10)", "created_at DESC")
+//	table.AddIndex("email_lower", "lower(email)").Where("deleted_at IS NULL")
+func (table *Table) AddIndex(key string, columnNames ...string) *Index {
+	index := table.newIndexWithParts(key, columnNames...)
 	index.Type = "index"
 	table.pushIndex(index)
 	table.createIndexCommand(index.Index, nil, func() {
 		delete(table.IndexMap, index.Name)
 	})
-	return table
+	return index
 }
 
 // AddFulltext Indicate that the given fulltext index should be created.(donthing here)
@@ -42,18 +53,56 @@ func (table *Table) AddFulltext(key string, columnNames ...string) *Table {
 }
 
 // AddUnique Indicate that the given unique index should be created.
-func (table *Table) AddUnique(key string, columnNames ...string) *Table {
-	columns := []*Column{}
-	for _, name := range columnNames {
-		columns = append(columns, table.GetColumn(name))
-	}
-	index := table.newIndex(key, columns...)
+// The key parts are the same as the AddIndex.
+func (table *Table) AddUnique(key string, columnNames ...string) *Index {
+	index := table.newIndexWithParts(key, columnNames...)
 	index.Type = "unique"
 	table.pushIndex(index)
 	table.createIndexCommand(index.Index, nil, func() {
 		delete(table.IndexMap, index.Name)
 	})
-	return table
+	return index
+}
+
+// AddSpatialIndex Indicate that the given spatial index should be created.
+// MySQL creates a SPATIAL index (the columns should be not null), Postgres creates a GIST index,
+// SQLite stores the geometries as the WKT text, the index is a normal one.
+func (table *Table) AddSpatialIndex(key string, columnNames ...string) *Index {
+	index := table.newIndexWithParts(key, columnNames...)
+	index.Type = "spatial"
+	table.pushIndex(index)
+	table.createIndexCommand(index.Index, nil, func() {
+		delete(table.IndexMap, index.Name)
+	})
+	return index
+}
+
+// Using set the index method, e.g. btree, hash, gin, gist, brin (Postgres)
+//
+//	table.AddIndex("data_gin", "data jsonb_path_ops").Using("gin")
+func (index *Index) Using(method string) *Index {
+	index.IndexType = strings.ToUpper(method)
+	return index
+}
+
+// Include set the covering columns, they are stored in the index but not the key parts (Postgres 11+)
+func (index *Index) Include(columnNames ...string) *Index {
+	index.Index.Include = columnNames
+	return index
+}
+
+// Concurrently build the index without locking the writes of the table (Postgres).
+// The indexes of the new tables are built normally.
+func (index *Index) Concurrently() *Index {
+	index.Index.Concurrently = true
+	return index
+}
+
+// Where set the predicate of the partial index, only the rows match the predicate are indexed.
+// The partial indexes are supported by Postgres and SQLite, MySQL indexes all of the rows.
+func (index *Index) Where(predicate string) *Index {
+	index.Predicate = predicate
+	return index
 }
 
 // DropIndex Indicate that the given indexes should be dropped.
@@ -98,6 +147,63 @@ func (table *Table) newIndex(name string, columns ...*Column) *Index {
 	return index
 }
 
+// newIndexWithParts Create a new index instance with the key parts
+// "name", "name DESC", "name(10)", "name(10) DESC", "lower(email)", "lower(email) DESC", "data jsonb_path_ops"
+func (table *Table) newIndexWithParts(name string, specs ...string) *Index {
+	columns := []*Column{}
+	parts := []*dbal.IndexPart{}
+	for _, spec := range specs {
+		part := table.parseIndexPart(spec)
+		parts = append(parts, part)
+		if part.Column != nil {
+			columns = append(columns, table.GetColumn(part.Column.Name))
+		}
+	}
+
+	index := table.newIndex(name, columns...)
+	for _, part := range parts {
+		index.AddPart(part)
+	}
+	return index
+}
+
+// parseIndexPart parse the key part of the index, the unknown names which are not expressions are kept as the columns
+func (table *Table) parseIndexPart(spec string) *dbal.IndexPart {
+	part := &dbal.IndexPart{}
+	spec = strings.TrimSpace(spec)
+	upper := strings.ToUpper(spec)
+	for _, order := range []string{"ASC", "DESC"} {
+		if strings.HasSuffix(upper, " "+order) {
+			part.Order = order
+			spec = strings.TrimSpace(spec[:len(spec)-len(order)])
+			break
+		}
+	}
+
+	if matched := reIndexOpClass.FindStringSubmatch(spec); matched != nil {
+		part.OpClass = matched[2]
+		spec = matched[1]
+	}
+
+	if matched := reIndexPrefix.FindStringSubmatch(spec); matched != nil && table.HasColumn(matched[1]) {
+		part.Column = table.GetColumn(matched[1]).Column
+		part.Length, _ = strconv.Atoi(matched[2])
+		return part
+	}
+
+	if !table.HasColumn(spec) && strings.ContainsAny(spec, "() +-*/|'") {
+		part.Expression = spec
+		return part
+	}
+
+	column := table.GetColumn(spec)
+	if column == nil {
+		panic(fmt.Errorf("the column %s does not exist", spec))
+	}
+	part.Column = column.Column
+	return part
+}
+
 // pushIndex add an index to the table
 func (table *Table) pushIndex(index *Index) *Table {
 	table.Table.PushIndex(index.Index)
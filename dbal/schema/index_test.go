@@ -79,9 +79,6 @@ func TestIndexAddUnique(t *testing.T) {
 }
 
 func TestIndexRenameIndex(t *testing.T) {
-	if unit.DriverIs("sqlite3") {
-		return
-	}
 	defer unit.Catch()
 	builder := getTestBuilder()
 	TestIndexAddIndex(t)
@@ -111,6 +108,158 @@ func TestIndexDropIndex(t *testing.T) {
 	assert.False(t, table.HasIndex("field1_field2"), "the table should have not the field1_field2 index")
 }
 
+func TestIndexKeyParts(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilderInstance()
+	table := NewTable("test", builder)
+	table.String("name", 80)
+	table.String("email", 80)
+	table.Timestamp("created_at")
+	index := table.AddIndex("index_1", "name(10)", "created_at desc", "lower(email)").Where("created_at IS NOT NULL")
+
+	assert.Equal(t, 3, len(index.Parts), "the index should have 3 key parts")
+	assert.Equal(t, 2, len(index.Columns), "the index should have 2 columns")
+	if len(index.Parts) == 3 {
+		assert.Equal(t, "name", index.Parts[0].Column.Name, "the first key part should be the name column")
+		assert.Equal(t, 10, index.Parts[0].Length, "the prefix length of name should be 10")
+		assert.Equal(t, "DESC", index.Parts[1].Order, "the created_at should be descending")
+		assert.True(t, index.Parts[2].Column == nil, "the third key part should be an expression")
+		assert.Equal(t, "lower(email)", index.Parts[2].Expression, "the expression should be lower(email)")
+	}
+	assert.Equal(t, "created_at IS NOT NULL", index.Predicate, "the predicate should be set")
+}
+
+func TestIndexMethodAndOperatorClass(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilderInstance()
+	table := NewTable("test", builder)
+	table.JSONB("data")
+	table.String("name", 80)
+	table.String("email", 80)
+	index := table.AddIndex("index_1", "data jsonb_path_ops").Using("gin").Concurrently()
+	covering := table.AddUnique("index_2", "name DESC").Include("email")
+
+	assert.Equal(t, "GIN", index.IndexType, "the index method should be GIN")
+	assert.True(t, index.Index.Concurrently, "the index should be built concurrently")
+	if len(index.Parts) == 1 {
+		assert.Equal(t, "data", index.Parts[0].Column.Name, "the key part should be the data column")
+		assert.Equal(t, "jsonb_path_ops", index.Parts[0].OpClass, "the operator class should be jsonb_path_ops")
+	}
+	assert.Equal(t, []string{"email"}, covering.Index.Include, "the covering columns should be email")
+	if len(covering.Parts) == 1 {
+		assert.Equal(t, "DESC", covering.Parts[0].Order, "the name should be descending")
+		assert.Equal(t, "", covering.Parts[0].OpClass, "the operator class should be empty")
+	}
+}
+
+func TestIndexPostgresMethod(t *testing.T) {
+	defer unit.Catch()
+	if unit.Not("postgres") {
+		return
+	}
+
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_index")
+	builder.MustCreateTable("table_test_index", func(table Blueprint) {
+		table.ID("id")
+		table.JSONB("data")
+		table.String("name", 80)
+		table.AddIndex("data_gin", "data jsonb_path_ops").Using("gin").Concurrently()
+	})
+	builder.MustAlterTable("table_test_index", func(table Blueprint) {
+		table.AddIndex("name_hash", "name").Using("hash").Concurrently()
+	})
+
+	table := builder.MustGetTable("table_test_index")
+	gin := table.GetIndex("data_gin")
+	assert.NotNil(t, gin, "the data_gin index should be read back")
+	if gin != nil && len(gin.Parts) == 1 {
+		assert.Equal(t, "GIN", gin.IndexType, "the index method should be GIN")
+		assert.Equal(t, "jsonb_path_ops", gin.Parts[0].OpClass, "the operator class should be read back")
+	}
+
+	hash := table.GetIndex("name_hash")
+	assert.NotNil(t, hash, "the name_hash index should be read back")
+	if hash != nil {
+		assert.Equal(t, "HASH", hash.IndexType, "the index method should be HASH")
+	}
+}
+
+func TestIndexPartialExpressionIndex(t *testing.T) {
+	defer unit.Catch()
+	if unit.Not("sqlite3") && unit.Not("postgres") {
+		return
+	}
+
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_index")
+	builder.MustCreateTable("table_test_index", func(table Blueprint) {
+		table.ID("id")
+		table.String("email", 80)
+		table.Integer("score")
+		table.Timestamp("deleted_at").Null()
+		table.AddUnique("email_lower", "lower(email)").Where("deleted_at IS NULL")
+		table.AddIndex("score_desc", "score DESC")
+	})
+
+	db := builder.DB()
+	_, err := db.Exec("INSERT INTO table_test_index (email, score, deleted_at) VALUES ('Ada@example.com', 1, '2021-01-01 00:00:00')")
+	assert.Nil(t, err, "the insert should be succeed")
+	_, err = db.Exec("INSERT INTO table_test_index (email, score) VALUES ('ada@example.com', 2)")
+	assert.Nil(t, err, "the deleted rows should not be indexed")
+	_, err = db.Exec("INSERT INTO table_test_index (email, score) VALUES ('ADA@example.com', 3)")
+	assert.NotNil(t, err, "the lower email should be unique")
+
+	table := builder.MustGetTable("table_test_index")
+	unique := table.GetIndex("email_lower")
+	assert.NotNil(t, unique, "the email_lower index should be read back")
+	if unique != nil && len(unique.Parts) == 1 {
+		assert.Equal(t, "unique", unique.Type, "the email_lower index should be unique")
+		assert.Contains(t, unique.Parts[0].Expression, "lower", "the expression should be read back")
+		assert.Contains(t, unique.Predicate, "deleted_at IS NULL", "the predicate should be read back")
+	}
+
+	score := table.GetIndex("score_desc")
+	assert.NotNil(t, score, "the score_desc index should be read back")
+	if score != nil && len(score.Parts) == 1 {
+		assert.Equal(t, "score", score.Parts[0].Column.Name, "the key part should be the score column")
+		assert.Equal(t, "DESC", score.Parts[0].Order, "the order should be read back")
+	}
+}
+
+func TestIndexSpatialIndex(t *testing.T) {
+	defer unit.Catch()
+	if !testSpatialSupported() || unit.Is("mysql5.6") {
+		return
+	}
+
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_index")
+	builder.MustCreateTable("table_test_index", func(table Blueprint) {
+		table.ID("id")
+		table.Point("location")
+		table.Polygon("area")
+		table.AddSpatialIndex("location_spatial", "location")
+	})
+	builder.MustAlterTable("table_test_index", func(table Blueprint) {
+		table.AddSpatialIndex("area_spatial", "area")
+	})
+
+	table := builder.MustGetTable("table_test_index")
+	for _, name := range []string{"location_spatial", "area_spatial"} {
+		index := table.GetIndex(name)
+		assert.NotNil(t, index, "the %s index should be read back", name)
+		if index == nil {
+			continue
+		}
+		if unit.DriverIs("mysql") {
+			assert.Equal(t, "spatial", index.Type, "the %s index should be spatial", name)
+		} else if unit.DriverIs("postgres") {
+			assert.Equal(t, "GIST", index.IndexType, "the %s index method should be GIST", name)
+		}
+	}
+}
+
 // clean the test data
 func TestIndexClean(t *testing.T) {
 	builder := getTestBuilder()
@@ -1,6 +1,9 @@
 package schema
 
 import (
+	"io"
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
 )
@@ -23,6 +26,33 @@ type Schema interface {
 	HasTable(name string) (bool, error)
 	RenameTable(old string, new string) error
 	DropTableIfExists(name string) error
+	Pretend(callback func(schema Schema)) ([]string, error)
+	Sync(name string, callback func(table Blueprint), force ...bool) error
+	Export(tables ...string) (*Document, error)
+	Import(doc *Document) error
+	Dump(w io.Writer, driver string, tables ...string) error
+
+	GetViews() ([]*dbal.View, error)
+	CreateView(name string, query interface{}, options ...dbal.ViewOption) error
+	CreateOrReplaceView(name string, query interface{}, options ...dbal.ViewOption) error
+	DropView(name string) error
+	DropViewIfExists(name string) error
+	RefreshMaterializedView(name string) error
+
+	GetSchemas() ([]string, error)
+	CreateSchema(name string) error
+	DropSchema(name string, cascade ...bool) error
+
+	GetDatabases() ([]string, error)
+	DatabaseExists(name string) (bool, error)
+	CreateDatabase(name string, charset string, collation string) error
+	DropDatabase(name string) error
+
+	GetPartitions(name string) ([]*dbal.Partition, error)
+	AddPartition(name string, partitions ...*dbal.Partition) error
+	AddMonthlyPartitions(name string, from time.Time, months int) error
+	DropPartition(name string, partitions ...string) error
+	DetachPartition(name string, partition string) error
 
 	MustGetConnection() *dbal.Connection
 	MustGetDB() *sqlx.DB
@@ -37,6 +67,33 @@ type Schema interface {
 	MustHasTable(name string) bool
 	MustRenameTable(old string, new string) Blueprint
 	MustDropTableIfExists(name string)
+	MustPretend(callback func(schema Schema)) []string
+	MustSync(name string, callback func(table Blueprint), force ...bool)
+	MustExport(tables ...string) *Document
+	MustImport(doc *Document)
+	MustDump(w io.Writer, driver string, tables ...string)
+
+	MustGetViews() []*dbal.View
+	MustCreateView(name string, query interface{}, options ...dbal.ViewOption)
+	MustCreateOrReplaceView(name string, query interface{}, options ...dbal.ViewOption)
+	MustDropView(name string)
+	MustDropViewIfExists(name string)
+	MustRefreshMaterializedView(name string)
+
+	MustGetSchemas() []string
+	MustCreateSchema(name string)
+	MustDropSchema(name string, cascade ...bool)
+
+	MustGetDatabases() []string
+	MustDatabaseExists(name string) bool
+	MustCreateDatabase(name string, charset string, collation string)
+	MustDropDatabase(name string)
+
+	MustGetPartitions(name string) []*dbal.Partition
+	MustAddPartition(name string, partitions ...*dbal.Partition)
+	MustAddMonthlyPartitions(name string, from time.Time, months int)
+	MustDropPartition(name string, partitions ...string)
+	MustDetachPartition(name string, partition string)
 
 	DB() *sqlx.DB // alias MustGetDB
 }
@@ -53,6 +110,14 @@ type Blueprint interface {
 	GetColumns() map[string]*Column
 	GetIndexNames() []string
 	GetIndexes() map[string]*Index
+	SetComment(comment string) *Table
+	SetEngine(engine string) *Table
+	SetCharset(charset string) *Table
+	SetCollation(collation string) *Table
+	SetRowFormat(format string) *Table
+	SetAutoIncrementStart(start int) *Table
+	SetTablespace(tablespace string) *Table
+	SetFillFactor(factor int) *Table
 
 	// defined in column.go
 	GetColumn(name string) *Column
@@ -68,14 +133,25 @@ type Blueprint interface {
 	// defined in index.go
 	GetIndex(name string) *Index
 	HasIndex(name ...string) bool
-	AddIndex(name string, columnNames ...string) *Table
-	AddUnique(name string, columnNames ...string) *Table
+	AddIndex(name string, columnNames ...string) *Index
+	AddUnique(name string, columnNames ...string) *Index
+	AddSpatialIndex(name string, columnNames ...string) *Index
 	AddFulltext(name string, columnNames ...string) *Table
 	RenameIndex(old string, new string) *Index
 	DropIndex(name ...string)
 
 	// defined in constraint.go
-	// @todo: GetUniqueConstraint, AddUniqueConstraint, DropUniqueConstraint
+	GetForeign(name string) *ForeignKey
+	HasForeign(name ...string) bool
+	GetForeigns() map[string]*ForeignKey
+	Foreign(columnNames ...string) *ForeignKey
+	DropForeign(name ...string)
+	GetConstraint(name string) *Constraint
+	HasConstraint(name ...string) bool
+	GetConstraints() map[string]*Constraint
+	AddUniqueConstraint(name string, columnNames ...string) *Table
+	AddCheck(name string, expression string) *Table
+	DropConstraint(name ...string)
 
 	// defined in blueprint.go
 	// Character types
@@ -135,12 +211,19 @@ type Blueprint interface {
 	JSONB(name string) *Column
 
 	// uuid, ipAddress, macAddress, year etc.
-	// @todo: geometry, geometryCollection, point, multiPoint, polygon, multiPolygon
 	UUID(name string) *Column
 	IPAddress(name string) *Column
 	MACAddress(name string) *Column
 	Year(name string) *Column
 
+	// spatial types
+	Geometry(name string) *Column
+	GeometryCollection(name string) *Column
+	Point(name string) *Column
+	MultiPoint(name string) *Column
+	Polygon(name string) *Column
+	MultiPolygon(name string) *Column
+
 	// timestamps, timestampsTz,DropTimestamps, DropTimestampsTz, softDeletes, softDeletesTz, DropSoftDeletes, DropSoftDeletesTz
 	Timestamps(args ...int) map[string]*Column
 	TimestampsTz(args ...int) map[string]*Column
@@ -152,5 +235,14 @@ type Blueprint interface {
 	DropSoftDeletes()
 	DropSoftDeletesTz()
 
-	//@todo: morphs, nullableMorphs, uuidMorphs nullableUuidMorphs
+	Version(name ...string) *Column
+
+	// morphs, nullableMorphs, uuidMorphs, nullableUuidMorphs, ulidMorphs, nullableUlidMorphs, DropMorphs
+	Morphs(name string) map[string]*Column
+	NullableMorphs(name string) map[string]*Column
+	UUIDMorphs(name string) map[string]*Column
+	NullableUUIDMorphs(name string) map[string]*Column
+	ULIDMorphs(name string) map[string]*Column
+	NullableULIDMorphs(name string) map[string]*Column
+	DropMorphs(name string)
 }
@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"github.com/yaoapp/xun/utils"
+)
+
+// GetSchemas get the schemas (namespaces) of the database (Postgres)
+func (builder *Builder) GetSchemas() ([]string, error) {
+	return builder.Grammar.GetSchemas()
+}
+
+// MustGetSchemas get the schemas (namespaces) of the database (Postgres)
+func (builder *Builder) MustGetSchemas() []string {
+	schemas, err := builder.GetSchemas()
+	utils.PanicIF(err)
+	return schemas
+}
+
+// CreateSchema create a new schema (Postgres), the tables of the schema are named as schema.table
+//
+//	builder.CreateSchema("reporting")
+//	builder.CreateTable("reporting.daily", func(table schema.Blueprint) { ... })
+func (builder *Builder) CreateSchema(name string) error {
+	builder.forgetAllGenerated()
+	return builder.Grammar.CreateSchema(name)
+}
+
+// MustCreateSchema create a new schema (Postgres)
+func (builder *Builder) MustCreateSchema(name string) {
+	err := builder.CreateSchema(name)
+	utils.PanicIF(err)
+}
+
+// DropSchema drop the schema (Postgres), the tables of the schema are dropped if the cascade is true.
+func (builder *Builder) DropSchema(name string, cascade ...bool) error {
+	builder.forgetAllGenerated()
+	return builder.Grammar.DropSchema(name, len(cascade) > 0 && cascade[0])
+}
+
+// MustDropSchema drop the schema (Postgres)
+func (builder *Builder) MustDropSchema(name string, cascade ...bool) {
+	err := builder.DropSchema(name, cascade...)
+	utils.PanicIF(err)
+}
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestNamespaceCreateSchema(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestNamespaceClean(nil)
+	if !unit.DriverIs("postgres") {
+		assert.NotNil(t, builder.CreateSchema("table_test_namespace"), "the schema is not supported")
+		_, err := builder.GetSchemas()
+		assert.NotNil(t, err, "the schema is not supported")
+		return
+	}
+
+	builder.MustCreateSchema("table_test_namespace")
+	assert.Contains(t, builder.MustGetSchemas(), "table_test_namespace", "the schema should be listed")
+	assert.Contains(t, builder.MustGetSchemas(), builder.Builder().Schema, "the default schema should be listed")
+	assert.NotNil(t, builder.CreateSchema("table_test_namespace"), "the schema exists")
+}
+
+func TestNamespaceQualifiedTable(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestNamespaceClean(nil)
+	if !unit.DriverIs("postgres") {
+		return
+	}
+
+	builder.MustCreateSchema("table_test_namespace")
+	builder.MustCreateTable("table_test_namespace.daily", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80).Index()
+		table.Enum("status", []string{"open", "closed"})
+		table.Timestamps()
+	})
+	assert.True(t, builder.MustHasTable("table_test_namespace.daily"), "the table should be created in the schema")
+	assert.False(t, builder.MustHasTable("daily"), "the table should not be created in the default schema")
+
+	builder.MustAlterTable("table_test_namespace.daily", func(table Blueprint) {
+		table.Integer("score").Index()
+		table.RenameIndex("name_index", "name_idx")
+	})
+	table := builder.MustGetTable("table_test_namespace.daily")
+	assert.True(t, table.HasColumn("score"), "the column should be added")
+	assert.True(t, table.HasIndex("score_index"), "the index should be created")
+	assert.True(t, table.HasIndex("name_idx"), "the index should be renamed")
+	assert.Equal(t, "table_test_namespace", table.Get().SchemaName)
+
+	builder.DB().MustExec(`INSERT INTO table_test_namespace.daily (name, status, score) VALUES ('Ada', 'open', 1)`)
+	var count int
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_namespace.daily")
+	assert.Equal(t, 1, count, "the row should be inserted")
+
+	builder.MustRenameTable("table_test_namespace.daily", "table_test_namespace.daily_renamed")
+	assert.True(t, builder.MustHasTable("table_test_namespace.daily_renamed"), "the table should be renamed in the schema")
+
+	builder.MustDropTable("table_test_namespace.daily_renamed")
+	assert.False(t, builder.MustHasTable("table_test_namespace.daily_renamed"), "the table should be dropped")
+}
+
+func TestNamespaceDropSchema(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestNamespaceClean(nil)
+	if !unit.DriverIs("postgres") {
+		assert.NotNil(t, builder.DropSchema("table_test_namespace"), "the schema is not supported")
+		return
+	}
+
+	builder.MustCreateSchema("table_test_namespace")
+	builder.MustCreateTable("table_test_namespace.daily", func(table Blueprint) {
+		table.ID("id")
+	})
+	assert.NotNil(t, builder.DropSchema("table_test_namespace"), "the schema is not empty")
+	builder.MustDropSchema("table_test_namespace", true)
+	assert.NotContains(t, builder.MustGetSchemas(), "table_test_namespace", "the schema should be dropped")
+}
+
+func TestNamespaceClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropSchema("table_test_namespace", true)
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// GetPartitions get the partitions of the partitioned table (MySQL, Postgres 10+)
+func (builder *Builder) GetPartitions(name string) ([]*dbal.Partition, error) {
+	return builder.Grammar.GetPartitions(builder.table(name).GetFullName())
+}
+
+// MustGetPartitions get the partitions of the partitioned table (MySQL, Postgres 10+)
+func (builder *Builder) MustGetPartitions(name string) []*dbal.Partition {
+	partitions, err := builder.GetPartitions(name)
+	utils.PanicIF(err)
+	return partitions
+}
+
+// AddPartition add the partitions to the partitioned table, the method of the partitions is the same as the table.
+//
+//	err := builder.AddPartition("events", &dbal.Partition{Name: "p202101", From: "2021-01-01", To: "2021-02-01"})
+func (builder *Builder) AddPartition(name string, partitions ...*dbal.Partition) error {
+	table := builder.table(name).GetFullName()
+	builder.forgetGenerated(table)
+	return builder.Grammar.AddPartition(table, partitions)
+}
+
+// MustAddPartition add the partitions to the partitioned table
+func (builder *Builder) MustAddPartition(name string, partitions ...*dbal.Partition) {
+	err := builder.AddPartition(name, partitions...)
+	utils.PanicIF(err)
+}
+
+// AddMonthlyPartitions pre-create the monthly RANGE partitions from the month of the given time,
+// the existing partitions are skipped. (see dbal.MonthlyPartitions)
+//
+//	err := builder.AddMonthlyPartitions("events", time.Now(), 3)
+func (builder *Builder) AddMonthlyPartitions(name string, from time.Time, months int) error {
+	current, err := builder.GetPartitions(name)
+	if err != nil {
+		return err
+	}
+
+	exists := map[string]bool{}
+	for _, partition := range current {
+		exists[partition.Name] = true
+	}
+
+	partitions := []*dbal.Partition{}
+	for _, partition := range dbal.MonthlyPartitions(from, months) {
+		if !exists[partition.Name] {
+			partitions = append(partitions, partition)
+		}
+	}
+
+	if len(partitions) == 0 {
+		return nil
+	}
+	return builder.AddPartition(name, partitions...)
+}
+
+// MustAddMonthlyPartitions pre-create the monthly RANGE partitions from the month of the given time
+func (builder *Builder) MustAddMonthlyPartitions(name string, from time.Time, months int) {
+	err := builder.AddMonthlyPartitions(name, from, months)
+	utils.PanicIF(err)
+}
+
+// DropPartition drop the partitions of the partitioned table, the rows of the partitions are deleted.
+func (builder *Builder) DropPartition(name string, partitions ...string) error {
+	table := builder.table(name).GetFullName()
+	builder.forgetGenerated(table)
+	return builder.Grammar.DropPartition(table, partitions)
+}
+
+// MustDropPartition drop the partitions of the partitioned table
+func (builder *Builder) MustDropPartition(name string, partitions ...string) {
+	err := builder.DropPartition(name, partitions...)
+	utils.PanicIF(err)
+}
+
+// DetachPartition detach the partition from the partitioned table, the rows are kept in the table named {table}_{partition}.
+func (builder *Builder) DetachPartition(name string, partition string) error {
+	table := builder.table(name).GetFullName()
+	builder.forgetGenerated(table)
+	builder.forgetGenerated(table + "_" + partition)
+	return builder.Grammar.DetachPartition(table, partition)
+}
+
+// MustDetachPartition detach the partition from the partitioned table
+func (builder *Builder) MustDetachPartition(name string, partition string) {
+	err := builder.DetachPartition(name, partition)
+	utils.PanicIF(err)
+}
@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestPartitionMonthlyPartitions(t *testing.T) {
+	partitions := dbal.MonthlyPartitions(time.Date(2021, 11, 15, 10, 0, 0, 0, time.Local), 3)
+	assert.Equal(t, 3, len(partitions), "the partitions of 3 months should be returned")
+	assert.Equal(t, "p202111", partitions[0].Name)
+	assert.Equal(t, "2021-11-01", partitions[0].From)
+	assert.Equal(t, "2021-12-01", partitions[0].To)
+	assert.Equal(t, "p202201", partitions[2].Name)
+	assert.Equal(t, "2022-02-01", partitions[2].To)
+}
+
+func TestPartitionCreateTable(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestPartitionClean(nil)
+
+	err := testPartitionCreateTable(builder)
+	if unit.DriverIs("sqlite3") || unit.Is("postgres9.6") {
+		assert.NotNil(t, err, "the table partitioning is not supported")
+		_, err = builder.GetPartitions("table_test_partition_events")
+		assert.NotNil(t, err, "the table partitioning is not supported")
+		return
+	}
+
+	assert.Nil(t, err, "the partitioned table should be created")
+	partitions := builder.MustGetPartitions("table_test_partition_events")
+	assert.Equal(t, 2, len(partitions), "the table should have 2 partitions")
+	if len(partitions) == 2 {
+		assert.Equal(t, "p202101", partitions[0].Name)
+		assert.Equal(t, "p202102", partitions[1].Name)
+		assert.Contains(t, partitions[0].Method, "RANGE")
+	}
+
+	builder.DB().MustExec("INSERT INTO table_test_partition_events (id, name, created_at) VALUES (1, 'login', '2021-01-05 10:00:00'), (2, 'logout', '2021-02-05 10:00:00')")
+	var count int
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_partition_events")
+	assert.Equal(t, 2, count, "the rows should be stored in the partitions")
+}
+
+func TestPartitionAddMonthlyPartitions(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestPartitionClean(nil)
+	if unit.DriverIs("sqlite3") || unit.Is("postgres9.6") {
+		assert.NotNil(t, builder.AddMonthlyPartitions("table_test_partition_events", time.Now(), 3), "the table partitioning is not supported")
+		return
+	}
+
+	builder.MustCreateTable("table_test_partition_plain", func(table Blueprint) {
+		table.BigInteger("id")
+	})
+	assert.NotNil(t, builder.AddPartition("table_test_partition_plain", &dbal.Partition{Name: "p1", To: 10}), "the table is not partitioned")
+
+	err := testPartitionCreateTable(builder)
+	assert.Nil(t, err, "the partitioned table should be created")
+
+	builder.MustAddMonthlyPartitions("table_test_partition_events", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 4)
+	partitions := builder.MustGetPartitions("table_test_partition_events")
+	assert.Equal(t, 4, len(partitions), "the missing partitions should be added")
+	if len(partitions) == 4 {
+		assert.Equal(t, "p202104", partitions[3].Name)
+	}
+}
+
+func TestPartitionDropAndDetachPartition(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	TestPartitionClean(nil)
+	if unit.DriverIs("sqlite3") || unit.Is("postgres9.6") {
+		assert.NotNil(t, builder.DropPartition("table_test_partition_events", "p202101"), "the table partitioning is not supported")
+		assert.NotNil(t, builder.DetachPartition("table_test_partition_events", "p202101"), "the table partitioning is not supported")
+		return
+	}
+
+	err := testPartitionCreateTable(builder)
+	assert.Nil(t, err, "the partitioned table should be created")
+	builder.DB().MustExec("INSERT INTO table_test_partition_events (id, name, created_at) VALUES (1, 'login', '2021-01-05 10:00:00'), (2, 'logout', '2021-02-05 10:00:00')")
+
+	builder.MustDetachPartition("table_test_partition_events", "p202101")
+	assert.True(t, builder.MustHasTable("table_test_partition_events_p202101"), "the detached partition should be a table")
+	var count int
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_partition_events_p202101")
+	assert.Equal(t, 1, count, "the rows should be kept in the detached table")
+
+	builder.MustDropPartition("table_test_partition_events", "p202102")
+	assert.Equal(t, 0, len(builder.MustGetPartitions("table_test_partition_events")), "the partitions should be removed")
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_partition_events")
+	assert.Equal(t, 0, count, "the rows of the dropped partition should be deleted")
+}
+
+func TestPartitionPretend(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	if !unit.DriverIs("mysql") {
+		return
+	}
+
+	stmts := builder.MustPretend(func(schema Schema) {
+		schema.MustCreateTable("table_test_partition_events", func(table Blueprint) {
+			table.BigInteger("id")
+			table.DateTime("created_at")
+		}, dbal.CreateTableOption{Partition: dbal.PartitionByMonth("created_at", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 1)})
+	})
+	assert.Equal(t, 1, len(stmts), "the table should be created")
+	if len(stmts) == 1 {
+		assert.Contains(t, stmts[0], "PARTITION BY RANGE COLUMNS(`created_at`) (PARTITION `p202101` VALUES LESS THAN ('2021-02-01'))")
+	}
+}
+
+func TestPartitionClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_partition_events_p202101")
+	builder.DropTableIfExists("table_test_partition_events")
+	builder.DropTableIfExists("table_test_partition_plain")
+}
+
+func testPartitionCreateTable(builder Schema) error {
+	return builder.CreateTable("table_test_partition_events", func(table Blueprint) {
+		table.BigInteger("id")
+		table.String("name", 80)
+		table.DateTime("created_at")
+	}, dbal.CreateTableOption{Partition: dbal.PartitionByMonth("created_at", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 2)})
+}
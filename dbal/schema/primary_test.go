@@ -24,9 +24,6 @@ func TestPrimaryAddPrimary(t *testing.T) {
 
 func TestPrimaryAddPrimaryFail(t *testing.T) {
 	defer unit.Catch()
-	if unit.DriverIs("sqlite3") {
-		return
-	}
 	builder := getTestBuilderInstance()
 	builder.DropTableIfExists("table_test_primary")
 	TestPrimaryAddPrimary(t)
@@ -41,7 +38,7 @@ func TestPrimaryAddPrimaryFail(t *testing.T) {
 		table := builder.MustGetTable("table_test_primary")
 		primryKey := table.GetPrimary()
 		assert.True(t, primryKey == nil, "The primary key should be nil")
-	} else if unit.DriverIs("postgres") {
+	} else if unit.DriverIs("postgres") || unit.DriverIs("sqlite3") {
 		assert.True(t, err == nil, "The return error should  be nil")
 		table := builder.MustGetTable("table_test_primary")
 		primryKey := table.GetPrimary()
@@ -60,9 +57,6 @@ func TestPrimaryGetPrimary(t *testing.T) {
 
 func TestPrimaryDropPrimary(t *testing.T) {
 	defer unit.Catch()
-	if unit.DriverIs("sqlite3") {
-		return
-	}
 	builder := getTestBuilderInstance()
 	TestPrimaryAddPrimary(t)
 	builder.MustAlterTable("table_test_primary", func(table Blueprint) {
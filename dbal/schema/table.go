@@ -1,7 +1,7 @@
 package schema
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/yaoapp/xun/dbal"
 )
@@ -23,16 +23,28 @@ func (table *Table) GetFullName() string {
 
 // NewTable create a new blueprint intance
 func NewTable(name string, builder *Builder) *Table {
-	tableName := fmt.Sprintf("%s%s", builder.Conn.Option.Prefix, name)
+	tableName := dbal.NewName(name, builder.Conn.Option.Prefix).Fullname()
+
+	// the schema-qualified table (reporting.daily) is in the given schema
+	schemaName := builder.Schema
+	if i := strings.LastIndex(tableName, "."); i > 0 {
+		schemaName = tableName[:i]
+	}
+
 	table := &Table{
 		Name:        name,
 		Prefix:      builder.Conn.Option.Prefix,
-		Table:       dbal.NewTable(tableName, builder.Schema, builder.Database),
+		Table:       dbal.NewTable(tableName, schemaName, builder.Database),
 		Builder:     builder,
 		IndexNames:  []string{},
 		ColumnNames: []string{},
 		ColumnMap:   map[string]*Column{},
 		IndexMap:    map[string]*Index{},
+
+		ForeignKeyNames: []string{},
+		ForeignKeyMap:   map[string]*ForeignKey{},
+		ConstraintNames: []string{},
+		ConstraintMap:   map[string]*Constraint{},
 	}
 	return table
 }
@@ -61,3 +73,89 @@ func (table *Table) GetIndexes() map[string]*Index {
 func (table *Table) Get() *Table {
 	return table
 }
+
+// the table options, the options not supported by the driver are ignored
+
+// SetComment set the comment of the table
+func (table *Table) SetComment(comment string) *Table {
+	table.Table.Comment = comment
+	table.setTableOptionCommand("comment", comment)
+	return table
+}
+
+// SetEngine set the storage engine of the table (MySQL)
+func (table *Table) SetEngine(engine string) *Table {
+	table.Table.Engine = engine
+	table.setTableOptionCommand("engine", engine)
+	return table
+}
+
+// SetCharset set the default character set of the table (MySQL)
+func (table *Table) SetCharset(charset string) *Table {
+	table.Table.Charset = charset
+	table.setTableOptionCommand("charset", charset)
+	return table
+}
+
+// SetCollation set the default collation of the table (MySQL)
+func (table *Table) SetCollation(collation string) *Table {
+	table.Table.Collation = collation
+	table.setTableOptionCommand("collation", collation)
+	return table
+}
+
+// SetRowFormat set the row format of the table, e.g. DYNAMIC, COMPRESSED (MySQL)
+func (table *Table) SetRowFormat(format string) *Table {
+	table.Table.RowFormat = format
+	table.setTableOptionCommand("rowFormat", format)
+	return table
+}
+
+// SetAutoIncrementStart set the next value of the auto-increment column
+func (table *Table) SetAutoIncrementStart(start int) *Table {
+	table.Table.AutoIncrement = start
+	table.setTableOptionCommand("autoIncrement", start)
+	return table
+}
+
+// SetTablespace set the tablespace of the table
+func (table *Table) SetTablespace(tablespace string) *Table {
+	table.Table.Tablespace = tablespace
+	table.setTableOptionCommand("tablespace", tablespace)
+	return table
+}
+
+// SetFillFactor set the fill factor percentage of the table (Postgres, Dameng)
+func (table *Table) SetFillFactor(factor int) *Table {
+	table.Table.FillFactor = factor
+	table.setTableOptionCommand("fillFactor", factor)
+	return table
+}
+
+// setOption set the table options of creating the table
+func (table *Table) setOption(option dbal.CreateTableOption) {
+	if option.Engine != "" {
+		table.Table.Engine = option.Engine
+	}
+	if option.Comment != "" {
+		table.Table.Comment = option.Comment
+	}
+	if option.Charset != "" {
+		table.Table.Charset = option.Charset
+	}
+	if option.Collation != "" {
+		table.Table.Collation = option.Collation
+	}
+	if option.RowFormat != "" {
+		table.Table.RowFormat = option.RowFormat
+	}
+	if option.AutoIncrementStart > 0 {
+		table.Table.AutoIncrement = option.AutoIncrementStart
+	}
+	if option.Tablespace != "" {
+		table.Table.Tablespace = option.Tablespace
+	}
+	if option.FillFactor > 0 {
+		table.Table.FillFactor = option.FillFactor
+	}
+}
@@ -27,12 +27,16 @@ type Table struct {
 	*dbal.Table
 	*Builder
 	*Primary
-	ColumnNames []string
-	ColumnMap   map[string]*Column
-	IndexNames  []string
-	IndexMap    map[string]*Index
-	Name        string
-	Prefix      string
+	ColumnNames     []string
+	ColumnMap       map[string]*Column
+	IndexNames      []string
+	IndexMap        map[string]*Index
+	ForeignKeyNames []string
+	ForeignKeyMap   map[string]*ForeignKey
+	ConstraintNames []string
+	ConstraintMap   map[string]*Constraint
+	Name            string
+	Prefix          string
 }
 
 // Column the table column struct
@@ -52,3 +56,71 @@ type Primary struct {
 	*dbal.Primary
 	Table *Table
 }
+
+// ForeignKey the table foreign key constraint
+type ForeignKey struct {
+	*dbal.ForeignKey
+	Table *Table
+}
+
+// Constraint the table unique or check constraint
+type Constraint struct {
+	*dbal.Constraint
+	Table *Table
+}
+
+// Document the versioned, dialect-neutral description of the tables (see Export and Import)
+type Document struct {
+	Version string          `json:"version" yaml:"version"`
+	Tables  []TableDocument `json:"tables" yaml:"tables"`
+}
+
+// TableDocument the description of a table
+type TableDocument struct {
+	Name     string            `json:"name" yaml:"name"`
+	Columns  []ColumnDocument  `json:"columns" yaml:"columns"`
+	Primary  []string          `json:"primary,omitempty" yaml:"primary,omitempty"`
+	Indexes  []IndexDocument   `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Foreigns []ForeignDocument `json:"foreigns,omitempty" yaml:"foreigns,omitempty"`
+}
+
+// ColumnDocument the description of a column, the type is the name of the Blueprint type (string, bigInteger ...)
+type ColumnDocument struct {
+	Name              string      `json:"name" yaml:"name"`
+	Type              string      `json:"type" yaml:"type"`
+	Length            *int        `json:"length,omitempty" yaml:"length,omitempty"`
+	Precision         *int        `json:"precision,omitempty" yaml:"precision,omitempty"`
+	Scale             *int        `json:"scale,omitempty" yaml:"scale,omitempty"`
+	DateTimePrecision *int        `json:"datetime_precision,omitempty" yaml:"datetime_precision,omitempty"`
+	Nullable          bool        `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Unsigned          bool        `json:"unsigned,omitempty" yaml:"unsigned,omitempty"`
+	AutoIncrement     bool        `json:"auto_increment,omitempty" yaml:"auto_increment,omitempty"`
+	StoredAs          string      `json:"stored_as,omitempty" yaml:"stored_as,omitempty"`
+	VirtualAs         string      `json:"virtual_as,omitempty" yaml:"virtual_as,omitempty"`
+	Default           interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	DefaultRaw        string      `json:"default_raw,omitempty" yaml:"default_raw,omitempty"`
+	OnUpdateCurrent   bool        `json:"on_update_current,omitempty" yaml:"on_update_current,omitempty"`
+	Comment           string      `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Option            []string    `json:"option,omitempty" yaml:"option,omitempty"`
+}
+
+// IndexDocument the description of an index, the type is index or unique.
+// The columns are the key parts in the AddIndex form, e.g. name(10) DESC, lower(email)
+type IndexDocument struct {
+	Name    string   `json:"name" yaml:"name"`
+	Type    string   `json:"type" yaml:"type"`
+	Columns []string `json:"columns" yaml:"columns"`
+	Where   string   `json:"where,omitempty" yaml:"where,omitempty"`
+	Using   string   `json:"using,omitempty" yaml:"using,omitempty"`
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+}
+
+// ForeignDocument the description of a foreign key
+type ForeignDocument struct {
+	Name       string   `json:"name" yaml:"name"`
+	Columns    []string `json:"columns" yaml:"columns"`
+	On         string   `json:"on" yaml:"on"`
+	References []string `json:"references" yaml:"references"`
+	OnDelete   string   `json:"on_delete,omitempty" yaml:"on_delete,omitempty"`
+	OnUpdate   string   `json:"on_update,omitempty" yaml:"on_update,omitempty"`
+}
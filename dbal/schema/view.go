@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// the query builder that can be used as the view definition (query.Query)
+type viewQuery interface {
+	ToSQL() string
+	GetBindings() []interface{}
+}
+
+// GetViews Get all of the views for the schema, the view names are without the prefix.
+// Only the views with the prefix are returned if the prefix is set.
+func (builder *Builder) GetViews() ([]*dbal.View, error) {
+	views, err := builder.Grammar.GetViews()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := builder.Conn.Option.Prefix
+	if prefix == "" {
+		return views, nil
+	}
+
+	prefixed := []*dbal.View{}
+	for _, view := range views {
+		if strings.HasPrefix(view.Name, prefix) {
+			view.Name = strings.TrimPrefix(view.Name, prefix)
+			prefixed = append(prefixed, view)
+		}
+	}
+	return prefixed, nil
+}
+
+// MustGetViews Get all of the views for the schema, the view names are without the prefix.
+// Only the views with the prefix are returned if the prefix is set.
+func (builder *Builder) MustGetViews() []*dbal.View {
+	views, err := builder.GetViews()
+	utils.PanicIF(err)
+	return views
+}
+
+// CreateView create a new view, the definition is a query builder or a select statement.
+// The bindings of the query builder are inlined, the views can not be parameterized.
+//
+//	err := builder.CreateView("active_users", qb.Table("users").Where("status", "active"))
+//	err := builder.CreateView("daily_orders", "SELECT ...", dbal.ViewOption{Materialized: true})
+func (builder *Builder) CreateView(name string, query interface{}, options ...dbal.ViewOption) error {
+	view := &dbal.View{
+		DBName:     builder.Database,
+		SchemaName: builder.Schema,
+		Name:       builder.table(name).GetFullName(),
+	}
+
+	switch q := query.(type) {
+	case string:
+		view.SQL = q
+	case viewQuery:
+		view.SQL = q.ToSQL()
+		view.Bindings = q.GetBindings()
+	default:
+		return fmt.Errorf("the view definition should be a query builder or a select statement, %T given", query)
+	}
+
+	builder.forgetGenerated(view.Name)
+	return builder.Grammar.CreateView(view, options...)
+}
+
+// MustCreateView create a new view, the definition is a query builder or a select statement.
+func (builder *Builder) MustCreateView(name string, query interface{}, options ...dbal.ViewOption) {
+	err := builder.CreateView(name, query, options...)
+	utils.PanicIF(err)
+}
+
+// CreateOrReplaceView create a new view or replace the existing one, the definition is a query builder or a select statement.
+func (builder *Builder) CreateOrReplaceView(name string, query interface{}, options ...dbal.ViewOption) error {
+	option := dbal.ViewOption{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+	option.Replace = true
+	return builder.CreateView(name, query, option)
+}
+
+// MustCreateOrReplaceView create a new view or replace the existing one, the definition is a query builder or a select statement.
+func (builder *Builder) MustCreateOrReplaceView(name string, query interface{}, options ...dbal.ViewOption) {
+	err := builder.CreateOrReplaceView(name, query, options...)
+	utils.PanicIF(err)
+}
+
+// DropView drop the view
+func (builder *Builder) DropView(name string) error {
+	view := builder.table(name).GetFullName()
+	builder.forgetGenerated(view)
+	return builder.Grammar.DropView(view)
+}
+
+// MustDropView drop the view
+func (builder *Builder) MustDropView(name string) {
+	err := builder.DropView(name)
+	utils.PanicIF(err)
+}
+
+// DropViewIfExists drop the view if it exists
+func (builder *Builder) DropViewIfExists(name string) error {
+	view := builder.table(name).GetFullName()
+	builder.forgetGenerated(view)
+	return builder.Grammar.DropViewIfExists(view)
+}
+
+// MustDropViewIfExists drop the view if it exists
+func (builder *Builder) MustDropViewIfExists(name string) {
+	err := builder.DropViewIfExists(name)
+	utils.PanicIF(err)
+}
+
+// RefreshMaterializedView refresh the materialized view (Postgres only)
+func (builder *Builder) RefreshMaterializedView(name string) error {
+	return builder.Grammar.RefreshMaterializedView(builder.table(name).GetFullName())
+}
+
+// MustRefreshMaterializedView refresh the materialized view (Postgres only)
+func (builder *Builder) MustRefreshMaterializedView(name string) {
+	err := builder.RefreshMaterializedView(name)
+	utils.PanicIF(err)
+}
@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestViewCreateView(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testViewCreateTable(builder)
+
+	qb := query.New(unit.Driver(), unit.DSN())
+	err := builder.CreateView("table_test_view_active", qb.Table("table_test_view_users").Where("status", "it's active"))
+	assert.Nil(t, err, "the view should be created")
+
+	rows := []struct {
+		Name string `db:"name"`
+	}{}
+	err = builder.DB().Select(&rows, "SELECT name FROM table_test_view_active ORDER BY name")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, 2, len(rows), "the view should have 2 rows")
+
+	err = builder.CreateView("table_test_view_active", "SELECT id FROM table_test_view_users")
+	assert.NotNil(t, err, "the view exists")
+
+	view := testViewGet(builder, "table_test_view_active")
+	assert.NotNil(t, view, "the view should be listed")
+	if view != nil {
+		assert.Contains(t, view.SQL, "table_test_view_users", "the definition should be read from the catalog")
+		assert.False(t, view.Materialized, "the view should not be materialized")
+	}
+
+	tables := builder.MustGetTables()
+	assert.NotContains(t, tables, "table_test_view_active", "the views should not be listed as tables")
+	assert.False(t, builder.MustHasTable("table_test_view_active"), "the view should not be a table")
+}
+
+func TestViewCreateOrReplaceView(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testViewCreateTable(builder)
+
+	builder.MustCreateOrReplaceView("table_test_view_names", "SELECT id, name FROM table_test_view_users")
+	builder.MustCreateOrReplaceView("table_test_view_names", "SELECT id, name FROM table_test_view_users WHERE id > 1")
+
+	var count int
+	err := builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_view_names")
+	assert.Nil(t, err, "the select should be succeed")
+	assert.Equal(t, 2, count, "the view should be replaced")
+}
+
+func TestViewMaterializedView(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testViewCreateTable(builder)
+
+	err := builder.CreateView("table_test_view_materialized", "SELECT id, name FROM table_test_view_users", dbal.ViewOption{Materialized: true})
+	if unit.Not("postgres") {
+		assert.NotNil(t, err, "the materialized view is not supported")
+		assert.NotNil(t, builder.RefreshMaterializedView("table_test_view_materialized"), "the materialized view is not supported")
+		return
+	}
+
+	assert.Nil(t, err, "the materialized view should be created")
+	builder.DB().MustExec("INSERT INTO table_test_view_users (name, status) VALUES ('Dan', 'inactive')")
+
+	var count int
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_view_materialized")
+	assert.Equal(t, 3, count, "the materialized view should not be refreshed")
+
+	builder.MustRefreshMaterializedView("table_test_view_materialized")
+	builder.DB().Get(&count, "SELECT COUNT(*) FROM table_test_view_materialized")
+	assert.Equal(t, 4, count, "the materialized view should be refreshed")
+
+	view := testViewGet(builder, "table_test_view_materialized")
+	assert.True(t, view != nil && view.Materialized, "the view should be materialized")
+	builder.MustDropView("table_test_view_materialized")
+}
+
+func TestViewDropView(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testViewCreateTable(builder)
+
+	builder.MustCreateView("table_test_view_names", "SELECT id, name FROM table_test_view_users")
+	err := builder.DropView("table_test_view_names")
+	assert.Nil(t, err, "the view should be dropped")
+	assert.Nil(t, testViewGet(builder, "table_test_view_names"), "the view should be dropped")
+
+	err = builder.DropView("table_test_view_names")
+	assert.NotNil(t, err, "the view does not exist")
+
+	err = builder.DropViewIfExists("table_test_view_names")
+	assert.Nil(t, err, "the missing view should be ignored")
+}
+
+func TestViewGetViewsPrefix(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	testViewCreateTable(builder)
+	builder.MustCreateView("table_test_view_names", "SELECT id, name FROM table_test_view_users")
+
+	prefixed := newBuilder(unit.Driver(), unit.DSN())
+	prefixed.SetOption(&dbal.Option{Prefix: "xun_"})
+	prefixed.DropViewIfExists("table_test_view_prefix")
+	defer prefixed.DropViewIfExists("table_test_view_prefix")
+	prefixed.MustCreateView("table_test_view_prefix", "SELECT id, name FROM table_test_view_users")
+
+	assert.NotNil(t, testViewGet(prefixed, "table_test_view_prefix"), "the prefixed view should be listed without the prefix")
+	assert.Nil(t, testViewGet(prefixed, "table_test_view_names"), "the views without the prefix should not be listed")
+	assert.NotNil(t, testViewGet(builder, "xun_table_test_view_prefix"), "all views should be listed if the prefix is not set")
+}
+
+func TestViewClean(t *testing.T) {
+	builder := getTestBuilder()
+	builder.DropViewIfExists("table_test_view_active")
+	builder.DropViewIfExists("table_test_view_names")
+	builder.DropViewIfExists("table_test_view_materialized")
+	builder.DropTableIfExists("table_test_view_users")
+}
+
+func testViewCreateTable(builder Schema) {
+	TestViewClean(nil)
+	builder.MustCreateTable("table_test_view_users", func(table Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+		table.String("status", 20)
+	})
+	builder.DB().MustExec("INSERT INTO table_test_view_users (name, status) VALUES ('Ada', 'it''s active'), ('Bob', 'inactive'), ('Cyd', 'it''s active')")
+}
+
+func testViewGet(builder Schema, name string) *dbal.View {
+	for _, view := range builder.MustGetViews() {
+		if view.Name == name {
+			return view
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package dbal
 
 import (
+	"sync"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -25,9 +26,18 @@ type Config struct {
 
 // Option the database configuration
 type Option struct {
-	Prefix    string `json:"prefix,omitempty"` // Table prifix
-	Collation string `json:"collation,omitempty"`
-	Charset   string `json:"charset,omitempty"`
+	Prefix     string       `json:"prefix,omitempty"` // Table prifix
+	Collation  string       `json:"collation,omitempty"`
+	Charset    string       `json:"charset,omitempty"`
+	SearchPath string       `json:"search_path,omitempty"` // The search_path of the Postgres connections, e.g. "tenant_1,public". The first schema is the default schema
+	Retry      *RetryPolicy `json:"-"`                     // The retry policy of the deadlocks and the serialization failures. nil means no retry
+	Pretend    *Pretend     `json:"-"`                     // The schema statements are collected but not executed (dry-run). nil means execute them
+}
+
+// Pretend the collector of the schema statements in the pretend (dry-run) mode
+type Pretend struct {
+	Statements []string
+	mutex      sync.Mutex
 }
 
 // Version the database version
@@ -36,6 +46,35 @@ type Version struct {
 	semver.Version
 }
 
+// View the database view, the SQL is the select statement of the view
+type View struct {
+	DBName       string        `db:"db_name"`
+	SchemaName   string        `db:"schema_name"`
+	Name         string        `db:"view_name"`
+	SQL          string        `db:"view_definition"`
+	Materialized bool          `db:"materialized"`
+	Bindings     []interface{} `db:"-"`
+}
+
+// Partition the partition of a table, the bound of the new partition is one of the From and To (RANGE),
+// the Values (LIST) and the Modulus and Remainder (HASH). The nil From and To are the MINVALUE and MAXVALUE,
+// MySQL uses the To only. The Method, Expression and Description are read from the catalog.
+type Partition struct {
+	DBName      string        `db:"db_name"`
+	SchemaName  string        `db:"schema_name"`
+	TableName   string        `db:"table_name"`
+	Name        string        `db:"partition_name"`
+	Position    int           `db:"partition_position"`
+	Method      string        `db:"partition_method"`
+	Expression  string        `db:"partition_expression"`
+	Description string        `db:"partition_description"`
+	From        interface{}   `db:"-"`
+	To          interface{}   `db:"-"`
+	Values      []interface{} `db:"-"`
+	Modulus     int           `db:"-"`
+	Remainder   int           `db:"-"`
+}
+
 // Table the table struct
 type Table struct {
 	DBName        string    `db:"db_name"`
@@ -52,11 +91,18 @@ type Table struct {
 	RowLength     int       `db:"avg_row_length"`
 	IndexLength   int       `db:"index_length"`
 	AutoIncrement int       `db:"auto_increment"`
+	RowFormat     string    `db:"row_format"`
+	Tablespace    string    `db:"tablespace"`
+	FillFactor    int       `db:"fill_factor"`
 	Primary       *Primary
 	ColumnMap     map[string]*Column
 	IndexMap      map[string]*Index
+	ForeignKeyMap map[string]*ForeignKey
+	ConstraintMap map[string]*Constraint
 	Columns       []*Column
 	Indexes       []*Index
+	ForeignKeys   []*ForeignKey
+	Constraints   []*Constraint
 	Commands      []*Command
 }
 
@@ -80,6 +126,7 @@ type Column struct {
 	Collation                *string     `db:"collation"`
 	Key                      *string     `db:"key"`
 	Extra                    *string     `db:"extra"`
+	GenerationExpression     string      `db:"generation_expression"`
 	Comment                  *string     `db:"comment"`
 	Primary                  bool        `db:"primary"`
 	TypeName                 string      `db:"type_name"`
@@ -92,6 +139,9 @@ type Column struct {
 	MaxDateTimePrecision     int
 	DefaultDateTimePrecision int
 	Option                   []string
+	OnUpdateCurrent          bool   // the value is set to the current time when the row is updated
+	After                    string // the column is placed after the given one (MySQL ADD COLUMN)
+	First                    bool   // the column is placed as the first one (MySQL ADD COLUMN)
 	Table                    *Table
 	Indexes                  []*Index
 	Constraint               *Constraint
@@ -110,12 +160,28 @@ type Index struct {
 	Unique       bool    `db:"unique"`
 	Primary      bool    `db:"primary"`
 	SubPart      int     `db:"sub_part"`
+	Expression   string  `db:"expression"`
+	Order        string  `db:"sort_order"`
+	Predicate    string  `db:"predicate"`
+	OpClass      string  `db:"operator_class"`
 	Type         string  `db:"type"`
 	IndexType    string  `db:"index_type"`
 	Comment      *string `db:"comment"`
 	IndexComment *string `db:"index_comment"`
 	Table        *Table
 	Columns      []*Column
+	Parts        []*IndexPart
+	Include      []string // the covering columns are stored in the index but not the key parts (Postgres)
+	Concurrently bool     // build the index without locking the writes (Postgres)
+}
+
+// IndexPart the key part of the index, it is a column or an expression (the column is nil)
+type IndexPart struct {
+	Column     *Column
+	Expression string
+	Order      string // ASC or DESC, empty means the default order
+	Length     int    // the prefix length of the column, 0 means the whole column
+	OpClass    string // the operator class of the key part, e.g. jsonb_path_ops (Postgres)
 }
 
 // Primary the table primary key
@@ -127,14 +193,33 @@ type Primary struct {
 	Columns   []*Column
 }
 
+// ForeignKey the table foreign key constraint
+type ForeignKey struct {
+	DBName               string `db:"db_name"`
+	TableName            string `db:"table_name"`
+	Name                 string `db:"constraint_name"`
+	ColumnName           string `db:"column_name"`
+	SEQ                  int    `db:"seq_in_key"`
+	ReferencedTable      string `db:"referenced_table_name"`
+	ReferencedColumnName string `db:"referenced_column_name"`
+	OnDelete             string `db:"on_delete"`
+	OnUpdate             string `db:"on_update"`
+	Table                *Table
+	Columns              []*Column
+	ReferencedColumns    []string
+}
+
 // Constraint the table constraint
+// The Type is UNIQUE or CHECK, the check expression is kept in the Args[0].
 type Constraint struct {
 	SchemaName string
 	TableName  string
 	ColumnName string
+	Name       string
 	Type       string
 	Args       []string
 	Table      *Table
+	Columns    []*Column
 }
 
 // Command The Command that should be run for the table.
@@ -261,4 +346,5 @@ type Query struct {
 	IsJoinClause       bool                     // Determine if the query is a join clause.
 	BindingOffset      int                      // The Binding offset before select
 	SQL                string                   // The SQL STMT
+	Retry              *RetryPolicy             // The retry policy of the query, overrides the connection's one.
 }
@@ -15,6 +15,7 @@ require (
 	github.com/qustavo/sqlhooks/v2 v2.1.0
 	github.com/stretchr/testify v1.7.1
 	github.com/yaoapp/kun v0.9.0
+	gopkg.in/yaml.v3 v3.0.0
 )
 
 require (
@@ -28,5 +29,4 @@ require (
 	github.com/sirupsen/logrus v1.8.1 // indirect
 	golang.org/x/sys v0.6.0 // indirect
 	golang.org/x/text v0.3.7 // indirect
-	gopkg.in/yaml.v3 v3.0.0 // indirect
 )
@@ -37,18 +37,28 @@ func (grammarSQL Dameng) SQLAddColumn(column *dbal.Column) string {
 	extra := ""
 
 	// 达梦数据库的自增列使用IDENTITY语法（与GORM一致，SQL Server风格）
-	if utils.StringVal(column.Extra) != "" {
+	if utils.StringVal(column.Extra) == "AutoIncrement" {
+		start := 1
+		if column.Table != nil && column.Table.AutoIncrement > 0 {
+			start = column.Table.AutoIncrement
+		}
 		if typ == "BIGINT" {
-			typ = "BIGINT IDENTITY(1,1)"
+			typ = fmt.Sprintf("BIGINT IDENTITY(%d,1)", start)
 		} else if typ == "SMALLINT" {
-			typ = "SMALLINT IDENTITY(1,1)"
+			typ = fmt.Sprintf("SMALLINT IDENTITY(%d,1)", start)
 		} else {
-			typ = "INT IDENTITY(1,1)"
+			typ = fmt.Sprintf("INT IDENTITY(%d,1)", start)
 		}
 		nullable = ""
 		defaultValue = ""
 	}
 
+	// the generated columns are always virtual, the expression should be placed before the constraints
+	if column.IsGenerated() {
+		nullable = fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL %s", column.GenerationExpression, nullable)
+		defaultValue = ""
+	}
+
 	if typ == "IPADDRESS" { // ipAddress
 		typ = "integer"
 	} else if typ == "YEAR" { // 2021 -1046 smallInt (2-byte)
@@ -95,9 +105,15 @@ func (grammarSQL Dameng) SQLAddIndex(index *dbal.Index) string {
 	}
 
 	// UNIQUE KEY `unionid` (`unionid`) COMMENT 'xxxx'
+	// the expressions are the function-based key parts, the prefix lengths and the partial indexes are not supported
 	columns := []string{}
-	for _, column := range index.Columns {
-		columns = append(columns, quoter.ID(column.Name))
+	for _, part := range index.KeyParts() {
+		order := utils.GetIF(part.Order != "", " "+part.Order, "").(string)
+		if part.Column == nil {
+			columns = append(columns, part.Expression+order)
+			continue
+		}
+		columns = append(columns, quoter.ID(part.Column.Name)+order)
 	}
 
 	comment := ""
@@ -22,7 +22,8 @@ func init() {
 
 // setup the method will be executed when db server was connected
 func (grammarSQL *Dameng) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Option) error {
-	if db == nil {
+	// the grammar without a database only collects the statements in the pretend mode, e.g. dumping to another driver
+	if db == nil && (option == nil || option.Pretend == nil) {
 		return fmt.Errorf("db is nil")
 	}
 
@@ -132,6 +133,10 @@ func New(opts ...sql.Option) dbal.Grammar {
 		dm.FlipTypes["NUMBER"] = "decimal"
 		dm.FlipTypes["VARCHAR"] = "string"  // 与GORM保持一致
 		dm.FlipTypes["VARCHAR2"] = "string" // 兼容旧数据
+		dm.FlipTypes["TIMESTAMP"] = "timestamp"
+		dm.FlipTypes["TIMESTAMP WITH TIME ZONE"] = "timestampTz"
+		dm.FlipTypes["TIME"] = "time"
+		dm.FlipTypes["TIME WITH TIME ZONE"] = "timeTz"
 	}
 
 	return dm
@@ -0,0 +1,53 @@
+package dameng
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// GetDatabases get the schemas owned by the current user, the databases of dameng are the schemas.
+func (grammarSQL Dameng) GetDatabases() ([]string, error) {
+	sql := "SELECT s.NAME FROM SYSOBJECTS s JOIN SYSOBJECTS u ON s.PID = u.ID " +
+		"WHERE s.TYPE$ = 'SCH' AND u.TYPE$ = 'UR' AND u.NAME = USER ORDER BY s.NAME"
+	defer log.Debug(sql)
+	databases := []string{}
+	err := grammarSQL.DB.Select(&databases, sql)
+	if err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// DatabaseExists check if the schema exists, the databases of dameng are the schemas.
+func (grammarSQL Dameng) DatabaseExists(name string) (bool, error) {
+	sql := fmt.Sprintf("SELECT COUNT(*) FROM SYSOBJECTS WHERE TYPE$ = 'SCH' AND NAME = %s", grammarSQL.VAL(name))
+	defer log.Debug(sql)
+	var count int
+	err := grammarSQL.DB.Get(&count, sql)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateDatabase create a new schema owned by the current user, the databases of dameng are the schemas.
+// The charset and the collation are ignored, they are the settings of the dameng instance.
+func (grammarSQL Dameng) CreateDatabase(name string, charset string, collation string) error {
+	var user string
+	err := grammarSQL.DB.Get(&user, "SELECT USER FROM DUAL")
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf("CREATE SCHEMA %s AUTHORIZATION %s", grammarSQL.ID(name), grammarSQL.ID(user))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropDatabase drop the schema and all of its objects, the databases of dameng are the schemas.
+func (grammarSQL Dameng) DropDatabase(name string) error {
+	sql := fmt.Sprintf("DROP SCHEMA %s CASCADE", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
@@ -19,9 +19,9 @@ func (grammarSQL Dameng) CompileDelete(query *dbal.Query) (string, []interface{}
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", alias)}
+	qualifier := grammarSQL.FromQualifier(query)
+	if qualifier != "" {
+		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", qualifier)}
 	} else {
 		query.Columns = []interface{}{"rowid"}
 	}
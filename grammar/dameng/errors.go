@@ -0,0 +1,40 @@
+package dameng
+
+import (
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// The DM driver does not export the error code, classify the error by the code and the message.
+// The names of the constraint and the column are not parsed, the message format varies with the server locale.
+// e.g. "违反表[USERS]唯一性约束" / "Violate unique constraint on [USERS]"
+var dmErrorClasses = []struct {
+	class    error
+	keywords []string
+}{
+	{dbal.ErrUniqueViolation, []string{"-6602", "唯一性约束", "unique constraint"}},
+	{dbal.ErrForeignKeyViolation, []string{"引用约束", "外键约束", "foreign key", "referential constraint"}},
+	{dbal.ErrNotNullViolation, []string{"非空约束", "不能为空", "not null", "cannot be null"}},
+	{dbal.ErrCheckViolation, []string{"CHECK约束", "check constraint"}},
+	{dbal.ErrDeadlock, []string{"死锁", "deadlock"}},
+	{dbal.ErrLockTimeout, []string{"锁超时", "lock timeout", "lock wait timeout"}},
+}
+
+// WrapError Classify the DM driver error.
+func (grammarSQL Dameng) WrapError(err error) error {
+	if err == nil {
+		return err
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, item := range dmErrorClasses {
+		for _, keyword := range item.keywords {
+			if strings.Contains(message, strings.ToLower(keyword)) {
+				return dbal.NewError(item.class, err)
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,32 @@
+package dameng
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// GetPartitions the table partitioning is not supported
+func (grammarSQL Dameng) GetPartitions(tableName string) ([]*dbal.Partition, error) {
+	return nil, grammarSQL.errPartitionNotSupported()
+}
+
+// AddPartition the table partitioning is not supported
+func (grammarSQL Dameng) AddPartition(tableName string, partitions []*dbal.Partition) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// DropPartition the table partitioning is not supported
+func (grammarSQL Dameng) DropPartition(tableName string, names []string) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// DetachPartition the table partitioning is not supported
+func (grammarSQL Dameng) DetachPartition(tableName string, name string) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// errPartitionNotSupported the error of the table partitioning
+func (grammarSQL Dameng) errPartitionNotSupported() error {
+	return fmt.Errorf("the table partitioning is not supported by %s", grammarSQL.Driver)
+}
@@ -68,6 +68,9 @@ func (grammarSQL Dameng) CreateTable(table *dbal.Table, options ...dbal.CreateTa
 		if option.Temporary {
 			sql = fmt.Sprintf("CREATE GLOBAL TEMPORARY TABLE %s (\n", name)
 		}
+		if option.Partition != nil {
+			return grammarSQL.errPartitionNotSupported()
+		}
 	}
 
 	stmts := []string{}
@@ -76,6 +79,8 @@ func (grammarSQL Dameng) CreateTable(table *dbal.Table, options ...dbal.CreateTa
 	var primary *dbal.Primary = nil
 	columns := []*dbal.Column{}
 	indexes := []*dbal.Index{}
+	foreigns := []*dbal.ForeignKey{}
+	constraints := []*dbal.Constraint{}
 	cbCommands := []*dbal.Command{}
 
 	for _, command := range table.Commands {
@@ -92,6 +97,14 @@ func (grammarSQL Dameng) CreateTable(table *dbal.Table, options ...dbal.CreateTa
 			primary = command.Params[0].(*dbal.Primary)
 			cbCommands = append(cbCommands, command)
 			break
+		case "CreateForeign":
+			foreigns = append(foreigns, command.Params[0].(*dbal.ForeignKey))
+			cbCommands = append(cbCommands, command)
+			break
+		case "CreateConstraint":
+			constraints = append(constraints, command.Params[0].(*dbal.Constraint))
+			cbCommands = append(cbCommands, command)
+			break
 		}
 	}
 
@@ -104,12 +117,34 @@ func (grammarSQL Dameng) CreateTable(table *dbal.Table, options ...dbal.CreateTa
 	if primary != nil {
 		stmts = append(stmts, grammarSQL.SQLAddPrimary(primary))
 	}
+
+	// Foreign keys
+	for _, foreign := range foreigns {
+		stmts = append(stmts, grammarSQL.SQLAddForeign(foreign))
+	}
+
+	// Constraints
+	for _, constraint := range constraints {
+		stmts = append(stmts, grammarSQL.SQLAddConstraint(constraint))
+	}
 	sql = sql + strings.Join(stmts, ",\n")
 	sql = sql + fmt.Sprintf("\n)")
 
+	// STORAGE(ON "TS1", FILLFACTOR 70)
+	storage := []string{}
+	if table.Tablespace != "" {
+		storage = append(storage, fmt.Sprintf("ON %s", grammarSQL.ID(table.Tablespace)))
+	}
+	if table.FillFactor > 0 {
+		storage = append(storage, fmt.Sprintf("FILLFACTOR %d", table.FillFactor))
+	}
+	if len(storage) > 0 {
+		sql = sql + fmt.Sprintf(" STORAGE(%s)", strings.Join(storage, ", "))
+	}
+
 	// Create table
 	defer log.Debug(sql)
-	_, err = grammarSQL.DB.Exec(sql)
+	err = grammarSQL.Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -121,11 +156,26 @@ func (grammarSQL Dameng) CreateTable(table *dbal.Table, options ...dbal.CreateTa
 	}
 
 	// Comments
+	if table.Comment != "" {
+		commentStmts = append(commentStmts, grammarSQL.SQLTableOption(table, "comment", table.Comment))
+	}
 	err = grammarSQL.createTableAddComment(table, commentStmts)
 	if err != nil {
 		return err
 	}
 
+	// the on update triggers
+	for _, column := range columns {
+		if column.OnUpdateCurrent {
+			stmt := grammarSQL.SQLCreateOnUpdateTrigger(table.TableName, column.Name)
+			defer log.Debug(stmt)
+			err = grammarSQL.Exec(stmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Callback
 	for _, cmd := range cbCommands {
 		cmd.Callback(err)
@@ -165,7 +215,7 @@ func (grammarSQL Dameng) createTableCreateIndex(table *dbal.Table, indexes []*db
 	if len(indexStmts) > 0 {
 		sql := strings.Join(indexStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		err := grammarSQL.Exec(sql)
 		return err
 	}
 	return nil
@@ -175,7 +225,7 @@ func (grammarSQL Dameng) createTableAddComment(table *dbal.Table, commentStmts [
 	if len(commentStmts) > 0 {
 		sql := strings.Join(commentStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		err := grammarSQL.Exec(sql)
 		return err
 
 		// for _, sql := range commentStmts {
@@ -193,15 +243,18 @@ func (grammarSQL Dameng) createTableAddComment(table *dbal.Table, commentStmts [
 func (grammarSQL Dameng) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
-	return err
+	err := grammarSQL.Exec(sql)
+	if err != nil {
+		return err
+	}
+	return grammarSQL.renameOnUpdateTriggers(old, new)
 }
 
 // DropTable drop a table on the schema.
 func (grammarSQL Dameng) DropTable(name string) error {
 	sql := fmt.Sprintf("DROP TABLE %s", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	return err
 }
 
@@ -217,6 +270,18 @@ func (grammarSQL Dameng) DropTableIfExists(name string) error {
 	return grammarSQL.DropTable(name)
 }
 
+// getTableOption read the comment and the tablespace of the table
+func (grammarSQL Dameng) getTableOption(table *dbal.Table) error {
+	sql := fmt.Sprintf(`
+		SELECT NVL(cm.COMMENTS, '') AS "table_comment", NVL(t.TABLESPACE_NAME, '') AS "tablespace"
+		FROM ALL_TABLES t
+		LEFT JOIN ALL_TAB_COMMENTS cm ON cm.OWNER = t.OWNER AND cm.TABLE_NAME = t.TABLE_NAME
+		WHERE t.OWNER = USER AND t.TABLE_NAME = %s
+	`, grammarSQL.VAL(strings.ToUpper(table.TableName)))
+	defer log.Debug(sql)
+	return grammarSQL.DB.Get(table, sql)
+}
+
 // GetTable get a table on the schema
 func (grammarSQL Dameng) GetTable(name string) (*dbal.Table, error) {
 	has, err := grammarSQL.TableExists(name)
@@ -229,6 +294,10 @@ func (grammarSQL Dameng) GetTable(name string) (*dbal.Table, error) {
 	}
 
 	table := dbal.NewTable(name, grammarSQL.GetSchema(), grammarSQL.GetDatabase())
+	err = grammarSQL.getTableOption(table)
+	if err != nil {
+		return nil, err
+	}
 	columns, err := grammarSQL.GetColumnListing(table.SchemaName, table.TableName)
 	if err != nil {
 		return nil, err
@@ -237,6 +306,15 @@ func (grammarSQL Dameng) GetTable(name string) (*dbal.Table, error) {
 	if err != nil {
 		return nil, err
 	}
+	foreignKeys, err := grammarSQL.GetForeignKeyListing(table.SchemaName, table.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, err := grammarSQL.GetTableConstraintListing(table.SchemaName, table.TableName)
+	if err != nil {
+		return nil, err
+	}
 
 	primaryKeyName := ""
 
@@ -246,21 +324,32 @@ func (grammarSQL Dameng) GetTable(name string) (*dbal.Table, error) {
 		table.PushColumn(column)
 	}
 
-	// attaching indexes
+	// attaching indexes, the expression key parts have no column
 	for i := range indexes {
 		idx := indexes[i]
-		if !table.HasColumn(idx.ColumnName) {
-			return nil, fmt.Errorf("the column %s does not exists", idx.ColumnName)
+		var column *dbal.Column
+		if idx.Expression == "" {
+			if !table.HasColumn(idx.ColumnName) {
+				return nil, fmt.Errorf("the column %s does not exists", idx.ColumnName)
+			}
+			column = table.ColumnMap[idx.ColumnName]
 		}
-		column := table.ColumnMap[idx.ColumnName]
 		if !table.HasIndex(idx.Name) {
 			index := *idx
 			index.Columns = []*dbal.Column{}
-			column.Indexes = append(column.Indexes, &index)
+			if column != nil {
+				column.Indexes = append(column.Indexes, &index)
+			}
 			table.PushIndex(&index)
 		}
 		index := table.IndexMap[idx.Name]
-		index.Columns = append(index.Columns, column)
+		index.AddPart(&dbal.IndexPart{
+			Column:     column,
+			Expression: idx.Expression,
+			Order:      idx.Order,
+			Length:     idx.SubPart,
+			OpClass:    idx.OpClass,
+		})
 		if index.Type == "primary" {
 			primaryKeyName = idx.Name
 		}
@@ -283,13 +372,109 @@ func (grammarSQL Dameng) GetTable(name string) (*dbal.Table, error) {
 		}
 	}
 
+	// attaching constraints
+	for _, c := range constraints {
+		if !table.HasConstraint(c.Name) {
+			constraint := *c
+			constraint.Table = table
+			constraint.Columns = []*dbal.Column{}
+			table.PushConstraint(&constraint)
+		}
+		if column, has := table.ColumnMap[c.ColumnName]; has {
+			table.ConstraintMap[c.Name].AddColumn(column)
+		}
+	}
+
+	// attaching foreign keys
+	for _, fk := range foreignKeys {
+		if !table.HasColumn(fk.ColumnName) {
+			return nil, fmt.Errorf("the column %s does not exists", fk.ColumnName)
+		}
+		if !table.HasForeignKey(fk.Name) {
+			foreign := *fk
+			foreign.Table = table
+			foreign.Columns = []*dbal.Column{}
+			foreign.ReferencedColumns = []string{}
+			table.PushForeignKey(&foreign)
+		}
+		foreign := table.ForeignKeyMap[fk.Name]
+		foreign.AddColumn(table.ColumnMap[fk.ColumnName], fk.ReferencedColumnName)
+	}
+
 	return table, nil
 }
 
+func (grammarSQL Dameng) alterTableCreateForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	foreign := command.Params[0].(*dbal.ForeignKey)
+	stmt := "ADD " + grammarSQL.SQLAddForeign(foreign)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Dameng) alterTableDropForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	stmt := fmt.Sprintf("DROP CONSTRAINT %s", grammarSQL.ID(name))
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Dameng) alterTableCreateConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	constraint := command.Params[0].(*dbal.Constraint)
+	stmt := "ADD " + grammarSQL.SQLAddConstraint(constraint)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Dameng) alterTableDropConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	stmt := fmt.Sprintf("DROP CONSTRAINT %s", grammarSQL.ID(name))
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Dameng) alterTableSetOption(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	stmt := grammarSQL.SQLTableOption(table, command.Params[0].(string), command.Params[1])
+	if stmt == "" {
+		command.Callback(nil)
+		return
+	}
+	*stmts = append(*stmts, stmt)
+	err := grammarSQL.ExecSQL(table, stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("SetTableOption: %s", err))
+	}
+	command.Callback(err)
+}
+
+// SQLTableOption return the statement changing the table option, returns "" if the option is not supported.
+// Only the comment can be changed, the tablespace and the fill factor are set when the table is created.
+func (grammarSQL Dameng) SQLTableOption(table *dbal.Table, name string, value interface{}) string {
+	if name == "comment" {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", grammarSQL.ID(table.TableName), grammarSQL.VAL(value))
+	}
+	return ""
+}
+
 // ExecSQL execute sql then update table structure
 func (grammarSQL Dameng) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
-	if err != nil {
+	err := grammarSQL.Exec(sql)
+	if err != nil || grammarSQL.IsPretend() {
 		return err
 	}
 	// update table structure
@@ -338,6 +523,21 @@ func (grammarSQL Dameng) AlterTable(table *dbal.Table) error {
 		case "DropPrimary":
 			grammarSQL.alterTableDropPrimary(table, command, sql, &stmts, &errs)
 			break
+		case "CreateForeign":
+			grammarSQL.alterTableCreateForeign(table, command, sql, &stmts, &errs)
+			break
+		case "DropForeign":
+			grammarSQL.alterTableDropForeign(table, command, sql, &stmts, &errs)
+			break
+		case "CreateConstraint":
+			grammarSQL.alterTableCreateConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "DropConstraint":
+			grammarSQL.alterTableDropConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "SetTableOption":
+			grammarSQL.alterTableSetOption(table, command, sql, &stmts, &errs)
+			break
 		}
 	}
 
@@ -373,15 +573,26 @@ func (grammarSQL Dameng) alterTableAddColumn(table *dbal.Table, command *dbal.Co
 		}
 	}
 
+	if err == nil && column.OnUpdateCurrent {
+		err = grammarSQL.execOnUpdateTrigger(table, column.Name, true, stmts)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("AddColumn: %s", err))
+		}
+	}
+
 	command.Callback(err)
 }
 
 func (grammarSQL Dameng) alterTableChangeColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	column := command.Params[0].(*dbal.Column)
+	onUpdate := table.HasColumn(column.Name) && table.ColumnMap[column.Name].OnUpdateCurrent
 	// 达梦数据库使用MODIFY而不是ALTER COLUMN
 	stmt := fmt.Sprintf("MODIFY %s", grammarSQL.SQLAddColumn(column))
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err == nil && column.OnUpdateCurrent != onUpdate {
+		err = grammarSQL.execOnUpdateTrigger(table, column.Name, column.OnUpdateCurrent, stmts)
+	}
 	if err != nil {
 		*errs = append(*errs, fmt.Errorf("ChangeColumn %s: %s", column.Name, err))
 	}
@@ -391,9 +602,18 @@ func (grammarSQL Dameng) alterTableChangeColumn(table *dbal.Table, command *dbal
 func (grammarSQL Dameng) alterTableRenameColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	old := command.Params[0].(string)
 	new := command.Params[1].(string)
+	onUpdate := table.HasColumn(old) && table.ColumnMap[old].OnUpdateCurrent
 	stmt := fmt.Sprintf("RENAME COLUMN %s TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
+
+	// the trigger names contain the column name
+	if err == nil && onUpdate {
+		err = grammarSQL.execOnUpdateTrigger(table, old, false, stmts)
+		if err == nil {
+			err = grammarSQL.execOnUpdateTrigger(table, new, true, stmts)
+		}
+	}
 	if err != nil {
 		*errs = append(*errs, fmt.Errorf("RenameColumn: %s", err))
 	}
@@ -402,9 +622,15 @@ func (grammarSQL Dameng) alterTableRenameColumn(table *dbal.Table, command *dbal
 
 func (grammarSQL Dameng) alterTableDropColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	name := command.Params[0].(string)
+	var err error
+	if column, has := table.ColumnMap[name]; has && column.OnUpdateCurrent {
+		err = grammarSQL.execOnUpdateTrigger(table, name, false, stmts)
+	}
 	stmt := fmt.Sprintf("DROP COLUMN %s", grammarSQL.ID(name))
 	*stmts = append(*stmts, sql+stmt)
-	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err == nil {
+		err = grammarSQL.ExecSQL(table, sql+stmt)
+	}
 	if err != nil {
 		*errs = append(*errs, fmt.Errorf("DropColumn: %s", err))
 	}
@@ -536,8 +762,7 @@ func (grammarSQL Dameng) GetColumnListing(dbName string, tableName string) ([]*d
 
 		// 处理默认值
 		if dataDefault != nil {
-			defStr := fmt.Sprintf("%v", dataDefault)
-			column.Default = &defStr
+			column.Default = fmt.Sprintf("%v", dataDefault)
 		}
 
 		// 处理长度
@@ -561,8 +786,15 @@ func (grammarSQL Dameng) GetColumnListing(dbName string, tableName string) ([]*d
 		columns = append(columns, &column)
 	}
 
+	triggers, err := grammarSQL.getOnUpdateTriggers(tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cast the database data type to DBAL data type
 	for _, column := range columns {
+		column.OnUpdateCurrent = hasOnUpdateTrigger(triggers, tableName, column.Name)
+
 		// 1. 使用FlipTypes映射达梦类型到DBAL类型
 		typ, has := grammarSQL.FlipTypes[column.Type]
 		if has {
@@ -599,6 +831,128 @@ func (grammarSQL Dameng) GetColumnListing(dbName string, tableName string) ([]*d
 	return columns, nil
 }
 
+// GetForeignKeyListing get a table foreign keys structure
+func (grammarSQL Dameng) GetForeignKeyListing(dbName string, tableName string) ([]*dbal.ForeignKey, error) {
+	// 外键约束类型为 R，通过 R_CONSTRAINT_NAME 关联被引用表的主键或唯一约束
+	sql := fmt.Sprintf(`
+		SELECT
+			c.CONSTRAINT_NAME,
+			cc.COLUMN_NAME,
+			cc.POSITION,
+			rc.TABLE_NAME,
+			rcc.COLUMN_NAME,
+			c.DELETE_RULE
+		FROM ALL_CONSTRAINTS c
+		JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER
+			AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+			AND cc.TABLE_NAME = c.TABLE_NAME
+		JOIN ALL_CONSTRAINTS rc ON rc.OWNER = c.R_OWNER
+			AND rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+		JOIN ALL_CONS_COLUMNS rcc ON rcc.OWNER = rc.OWNER
+			AND rcc.CONSTRAINT_NAME = rc.CONSTRAINT_NAME
+			AND rcc.POSITION = cc.POSITION
+		WHERE c.OWNER = USER AND c.CONSTRAINT_TYPE = 'R' AND c.TABLE_NAME = %s
+		ORDER BY c.CONSTRAINT_NAME, cc.POSITION
+	`, grammarSQL.VAL(strings.ToUpper(tableName)))
+
+	defer log.Debug(sql)
+
+	rows, err := grammarSQL.DB.Query(sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foreignKeys := []*dbal.ForeignKey{}
+	for rows.Next() {
+		var deleteRule interface{}
+		foreign := &dbal.ForeignKey{
+			DBName:    dbName,
+			TableName: tableName,
+		}
+
+		err := rows.Scan(
+			&foreign.Name,
+			&foreign.ColumnName,
+			&foreign.SEQ,
+			&foreign.ReferencedTable,
+			&foreign.ReferencedColumnName,
+			&deleteRule,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// 系统视图不记录 ON UPDATE 规则
+		foreign.OnDelete = "NO ACTION"
+		if deleteRule != nil {
+			foreign.OnDelete = strings.ToUpper(fmt.Sprintf("%s", deleteRule))
+		}
+		foreignKeys = append(foreignKeys, foreign)
+	}
+
+	return foreignKeys, nil
+}
+
+// GetTableConstraintListing get a table unique and check constraints structure
+func (grammarSQL Dameng) GetTableConstraintListing(dbName string, tableName string) ([]*dbal.Constraint, error) {
+	// 唯一约束类型为 U，检查约束类型为 C
+	sql := fmt.Sprintf(`
+		SELECT
+			c.CONSTRAINT_NAME,
+			c.CONSTRAINT_TYPE,
+			cc.COLUMN_NAME,
+			c.SEARCH_CONDITION
+		FROM ALL_CONSTRAINTS c
+		LEFT JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER
+			AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+			AND cc.TABLE_NAME = c.TABLE_NAME
+		WHERE c.OWNER = USER AND c.CONSTRAINT_TYPE IN ('U', 'C') AND c.TABLE_NAME = %s
+		ORDER BY c.CONSTRAINT_NAME, cc.POSITION
+	`, grammarSQL.VAL(strings.ToUpper(tableName)))
+
+	defer log.Debug(sql)
+
+	rows, err := grammarSQL.DB.Query(sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraints := []*dbal.Constraint{}
+	for rows.Next() {
+		var name, typ string
+		var columnName, condition interface{}
+		err := rows.Scan(&name, &typ, &columnName, &condition)
+		if err != nil {
+			return nil, err
+		}
+
+		constraint := dbal.NewConstraint(dbName, tableName, "")
+		constraint.Name = name
+		if columnName != nil {
+			constraint.ColumnName = fmt.Sprintf("%s", columnName)
+		}
+
+		constraint.Type = "UNIQUE"
+		if typ == "C" {
+			expression := ""
+			if condition != nil {
+				expression = fmt.Sprintf("%s", condition)
+			}
+			// 跳过系统生成的非空检查约束
+			if strings.HasSuffix(strings.ToUpper(expression), "IS NOT NULL") {
+				continue
+			}
+			constraint.Type = "CHECK"
+			constraint.Args = append(constraint.Args, expression)
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
 // GetIndexListing get a table indexes structure
 func (grammarSQL Dameng) GetIndexListing(dbName string, tableName string) ([]*dbal.Index, error) {
 	// 使用达梦数据库系统表查询索引信息，包括主键
@@ -620,6 +974,7 @@ func (grammarSQL Dameng) GetIndexListing(dbName string, tableName string) ([]*db
 			AND i.TABLE_NAME = c.TABLE_NAME
 			AND i.TABLE_OWNER = c.OWNER
 		WHERE i.TABLE_OWNER = USER AND i.TABLE_NAME = %s
+			AND (c.CONSTRAINT_TYPE IS NULL OR c.CONSTRAINT_TYPE <> 'U')
 		ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION
 	`, grammarSQL.VAL(strings.ToUpper(tableName)))
 
@@ -668,3 +1023,11 @@ func (grammarSQL Dameng) GetIndexListing(dbName string, tableName string) ([]*db
 
 	return indexes, nil
 }
+
+// NormalizeColumn describe the column as Dameng creates and introspects it, the given column is not changed.
+// The unsigned attribute is not supported.
+func (grammarSQL Dameng) NormalizeColumn(column *dbal.Column) *dbal.Column {
+	normalized := grammarSQL.FlipColumnType(column, "ipAddress", "year")
+	normalized.IsUnsigned = false
+	return normalized
+}
@@ -0,0 +1,103 @@
+package dameng
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// dameng has no ON UPDATE CURRENT_TIMESTAMP, the columns are maintained by the triggers named {table}_{column}_on_update
+
+// SQLCreateOnUpdateTrigger return the statement of creating the trigger that sets the column to the current time when the row is updated
+func (grammarSQL Dameng) SQLCreateOnUpdateTrigger(tableName string, columnName string) string {
+	column := grammarSQL.ID(columnName)
+	return fmt.Sprintf(
+		"CREATE OR REPLACE TRIGGER %s BEFORE UPDATE ON %s FOR EACH ROW BEGIN IF (:NEW.%s = :OLD.%s) OR (:NEW.%s IS NULL AND :OLD.%s IS NULL) THEN :NEW.%s := CURRENT_TIMESTAMP; END IF; END;",
+		grammarSQL.ID(dbal.OnUpdateTriggerName(tableName, columnName)), grammarSQL.ID(tableName),
+		column, column, column, column, column,
+	)
+}
+
+// SQLDropOnUpdateTrigger return the statement of dropping the on update trigger of the column
+func (grammarSQL Dameng) SQLDropOnUpdateTrigger(tableName string, columnName string) string {
+	return fmt.Sprintf("DROP TRIGGER %s", grammarSQL.ID(dbal.OnUpdateTriggerName(tableName, columnName)))
+}
+
+// getOnUpdateTriggers get the names of the on update triggers of the table, the names are in upper case
+func (grammarSQL Dameng) getOnUpdateTriggers(tableName string) (map[string]bool, error) {
+	sql := fmt.Sprintf(`
+		SELECT TRIGGER_NAME FROM ALL_TRIGGERS
+		WHERE OWNER = USER AND UPPER(TABLE_NAME) = %s AND UPPER(TRIGGER_NAME) LIKE %s
+	`, grammarSQL.VAL(strings.ToUpper(tableName)), grammarSQL.VAL("%_ON_UPDATE"))
+	defer log.Debug(sql)
+	names := []string{}
+	err := grammarSQL.DB.Select(&names, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := map[string]bool{}
+	for _, name := range names {
+		triggers[strings.ToUpper(name)] = true
+	}
+	return triggers, nil
+}
+
+// hasOnUpdateTrigger determine if the column has the on update trigger
+func hasOnUpdateTrigger(triggers map[string]bool, tableName string, columnName string) bool {
+	return triggers[strings.ToUpper(dbal.OnUpdateTriggerName(tableName, columnName))]
+}
+
+// execOnUpdateTrigger create or drop the on update trigger of the column, the missing trigger is not dropped
+func (grammarSQL Dameng) execOnUpdateTrigger(table *dbal.Table, columnName string, onUpdate bool, stmts *[]string) error {
+	sql := grammarSQL.SQLCreateOnUpdateTrigger(table.TableName, columnName)
+	if !onUpdate {
+		triggers, err := grammarSQL.getOnUpdateTriggers(table.TableName)
+		if err != nil {
+			return err
+		}
+		if !hasOnUpdateTrigger(triggers, table.TableName, columnName) {
+			return nil
+		}
+		sql = grammarSQL.SQLDropOnUpdateTrigger(table.TableName, columnName)
+	}
+	*stmts = append(*stmts, sql)
+	return grammarSQL.ExecSQL(table, sql)
+}
+
+// renameOnUpdateTriggers recreate the on update triggers of the renamed table, the trigger names contain the table name
+func (grammarSQL Dameng) renameOnUpdateTriggers(old string, new string) error {
+	if grammarSQL.IsPretend() {
+		return nil
+	}
+
+	triggers, err := grammarSQL.getOnUpdateTriggers(new)
+	if err != nil {
+		return err
+	}
+
+	columns, err := grammarSQL.GetColumnListing(grammarSQL.GetDatabase(), new)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		if !hasOnUpdateTrigger(triggers, old, column.Name) {
+			continue
+		}
+		stmts := []string{
+			grammarSQL.SQLDropOnUpdateTrigger(old, column.Name),
+			grammarSQL.SQLCreateOnUpdateTrigger(new, column.Name),
+		}
+		for _, stmt := range stmts {
+			defer log.Debug(stmt)
+			err = grammarSQL.Exec(stmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
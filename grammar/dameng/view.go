@@ -0,0 +1,60 @@
+package dameng
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// GetViews Get all of the views of the current user, the definitions are read from the catalog.
+func (grammarSQL Dameng) GetViews() ([]*dbal.View, error) {
+	stmt := "SELECT OWNER AS \"schema_name\", VIEW_NAME AS \"view_name\", TEXT AS \"view_definition\" FROM ALL_VIEWS WHERE OWNER = USER ORDER BY VIEW_NAME"
+	defer log.Debug(stmt)
+	views := []*dbal.View{}
+	err := grammarSQL.DB.Select(&views, stmt)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		view.DBName = grammarSQL.GetDatabase()
+	}
+	return views, nil
+}
+
+// CreateView create a new view using the select statement
+func (grammarSQL Dameng) CreateView(view *dbal.View, options ...dbal.ViewOption) error {
+	option := dbal.ViewOption{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	if option.Materialized {
+		return fmt.Errorf("the materialized view is not supported by %s", grammarSQL.Driver)
+	}
+
+	create := "CREATE VIEW"
+	if option.Replace {
+		create = "CREATE OR REPLACE VIEW"
+	}
+
+	stmt := fmt.Sprintf("%s %s AS %s", create, grammarSQL.ID(view.Name), sql.Interpolate(view.SQL, view.Bindings, sql.QuoteString))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// DropViewIfExists drop the view if it exists
+func (grammarSQL Dameng) DropViewIfExists(name string) error {
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM ALL_VIEWS WHERE OWNER = USER AND VIEW_NAME = %s", grammarSQL.VAL(name))
+	defer log.Debug(stmt)
+	var cnt int
+	err := grammarSQL.DB.Get(&cnt, stmt)
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return nil
+	}
+	return grammarSQL.DropView(name)
+}
@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// CompileDelete Compile a delete statement into SQL.
+func (grammarSQL MySQL) CompileDelete(query *dbal.Query) (string, []interface{}) {
+
+	// MySQL compiles the joins into the delete statement ( delete `t1` from `t1` inner join `t2` on ... )
+	// but a multiple-table delete does not accept the "order by" and "limit" clauses, both are rejected instead of being ignored.
+	if len(query.Joins) > 0 {
+		if query.Limit >= 0 {
+			panic(fmt.Errorf("MySQL does not support delete statements with both joins and limit"))
+		}
+		if len(query.Orders) > 0 {
+			panic(fmt.Errorf("MySQL does not support delete statements with both joins and order by"))
+		}
+		return grammarSQL.SQL.CompileDelete(query)
+	}
+
+	sql, bindings := grammarSQL.SQL.CompileDelete(query)
+	sql, bindings = grammarSQL.compileOrdersAndLimit(query, sql, bindings)
+	return sql, bindings
+}
@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/yaoapp/xun/dbal"
+)
+
+var (
+	reMySQLKey        = regexp.MustCompile("for key '([^']+)'")
+	reMySQLForeignKey = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+	reMySQLChildTable = regexp.MustCompile("fails \\(`[^`]+`\\.`([^`]+)`")
+	reMySQLColumn     = regexp.MustCompile("^(?:Column|Field) '([^']+)'")
+	reMySQLCheck      = regexp.MustCompile("^Check constraint '([^']+)'")
+)
+
+// WrapError Classify the MySQL driver error.
+func (grammarSQL MySQL) WrapError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if err == nil || !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	switch mysqlErr.Number {
+	case 1062, 1586: // ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME
+		wrapped := dbal.NewError(dbal.ErrUniqueViolation, err)
+		if match := reMySQLKey.FindStringSubmatch(mysqlErr.Message); match != nil {
+			// MySQL 8.0 reports the key name with the table name prefix. "table.key"
+			name := match[1]
+			if pos := strings.LastIndex(name, "."); pos >= 0 {
+				wrapped.Table = name[:pos]
+				name = name[pos+1:]
+			}
+			wrapped.Constraint = name
+		}
+		return wrapped
+
+	case 1451, 1452: // ER_ROW_IS_REFERENCED_2, ER_NO_REFERENCED_ROW_2
+		wrapped := dbal.NewError(dbal.ErrForeignKeyViolation, err)
+		if match := reMySQLForeignKey.FindStringSubmatch(mysqlErr.Message); match != nil {
+			wrapped.Constraint = match[1]
+		}
+		if match := reMySQLChildTable.FindStringSubmatch(mysqlErr.Message); match != nil {
+			wrapped.Table = match[1]
+		}
+		return wrapped
+
+	case 1048, 1364: // ER_BAD_NULL_ERROR, ER_NO_DEFAULT_FOR_FIELD
+		wrapped := dbal.NewError(dbal.ErrNotNullViolation, err)
+		if match := reMySQLColumn.FindStringSubmatch(mysqlErr.Message); match != nil {
+			wrapped.Column = match[1]
+		}
+		return wrapped
+
+	case 3819: // ER_CHECK_CONSTRAINT_VIOLATED
+		wrapped := dbal.NewError(dbal.ErrCheckViolation, err)
+		if match := reMySQLCheck.FindStringSubmatch(mysqlErr.Message); match != nil {
+			wrapped.Constraint = match[1]
+		}
+		return wrapped
+
+	case 1213: // ER_LOCK_DEADLOCK
+		return dbal.NewError(dbal.ErrDeadlock, err)
+
+	case 1205: // ER_LOCK_WAIT_TIMEOUT
+		return dbal.NewError(dbal.ErrLockTimeout, err)
+	}
+
+	return err
+}
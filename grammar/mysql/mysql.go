@@ -23,7 +23,8 @@ func init() {
 
 // setup the method will be executed when db server was connected
 func (grammarSQL *MySQL) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Option) error {
-	if db == nil {
+	// the grammar without a database only collects the statements in the pretend mode, e.g. dumping to another driver
+	if db == nil && (option == nil || option.Pretend == nil) {
 		return fmt.Errorf("db is nil")
 	}
 
@@ -34,6 +35,10 @@ func (grammarSQL *MySQL) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Op
 	grammarSQL.DB = db
 	grammarSQL.Config = config
 	grammarSQL.Option = option
+	if config.DSN == "" {
+		return nil
+	}
+
 	cfg, err := mysql.ParseDSN(grammarSQL.Config.DSN)
 	if err != nil {
 		return err
@@ -102,6 +107,7 @@ func New(opts ...sql.Option) dbal.Grammar {
 		my.FlipTypes["DATETIME"] = "dateTime"
 		my.FlipTypes["TIME"] = "time"
 		my.FlipTypes["TIMESTAMP"] = "timestamp"
+		my.FlipTypes["GEOMCOLLECTION"] = "geometryCollection" // MySQL 8.0
 	}
 	return my
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/yaoapp/xun"
 	"github.com/yaoapp/xun/grammar/sql"
 	"github.com/yaoapp/xun/utils"
 )
@@ -42,3 +43,21 @@ func (quoter *Quoter) VAL(v interface{}) string {
 	input = strings.ReplaceAll(input, "\r", "")
 	return "'" + input + "'"
 }
+
+// Parameter Get the appropriate query parameter place-holder for a value, the points are written as the WKT text.
+func (quoter *Quoter) Parameter(value interface{}, num int) string {
+	switch value.(type) {
+	case xun.Point, *xun.Point:
+		return "ST_GeomFromText(?)"
+	}
+	return quoter.Quoter.Parameter(value, num)
+}
+
+// Parameterize Create query parameter place-holders for an array.
+func (quoter *Quoter) Parameterize(values []interface{}, offset int) string {
+	params := []string{}
+	for idx, value := range values {
+		params = append(params, quoter.Parameter(value, offset+idx+1))
+	}
+	return strings.Join(params, ",")
+}
@@ -41,3 +41,34 @@ func (grammarSQL MySQL) CompileUpsert(query *dbal.Query, columns []interface{},
 
 	return fmt.Sprintf("%s %s", sql, strings.Join(segments, ", ")), bindings
 }
+
+// CompileUpdate Compile an update statement into SQL.
+func (grammarSQL MySQL) CompileUpdate(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
+
+	// MySQL compiles the joins into the update statement ( update `t1` inner join `t2` on ... set ... )
+	// but a multiple-table update does not accept the "order by" and "limit" clauses, both are rejected instead of being ignored.
+	if len(query.Joins) > 0 {
+		if query.Limit >= 0 {
+			panic(fmt.Errorf("MySQL does not support update statements with both joins and limit"))
+		}
+		if len(query.Orders) > 0 {
+			panic(fmt.Errorf("MySQL does not support update statements with both joins and order by"))
+		}
+		return grammarSQL.SQL.CompileUpdate(query, values)
+	}
+
+	sql, bindings := grammarSQL.SQL.CompileUpdate(query, values)
+	sql, bindings = grammarSQL.compileOrdersAndLimit(query, sql, bindings)
+	return sql, bindings
+}
+
+// compileOrdersAndLimit append the "order by" and "limit" clauses to a single-table update or delete statement.
+func (grammarSQL MySQL) compileOrdersAndLimit(query *dbal.Query, sql string, bindings []interface{}) (string, []interface{}) {
+	offset := len(bindings)
+	orders := grammarSQL.CompileOrders(query, query.Orders, &offset)
+	if orders != "" {
+		bindings = append(bindings, query.GetBindings("order")...)
+	}
+	limit := grammarSQL.CompileLimit(query, query.Limit, &offset)
+	return grammarSQL.JoinSegments(sql, orders, limit), bindings
+}
@@ -29,19 +29,26 @@ func (grammarSQL Postgres) SQLAddColumn(column *dbal.Column) string {
 	} else if typ == "BYTEA" {
 		typ = "BYTEA"
 	} else if typ == "ENUM" {
-		typ = strings.ToLower("ENUM__" + strings.Join(column.Option, "_EOPT_"))
+		typ = grammarSQL.typeID(column.TableName, strings.ToLower("ENUM__"+strings.Join(column.Option, "_EOPT_")))
 	} else if column.Length != nil {
 		typ = fmt.Sprintf("%s(%d)", typ, utils.IntVal(column.Length))
 	}
 
+	// the unsigned columns are emulated with a check constraint
 	unsigned := ""
+	if column.IsUnsigned && utils.StringVal(column.Extra) != "AutoIncrement" {
+		unsigned = fmt.Sprintf(
+			"CONSTRAINT %s CHECK (%s >= 0)",
+			quoter.ID(fmt.Sprintf("%s_%s_unsigned", baseName(column.TableName), column.Name)), quoter.ID(column.Name))
+	}
 	nullable := utils.GetIF(column.Nullable, "NULL", "NOT NULL").(string)
 
 	defaultValue := grammarSQL.GetDefaultValue(column)
 	// comment := utils.GetIF(utils.StringVal(column.Comment) != "", fmt.Sprintf("COMMENT %s", quoter.VAL(column.Comment)), "").(string)
-	collation := utils.GetIF(utils.StringVal(column.Collation) != "", fmt.Sprintf("COLLATE %s", utils.StringVal(column.Collation)), "").(string)
+	// the collation names are case sensitive, e.g. COLLATE "C"
+	collation := utils.GetIF(utils.StringVal(column.Collation) != "", fmt.Sprintf("COLLATE %s", quoter.ID(utils.StringVal(column.Collation))), "").(string)
 	extra := ""
-	if utils.StringVal(column.Extra) != "" {
+	if utils.StringVal(column.Extra) == "AutoIncrement" {
 		if typ == "BIGINT" {
 			typ = "BIGSERIAL"
 		} else if typ == "SMALLINT" {
@@ -53,6 +60,12 @@ func (grammarSQL Postgres) SQLAddColumn(column *dbal.Column) string {
 		defaultValue = ""
 	}
 
+	// the virtual generated columns are not supported, both of them are stored
+	if column.IsGenerated() {
+		extra = fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", column.GenerationExpression)
+		defaultValue = ""
+	}
+
 	if typ == "IPADDRESS" { // ipAddress
 		typ = "integer"
 	} else if typ == "YEAR" { // 2021 -1046 smallInt (2-byte)
@@ -73,15 +86,15 @@ func (grammarSQL Postgres) SQLAddComment(column *dbal.Column) string {
 		utils.StringVal(column.Comment) != "",
 		fmt.Sprintf(
 			"COMMENT on column %s.%s is %s;",
-			grammarSQL.ID(column.TableName),
+			grammarSQL.tableID(column.TableName),
 			grammarSQL.ID(column.Name),
 			grammarSQL.VAL(column.Comment),
 		), "").(string)
 
-	mappingTypes := []string{"ipAddress", "year"}
+	mappingTypes := []string{"ipAddress", "year", "geometryCollection", "point", "multiPoint", "polygon", "multiPolygon"}
 	if utils.StringHave(mappingTypes, column.Type) {
 		comment = fmt.Sprintf("COMMENT on column %s.%s is %s;",
-			grammarSQL.ID(column.TableName),
+			grammarSQL.tableID(column.TableName),
 			grammarSQL.ID(column.Name),
 			grammarSQL.VAL(fmt.Sprintf("T:%s|%s", column.Type, utils.StringVal(column.Comment))),
 		)
@@ -100,15 +113,32 @@ func (grammarSQL Postgres) SQLAddIndex(index *dbal.Index) string {
 
 	// UNIQUE KEY `unionid` (`unionid`) COMMENT 'xxxx'
 	// IS JSON
+	// the prefix lengths are not supported, the whole columns are indexed
 	columns := []string{}
 	isJSON := false
-	for _, column := range index.Columns {
-		columns = append(columns, quoter.ID(column.Name))
-		if column.Type == "json" || column.Type == "jsonb" {
+	for _, part := range index.KeyParts() {
+		order := utils.GetIF(part.Order != "", " "+part.Order, "").(string)
+		if part.OpClass != "" {
+			order = " " + part.OpClass + order
+		}
+		if part.Column == nil {
+			columns = append(columns, fmt.Sprintf("(%s)%s", part.Expression, order))
+			continue
+		}
+		columns = append(columns, quoter.ID(part.Column.Name)+order)
+		if part.Column.Type == "json" || part.Column.Type == "jsonb" {
 			isJSON = true
 		}
 	}
-	if isJSON {
+
+	// the spatial indexes are the gist indexes
+	method := strings.ToLower(index.IndexType)
+	if index.Type == "spatial" && method == "" {
+		method = "gist"
+	}
+
+	// the json columns can only be indexed by the gin or gist indexes
+	if isJSON && method != "gin" && method != "gist" {
 		return ""
 	}
 
@@ -116,16 +146,35 @@ func (grammarSQL Postgres) SQLAddIndex(index *dbal.Index) string {
 	if index.Comment != nil {
 		comment = fmt.Sprintf("COMMENT %s", quoter.VAL(index.Comment))
 	}
-	name := quoter.ID(fmt.Sprintf("%s_%s", index.TableName, index.Name))
+	name := quoter.ID(fmt.Sprintf("%s_%s", baseName(index.TableName), index.Name))
 	sql := ""
 	if typ == "PRIMARY KEY" {
 		sql = fmt.Sprintf(
 			"%s (%s) %s",
 			typ, strings.Join(columns, ","), comment)
 	} else {
+		if index.Concurrently {
+			typ = typ + " CONCURRENTLY"
+		}
+		// the index types of the other drivers (FULLTEXT, SPATIAL ...) are ignored
+		using := ""
+		switch method {
+		case "btree", "hash", "gin", "gist", "brin", "spgist":
+			using = fmt.Sprintf(" USING %s", method)
+		}
 		sql = fmt.Sprintf(
-			"CREATE %s %s ON %s (%s)",
-			typ, name, quoter.ID(index.TableName), strings.Join(columns, ","))
+			"CREATE %s %s ON %s%s (%s)",
+			typ, name, grammarSQL.tableID(index.TableName), using, strings.Join(columns, ","))
+		if len(index.Include) > 0 {
+			include := []string{}
+			for _, column := range index.Include {
+				include = append(include, quoter.ID(column))
+			}
+			sql = fmt.Sprintf("%s INCLUDE (%s)", sql, strings.Join(include, ","))
+		}
+		if index.Predicate != "" {
+			sql = fmt.Sprintf("%s WHERE %s", sql, index.Predicate)
+		}
 	}
 	return sql
 }
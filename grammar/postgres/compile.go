@@ -193,6 +193,18 @@ func (grammarSQL Postgres) WhereDateBased(typ string, query *dbal.Query, where d
 	return fmt.Sprintf("extract(%s from %s)%s%s", typ, grammarSQL.Wrap(where.Column), where.Operator, value)
 }
 
+// CompileDistance Compile the spherical distance in meters between the column and the point (WKT text), PostGIS
+func (grammarSQL Postgres) CompileDistance(column interface{}, point string) string {
+	return fmt.Sprintf("ST_Distance(%s::geography, ST_GeogFromText(%s))", grammarSQL.Wrap(column), grammarSQL.VAL(point))
+}
+
+// WhereDistance Compile a "where distance within" clause, ST_DWithin could use the spatial index.
+func (grammarSQL Postgres) WhereDistance(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	*bindingOffset = *bindingOffset + where.Offset
+	meters := grammarSQL.Parameter(where.Values[0], *bindingOffset)
+	return fmt.Sprintf("ST_DWithin(%s::geography, ST_GeogFromText(%s), %s)", grammarSQL.Wrap(where.Column), grammarSQL.VAL(where.Value), meters)
+}
+
 // CompileLock the lock into SQL.
 func (grammarSQL Postgres) CompileLock(query *dbal.Query, lock interface{}) string {
 	lockType, ok := lock.(string)
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// GetDatabases get the database names of the server, the templates are excluded.
+func (grammarSQL Postgres) GetDatabases() ([]string, error) {
+	sql := "SELECT datname FROM pg_database WHERE NOT datistemplate ORDER BY datname"
+	defer log.Debug(sql)
+	databases := []string{}
+	err := grammarSQL.DB.Select(&databases, sql)
+	if err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// DatabaseExists check if the database exists
+func (grammarSQL Postgres) DatabaseExists(name string) (bool, error) {
+	sql := fmt.Sprintf("SELECT COUNT(*) FROM pg_database WHERE datname = %s", grammarSQL.VAL(name))
+	defer log.Debug(sql)
+	var count int
+	err := grammarSQL.DB.Get(&count, sql)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateDatabase create a new database, the charset is the encoding (utf8mb4 is UTF8) and the collation is the locale (en_US.UTF-8).
+// The database is copied from the template0 if the encoding or the locale is given, the template1 may use the others.
+func (grammarSQL Postgres) CreateDatabase(name string, charset string, collation string) error {
+	sql := fmt.Sprintf("CREATE DATABASE %s", grammarSQL.ID(name))
+	if charset != "" {
+		encoding := strings.ToUpper(charset)
+		if strings.HasPrefix(encoding, "UTF8") || encoding == "UTF-8" {
+			encoding = "UTF8"
+		}
+		sql = fmt.Sprintf("%s ENCODING %s", sql, grammarSQL.VAL(encoding))
+	}
+	if collation != "" {
+		sql = fmt.Sprintf("%s LC_COLLATE %s LC_CTYPE %s", sql, grammarSQL.VAL(collation), grammarSQL.VAL(collation))
+	}
+	if charset != "" || collation != "" {
+		sql = sql + " TEMPLATE template0"
+	}
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropDatabase drop the database, the connected database can not be dropped.
+func (grammarSQL Postgres) DropDatabase(name string) error {
+	sql := fmt.Sprintf("DROP DATABASE %s", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
@@ -13,13 +13,18 @@ func (grammarSQL Postgres) CompileDelete(query *dbal.Query) (string, []interface
 		return grammarSQL.SQL.CompileDelete(query)
 	}
 
+	// delete from "t1" using "t2" where "t2"."t1_id" = "t1"."id" and ...
+	if query.Limit < 0 && grammarSQL.canCompileJoinsAsFrom(query) {
+		return grammarSQL.compileDeleteUsing(query)
+	}
+
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", alias)}
+	qualifier := grammarSQL.FromQualifier(query)
+	if qualifier != "" {
+		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", qualifier)}
 	} else {
 		query.Columns = []interface{}{"ctid"}
 	}
@@ -32,6 +37,19 @@ func (grammarSQL Postgres) CompileDelete(query *dbal.Query) (string, []interface
 	return sql, bindings
 }
 
+// compileDeleteUsing Compile a delete statement with joins into the "delete ... using ... where" form.
+func (grammarSQL Postgres) compileDeleteUsing(query *dbal.Query) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	using, wheres := grammarSQL.compileJoinsAsFrom(query, &offset)
+	bindings = append(bindings, query.GetBindings("join")...)
+	bindings = append(bindings, query.GetBindings("where")...)
+
+	return grammarSQL.JoinSegments("delete from", table, "using", using, wheres), bindings
+}
+
 // CompileTruncate Compile a truncate table statement into SQL.
 func (grammarSQL Postgres) CompileTruncate(query *dbal.Query) ([]string, [][]interface{}) {
 	sql := fmt.Sprintf("truncate table %s restart identity cascade", grammarSQL.WrapTable(query.From))
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// The SQLSTATE classes of the PostgreSQL errors
+var pgErrorClasses = map[pq.ErrorCode]error{
+	"23505": dbal.ErrUniqueViolation,     // unique_violation
+	"23503": dbal.ErrForeignKeyViolation, // foreign_key_violation
+	"23502": dbal.ErrNotNullViolation,    // not_null_violation
+	"23514": dbal.ErrCheckViolation,      // check_violation
+	"40P01": dbal.ErrDeadlock,            // deadlock_detected
+	"40001": dbal.ErrSerialization,       // serialization_failure
+	"55P03": dbal.ErrLockTimeout,         // lock_not_available
+}
+
+// WrapError Classify the PostgreSQL driver error.
+func (grammarSQL Postgres) WrapError(err error) error {
+	var pqErr *pq.Error
+	if err == nil || !errors.As(err, &pqErr) {
+		return err
+	}
+
+	class, has := pgErrorClasses[pqErr.Code]
+	if !has {
+		return err
+	}
+
+	wrapped := dbal.NewError(class, err)
+	wrapped.Constraint = pqErr.Constraint
+	wrapped.Table = pqErr.Table
+	wrapped.Column = pqErr.Column
+	return wrapped
+}
@@ -29,3 +29,14 @@ func (grammarSQL Postgres) ProcessInsertGetID(sql string, bindings []interface{}
 	}
 	return seq, nil
 }
+
+// ResetSequence Set the sequence of the serial column to the max value of the column,
+// it should be called after inserting the rows with the explicit ids (data migration).
+func (grammarSQL Postgres) ResetSequence(tableName string, column string) error {
+	sql := fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence(%s, %s), COALESCE(MAX(%s), 1), MAX(%s) IS NOT NULL) FROM %s",
+		grammarSQL.VAL(tableName), grammarSQL.VAL(column), grammarSQL.ID(column), grammarSQL.ID(column), grammarSQL.ID(tableName),
+	)
+	_, err := grammarSQL.DB.Exec(sql)
+	return err
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// GetSchemas get the schemas (namespaces) of the database, the system schemas are excluded.
+func (grammarSQL Postgres) GetSchemas() ([]string, error) {
+	sql := `SELECT nspname FROM pg_namespace WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema' ORDER BY nspname`
+	defer log.Debug(sql)
+	schemas := []string{}
+	err := grammarSQL.DB.Select(&schemas, sql)
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// CreateSchema create a new schema
+func (grammarSQL Postgres) CreateSchema(name string) error {
+	sql := fmt.Sprintf("CREATE SCHEMA %s", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropSchema drop the schema, the objects of the schema are dropped if the cascade is true.
+func (grammarSQL Postgres) DropSchema(name string, cascade bool) error {
+	sql := fmt.Sprintf("DROP SCHEMA %s", grammarSQL.ID(name))
+	if cascade {
+		sql = sql + " CASCADE"
+	}
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// splitName split the schema-qualified table name (reporting.daily), the default schema is used if the name is not qualified.
+func (grammarSQL Postgres) splitName(name string) (string, string) {
+	if i := strings.LastIndex(name, "."); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return grammarSQL.GetSchema(), name
+}
+
+// tableID quoting the schema-qualified table name, e.g. "reporting"."daily"
+func (grammarSQL Postgres) tableID(name string) string {
+	if i := strings.LastIndex(name, "."); i > 0 {
+		return grammarSQL.ID(name[:i]) + "." + grammarSQL.ID(name[i+1:])
+	}
+	return grammarSQL.ID(name)
+}
+
+// objectID quoting the name of the index or the partition in the schema of the table, e.g. "reporting"."daily_name_index"
+func (grammarSQL Postgres) objectID(tableName string, name string) string {
+	if i := strings.LastIndex(tableName, "."); i > 0 {
+		return grammarSQL.ID(tableName[:i]) + "." + grammarSQL.ID(name)
+	}
+	return grammarSQL.ID(name)
+}
+
+// typeID get the name of the user defined type (enum) in the schema of the table, the types are created by CreateType
+func (grammarSQL Postgres) typeID(tableName string, name string) string {
+	if i := strings.LastIndex(tableName, "."); i > 0 {
+		return grammarSQL.ID(tableName[:i]) + "." + name
+	}
+	return name
+}
+
+// baseName get the table name without the schema, the names of the indexes and the triggers are derived from it.
+func baseName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// the declarative partitioning is supported since Postgres 10
+var partitionVersion = semver.MustParse("10.0.0")
+
+// the partition key definition, e.g. RANGE (created_at)
+var rePartitionKey = regexp.MustCompile(`(?s)^(\w+)\s*\((.*)\)$`)
+
+// partitionName get the table name of the partition, the partitions are the tables named {table}_{partition}
+func partitionName(tableName string, name string) string {
+	return fmt.Sprintf("%s_%s", baseName(tableName), name)
+}
+
+// supportPartition check if the partitioning is supported by the server
+func (grammarSQL Postgres) supportPartition() error {
+	version, err := grammarSQL.GetVersion()
+	if err != nil {
+		return err
+	}
+	if version.LT(partitionVersion) {
+		return fmt.Errorf("the table partitioning is not supported by postgres %s", version)
+	}
+	return nil
+}
+
+// GetPartitions get the partitions of the table, the names are without the {table}_ prefix
+func (grammarSQL Postgres) GetPartitions(tableName string) ([]*dbal.Partition, error) {
+	err := grammarSQL.supportPartition()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, tableName := grammarSQL.splitName(tableName)
+	stmt := fmt.Sprintf(`
+		SELECT n.nspname AS schema_name, parent.relname AS table_name, child.relname AS partition_name,
+			pg_get_partkeydef(parent.oid) AS partition_expression,
+			pg_get_expr(child.relpartbound, child.oid) AS partition_description
+		FROM pg_inherits i
+		JOIN pg_class parent ON parent.oid = i.inhparent
+		JOIN pg_class child ON child.oid = i.inhrelid
+		JOIN pg_namespace n ON n.oid = parent.relnamespace
+		WHERE n.nspname = %s AND parent.relname = %s
+		ORDER BY child.relname`,
+		grammarSQL.VAL(schema),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(stmt)
+	partitions := []*dbal.Partition{}
+	err = grammarSQL.DB.Select(&partitions, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, partition := range partitions {
+		partition.DBName = grammarSQL.GetDatabase()
+		partition.Name = strings.TrimPrefix(partition.Name, tableName+"_")
+		partition.Position = i + 1
+		if matches := rePartitionKey.FindStringSubmatch(partition.Expression); matches != nil {
+			partition.Method = strings.ToUpper(matches[1])
+			partition.Expression = matches[2]
+		}
+	}
+	return partitions, nil
+}
+
+// AddPartition create the partitions of the partitioned table
+func (grammarSQL Postgres) AddPartition(tableName string, partitions []*dbal.Partition) error {
+	err := grammarSQL.supportPartition()
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range partitions {
+		stmt := grammarSQL.SQLCreatePartition(tableName, partition)
+		defer log.Debug(stmt)
+		err = grammarSQL.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropPartition drop the partitions and the rows of them
+func (grammarSQL Postgres) DropPartition(tableName string, names []string) error {
+	tables := []string{}
+	for _, name := range names {
+		tables = append(tables, grammarSQL.objectID(tableName, partitionName(tableName, name)))
+	}
+	stmt := fmt.Sprintf("DROP TABLE %s", strings.Join(tables, ", "))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// DetachPartition detach the partition, it becomes the standalone table {table}_{partition}
+func (grammarSQL Postgres) DetachPartition(tableName string, name string) error {
+	stmt := fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", grammarSQL.tableID(tableName), grammarSQL.objectID(tableName, partitionName(tableName, name)))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// SQLPartitionBy return the PARTITION BY clause of the table, e.g. PARTITION BY RANGE ("created_at")
+func (grammarSQL Postgres) SQLPartitionBy(option *dbal.PartitionOption) string {
+	key := fmt.Sprintf("(%s)", option.Expression)
+	if len(option.Columns) > 0 {
+		columns := []string{}
+		for _, column := range option.Columns {
+			columns = append(columns, grammarSQL.ID(column))
+		}
+		key = strings.Join(columns, ",")
+	}
+	return fmt.Sprintf("PARTITION BY %s (%s)", strings.ToUpper(option.Method), key)
+}
+
+// SQLCreatePartition return the statement of creating the partition, the partition without bound is the DEFAULT partition.
+//
+//	CREATE TABLE "events_p202101" PARTITION OF "events" FOR VALUES FROM ('2021-01-01') TO ('2021-02-01')
+func (grammarSQL Postgres) SQLCreatePartition(tableName string, partition *dbal.Partition) string {
+	bound := "DEFAULT"
+	switch {
+	case len(partition.Values) > 0:
+		values := []string{}
+		for _, value := range partition.Values {
+			values = append(values, sql.Literal(value, sql.QuoteString))
+		}
+		bound = fmt.Sprintf("FOR VALUES IN (%s)", strings.Join(values, ","))
+
+	case partition.Modulus > 0:
+		bound = fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", partition.Modulus, partition.Remainder)
+
+	case partition.From != nil || partition.To != nil:
+		from, to := "MINVALUE", "MAXVALUE"
+		if partition.From != nil {
+			from = sql.Literal(partition.From, sql.QuoteString)
+		}
+		if partition.To != nil {
+			to = sql.Literal(partition.To, sql.QuoteString)
+		}
+		bound = fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", from, to)
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s PARTITION OF %s %s",
+		grammarSQL.objectID(tableName, partitionName(tableName, partition.Name)), grammarSQL.tableID(tableName), bound)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // Load postgres driver
@@ -38,7 +39,8 @@ func init() {
 
 // setup the method will be executed when db server was connected
 func (grammarSQL *Postgres) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Option) error {
-	if db == nil {
+	// the grammar without a database only collects the statements in the pretend mode, e.g. dumping to another driver
+	if db == nil && (option == nil || option.Pretend == nil) {
 		return fmt.Errorf("db is nil")
 	}
 
@@ -50,19 +52,34 @@ func (grammarSQL *Postgres) setup(db *sqlx.DB, config *dbal.Config, option *dbal
 	grammarSQL.Config = config
 	grammarSQL.Option = option
 
-	uinfo, err := url.Parse(grammarSQL.Config.DSN)
-	if err != nil {
-		return err
+	// the default schema is the first schema of the search_path, the option overrides the DSN
+	searchPath := ""
+	if config.DSN != "" {
+		uinfo, err := url.Parse(grammarSQL.Config.DSN)
+		if err != nil {
+			return err
+		}
+		grammarSQL.DatabaseName = filepath.Base(uinfo.Path)
+		searchPath = uinfo.Query().Get("search_path")
 	}
-	grammarSQL.DatabaseName = filepath.Base(uinfo.Path)
-	schema := uinfo.Query().Get("search_path")
-	if schema == "" {
-		schema = "public"
+	if option != nil && option.SearchPath != "" {
+		searchPath = option.SearchPath
 	}
-	grammarSQL.SchemaName = schema
+	grammarSQL.SchemaName = defaultSchema(searchPath)
 	return nil
 }
 
+// defaultSchema get the schema of the new tables from the search_path, e.g. "$user", tenant_1, public
+func defaultSchema(searchPath string) string {
+	for _, schema := range strings.Split(searchPath, ",") {
+		schema = strings.Trim(strings.TrimSpace(schema), "\"")
+		if schema != "" && schema != "$user" {
+			return schema
+		}
+	}
+	return "public"
+}
+
 // NewWith Create a new grammar interface, using the given *sqlx.DB, *dbal.Config and *dbal.Option.
 func (grammarSQL Postgres) NewWith(db *sqlx.DB, config *dbal.Config, option *dbal.Option) (dbal.Grammar, error) {
 	err := grammarSQL.setup(db, config, option)
@@ -95,8 +112,9 @@ func New(opts ...sql.Option) dbal.Grammar {
 		pg.Driver = "postgres"
 	}
 	pg.IndexTypes = map[string]string{
-		"unique": "UNIQUE INDEX",
-		"index":  "INDEX",
+		"unique":  "UNIQUE INDEX",
+		"index":   "INDEX",
+		"spatial": "INDEX",
 	}
 
 	// overwrite types
@@ -119,6 +137,12 @@ func New(opts ...sql.Option) dbal.Grammar {
 	types["timestampTz"] = "TIMESTAMP(%d) WITH TIME ZONE"
 	types["binary"] = "BYTEA"
 	types["macAddress"] = "MACADDR"
+	types["geometry"] = "GEOMETRY" // PostGIS
+	types["geometryCollection"] = "GEOMETRY(GEOMETRYCOLLECTION)"
+	types["point"] = "GEOMETRY(POINT)"
+	types["multiPoint"] = "GEOMETRY(MULTIPOINT)"
+	types["polygon"] = "GEOMETRY(POLYGON)"
+	types["multiPolygon"] = "GEOMETRY(MULTIPOLYGON)"
 	pg.Types = types
 
 	// set fliptypes
@@ -3,6 +3,7 @@ package postgres
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -66,7 +67,7 @@ func (grammarSQL Postgres) GetVersion() (*dbal.Version, error) {
 // GetTables Get all of the table names for the database.
 func (grammarSQL Postgres) GetTables() ([]string, error) {
 	sql := fmt.Sprintf(
-		"SELECT table_name AS name FROM information_schema.tables WHERE table_catalog=%s AND table_schema=%s",
+		"SELECT table_name AS name FROM information_schema.tables WHERE table_catalog=%s AND table_schema=%s AND table_type='BASE TABLE'",
 		grammarSQL.VAL(grammarSQL.GetDatabase()),
 		grammarSQL.VAL(grammarSQL.GetSchema()),
 	)
@@ -81,10 +82,11 @@ func (grammarSQL Postgres) GetTables() ([]string, error) {
 
 // TableExists check if the table exists
 func (grammarSQL Postgres) TableExists(name string) (bool, error) {
+	schema, name := grammarSQL.splitName(name)
 	sql := fmt.Sprintf(
-		"SELECT table_name AS name FROM information_schema.tables WHERE table_catalog=%s AND table_schema=%s AND table_name = %s",
+		"SELECT table_name AS name FROM information_schema.tables WHERE table_catalog=%s AND table_schema=%s AND table_type='BASE TABLE' AND table_name = %s",
 		grammarSQL.VAL(grammarSQL.GetDatabase()),
-		grammarSQL.VAL(grammarSQL.GetSchema()),
+		grammarSQL.VAL(schema),
 		grammarSQL.VAL(name),
 	)
 	defer log.Debug(sql)
@@ -110,9 +112,9 @@ func (grammarSQL Postgres) CreateType(table *dbal.Table, types map[string][]stri
 	EXCEPTION  
 		WHEN duplicate_object THEN null;
 	END $$;
-	`, table.SchemaName, name, typ)
+	`, grammarSQL.ID(table.SchemaName), name, typ)
 		defer log.Debug(typeSQL)
-		_, err := grammarSQL.DB.Exec(typeSQL)
+		err := grammarSQL.Exec(typeSQL)
 		if err != nil {
 			return err
 		}
@@ -122,7 +124,7 @@ func (grammarSQL Postgres) CreateType(table *dbal.Table, types map[string][]stri
 
 // CreateTable create a new table on the schema
 func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.CreateTableOption) error {
-	name := grammarSQL.ID(table.TableName)
+	name := grammarSQL.tableID(table.TableName)
 	sql := fmt.Sprintf("CREATE TABLE %s (\n", name)
 	if len(options) > 0 {
 		option := options[0]
@@ -137,6 +139,8 @@ func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.Create
 	var primary *dbal.Primary = nil
 	columns := []*dbal.Column{}
 	indexes := []*dbal.Index{}
+	foreigns := []*dbal.ForeignKey{}
+	constraints := []*dbal.Constraint{}
 	cbCommands := []*dbal.Command{}
 	// Commands
 	// The commands must be:
@@ -161,6 +165,14 @@ func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.Create
 			primary = command.Params[0].(*dbal.Primary)
 			cbCommands = append(cbCommands, command)
 			break
+		case "CreateForeign":
+			foreigns = append(foreigns, command.Params[0].(*dbal.ForeignKey))
+			cbCommands = append(cbCommands, command)
+			break
+		case "CreateConstraint":
+			constraints = append(constraints, command.Params[0].(*dbal.Constraint))
+			cbCommands = append(cbCommands, command)
+			break
 		}
 	}
 
@@ -173,16 +185,60 @@ func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.Create
 	if primary != nil {
 		stmts = append(stmts, grammarSQL.SQLAddPrimary(primary))
 	}
+
+	// Foreign keys
+	for _, foreign := range foreigns {
+		stmts = append(stmts, grammarSQL.SQLAddForeign(foreign))
+	}
+
+	// Constraints
+	for _, constraint := range constraints {
+		stmts = append(stmts, grammarSQL.SQLAddConstraint(constraint))
+	}
 	sql = sql + strings.Join(stmts, ",\n")
 	sql = sql + fmt.Sprintf("\n)")
 
+	// PARTITION BY RANGE ("created_at")
+	var partition *dbal.PartitionOption = nil
+	if len(options) > 0 && options[0].Partition != nil {
+		partition = options[0].Partition
+		err = grammarSQL.supportPartition()
+		if err != nil {
+			return err
+		}
+		sql = sql + " " + grammarSQL.SQLPartitionBy(partition)
+	}
+
+	// WITH (fillfactor=70) TABLESPACE "ts1"
+	if table.FillFactor > 0 {
+		sql = sql + fmt.Sprintf(" WITH (fillfactor=%d)", table.FillFactor)
+	}
+	if table.Tablespace != "" {
+		sql = sql + fmt.Sprintf(" TABLESPACE %s", grammarSQL.ID(table.Tablespace))
+	}
+
 	// Create table
 	defer log.Debug(sql)
-	_, err = grammarSQL.DB.Exec(sql)
+	err = grammarSQL.Exec(sql)
 	if err != nil {
 		return err
 	}
 
+	// the partitions, the HASH partitions are numbered in order if the modulus is not given
+	if partition != nil {
+		for i, p := range partition.Partitions {
+			if strings.ToUpper(partition.Method) == "HASH" && p.Modulus == 0 {
+				p.Modulus, p.Remainder = len(partition.Partitions), i
+			}
+			stmt := grammarSQL.SQLCreatePartition(table.TableName, p)
+			defer log.Debug(stmt)
+			err = grammarSQL.Exec(stmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// indexes
 	err = grammarSQL.createTableCreateIndex(table, indexes)
 	if err != nil {
@@ -190,11 +246,39 @@ func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.Create
 	}
 
 	// Comments
+	if table.Comment != "" {
+		commentStmts = append(commentStmts, grammarSQL.SQLTableOption(table, "comment", table.Comment))
+	}
 	err = grammarSQL.createTableAddComment(table, commentStmts)
 	if err != nil {
 		return err
 	}
 
+	// the on update triggers
+	for _, column := range columns {
+		if column.OnUpdateCurrent {
+			for _, stmt := range grammarSQL.SQLCreateOnUpdateTrigger(table, column.Name) {
+				defer log.Debug(stmt)
+				err = grammarSQL.Exec(stmt)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// the start value of the auto-increment column
+	if table.AutoIncrement > 0 {
+		stmt := grammarSQL.SQLTableOption(table, "autoIncrement", table.AutoIncrement)
+		if stmt != "" {
+			defer log.Debug(stmt)
+			err = grammarSQL.Exec(stmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Callback
 	for _, cmd := range cbCommands {
 		cmd.Callback(err)
@@ -234,7 +318,10 @@ func (grammarSQL Postgres) createTableCreateIndex(table *dbal.Table, indexes []*
 		if index.Type == "primary" {
 			continue
 		}
-		indexStmt := grammarSQL.SQLAddIndex(index)
+		// the new table is empty, the indexes are built normally
+		idx := *index
+		idx.Concurrently = false
+		indexStmt := grammarSQL.SQLAddIndex(&idx)
 		if indexStmt != "" {
 			indexStmts = append(indexStmts, indexStmt)
 		}
@@ -242,7 +329,7 @@ func (grammarSQL Postgres) createTableCreateIndex(table *dbal.Table, indexes []*
 	if len(indexStmts) > 0 {
 		sql := strings.Join(indexStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		err := grammarSQL.Exec(sql)
 		return err
 	}
 	return nil
@@ -252,20 +339,39 @@ func (grammarSQL Postgres) createTableAddComment(table *dbal.Table, commentStmts
 	if len(commentStmts) > 0 {
 		sql := strings.Join(commentStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		err := grammarSQL.Exec(sql)
 		return err
 	}
 	return nil
 }
 
-// RenameTable rename a table on the schema.
-func (grammarSQL Postgres) RenameTable(old string, new string) error {
-	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
+// DropTable a table from the schema.
+func (grammarSQL Postgres) DropTable(name string) error {
+	sql := fmt.Sprintf("DROP TABLE %s", grammarSQL.tableID(name))
+	defer log.Debug(sql)
+	err := grammarSQL.Exec(sql)
+	return err
+}
+
+// DropTableIfExists if the table exists, drop it from the schema.
+func (grammarSQL Postgres) DropTableIfExists(name string) error {
+	sql := fmt.Sprintf("DROP TABLE IF EXISTS %s", grammarSQL.tableID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	return err
 }
 
+// RenameTable rename a table on the schema, the renamed table stays in its schema.
+func (grammarSQL Postgres) RenameTable(old string, new string) error {
+	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.tableID(old), grammarSQL.ID(baseName(new)))
+	defer log.Debug(sql)
+	err := grammarSQL.Exec(sql)
+	if err != nil {
+		return err
+	}
+	return grammarSQL.renameOnUpdateTriggers(old, new)
+}
+
 // GetTable get a table on the schema
 func (grammarSQL Postgres) GetTable(name string) (*dbal.Table, error) {
 	has, err := grammarSQL.TableExists(name)
@@ -277,39 +383,66 @@ func (grammarSQL Postgres) GetTable(name string) (*dbal.Table, error) {
 		return nil, fmt.Errorf("the table %s does not exists", name)
 	}
 
-	table := dbal.NewTable(name, grammarSQL.GetSchema(), grammarSQL.GetDatabase())
-	columns, err := grammarSQL.GetColumnListing(table.SchemaName, table.TableName)
+	schema, tableName := grammarSQL.splitName(name)
+	table := dbal.NewTable(name, schema, grammarSQL.GetDatabase())
+	columns, err := grammarSQL.GetColumnListing(schema, tableName)
 	if err != nil {
 		return nil, err
 	}
-	indexes, err := grammarSQL.GetIndexListing(table.SchemaName, table.TableName)
+	err = grammarSQL.getTableOption(table, columns)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := grammarSQL.GetIndexListing(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	foreignKeys, err := grammarSQL.GetForeignKeyListing(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, err := grammarSQL.GetTableConstraintListing(schema, tableName)
 	if err != nil {
 		return nil, err
 	}
 
 	primaryKeyName := ""
 
-	// attaching columns
+	// attaching columns, the objects of the schema-qualified table are named with the qualified name
 	for _, column := range columns {
+		column.TableName = name
 		column.Indexes = []*dbal.Index{}
 		table.PushColumn(column)
 	}
 
-	// attaching indexes
+	// attaching indexes, the expression key parts have no column
 	for i := range indexes {
 		idx := indexes[i]
-		if !table.HasColumn(idx.ColumnName) {
-			return nil, fmt.Errorf("the column %s does not exists", idx.ColumnName)
+		idx.TableName = name
+		var column *dbal.Column
+		if idx.Expression == "" {
+			if !table.HasColumn(idx.ColumnName) {
+				return nil, fmt.Errorf("the column %s does not exists", idx.ColumnName)
+			}
+			column = table.ColumnMap[idx.ColumnName]
 		}
-		column := table.ColumnMap[idx.ColumnName]
 		if !table.HasIndex(idx.Name) {
 			index := *idx
 			index.Columns = []*dbal.Column{}
-			column.Indexes = append(column.Indexes, &index)
+			if column != nil {
+				column.Indexes = append(column.Indexes, &index)
+			}
 			table.PushIndex(&index)
 		}
 		index := table.IndexMap[idx.Name]
-		index.Columns = append(index.Columns, column)
+		index.AddPart(&dbal.IndexPart{
+			Column:     column,
+			Expression: idx.Expression,
+			Order:      idx.Order,
+			Length:     idx.SubPart,
+			OpClass:    idx.OpClass,
+		})
 		if index.Type == "primary" {
 			primaryKeyName = idx.Name
 		}
@@ -332,13 +465,91 @@ func (grammarSQL Postgres) GetTable(name string) (*dbal.Table, error) {
 		}
 	}
 
+	// attaching constraints
+	for _, c := range constraints {
+		// the unsigned columns are emulated with the <table>_<column>_unsigned check constraints
+		if c.Type == "CHECK" && strings.HasSuffix(c.Name, "_unsigned") && table.HasColumn(c.ColumnName) {
+			table.ColumnMap[c.ColumnName].IsUnsigned = true
+			continue
+		}
+		if !table.HasConstraint(c.Name) {
+			constraint := *c
+			constraint.Table = table
+			constraint.Columns = []*dbal.Column{}
+			table.PushConstraint(&constraint)
+		}
+		if column, has := table.ColumnMap[c.ColumnName]; has {
+			table.ConstraintMap[c.Name].AddColumn(column)
+		}
+	}
+
+	// attaching foreign keys
+	for _, fk := range foreignKeys {
+		if !table.HasColumn(fk.ColumnName) {
+			return nil, fmt.Errorf("the column %s does not exists", fk.ColumnName)
+		}
+		if !table.HasForeignKey(fk.Name) {
+			foreign := *fk
+			foreign.Table = table
+			foreign.Columns = []*dbal.Column{}
+			foreign.ReferencedColumns = []string{}
+			table.PushForeignKey(&foreign)
+		}
+		foreign := table.ForeignKeyMap[fk.Name]
+		foreign.AddColumn(table.ColumnMap[fk.ColumnName], fk.ReferencedColumnName)
+	}
+
 	return table, nil
 }
 
+// getTableOption read the table options (comment, fill factor, tablespace) and the next value of the auto-increment column
+func (grammarSQL Postgres) getTableOption(table *dbal.Table, columns []*dbal.Column) error {
+	sql := fmt.Sprintf(`
+		SELECT COALESCE(obj_description(c.oid, 'pg_class'), '') AS "table_comment",
+			COALESCE(ts.spcname, '') AS "tablespace",
+			COALESCE(array_to_string(c.reloptions, ','), '') AS "create_options"
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_tablespace ts ON ts.oid = c.reltablespace
+		WHERE n.nspname = %s AND c.relname = %s`,
+		grammarSQL.VAL(table.SchemaName),
+		grammarSQL.VAL(baseName(table.TableName)),
+	)
+	defer log.Debug(sql)
+	err := grammarSQL.DB.Get(table, sql)
+	if err != nil {
+		return err
+	}
+
+	// fillfactor=70,autovacuum_enabled=false
+	for _, option := range strings.Split(table.CreateOptions, ",") {
+		if strings.HasPrefix(option, "fillfactor=") {
+			table.FillFactor, _ = strconv.Atoi(strings.TrimPrefix(option, "fillfactor="))
+		}
+	}
+
+	for _, column := range columns {
+		if utils.StringVal(column.Extra) != "AutoIncrement" {
+			continue
+		}
+		sequence := ""
+		sql = fmt.Sprintf("SELECT COALESCE(pg_get_serial_sequence(%s, %s), '')", grammarSQL.VAL(grammarSQL.tableID(table.TableName)), grammarSQL.VAL(column.Name))
+		defer log.Debug(sql)
+		err = grammarSQL.DB.Get(&sequence, sql)
+		if err != nil || sequence == "" {
+			return err
+		}
+		sql = fmt.Sprintf("SELECT CASE WHEN is_called THEN last_value + 1 ELSE last_value END FROM %s", sequence)
+		defer log.Debug(sql)
+		return grammarSQL.DB.Get(&table.AutoIncrement, sql)
+	}
+	return nil
+}
+
 // AlterTable alter a table on the schema
 func (grammarSQL Postgres) AlterTable(table *dbal.Table) error {
 
-	sql := fmt.Sprintf("ALTER TABLE %s ", grammarSQL.ID(table.TableName))
+	sql := fmt.Sprintf("ALTER TABLE %s ", grammarSQL.tableID(table.TableName))
 	stmts := []string{}
 	errs := []error{}
 
@@ -351,6 +562,11 @@ func (grammarSQL Postgres) AlterTable(table *dbal.Table) error {
 	//    CreateIndex(index *Index) for creating a index
 	//    DropIndex(name string) for  dropping a index
 	//    RenameIndex(old string,new string)  for renaming a index
+	//    CreateForeign(foreign *ForeignKey) for creating a foreign key
+	//    DropForeign(name string) for dropping a foreign key
+	//    CreateConstraint(constraint *Constraint) for creating a unique or check constraint
+	//    DropConstraint(name string) for dropping a unique or check constraint
+	//    SetTableOption(name string, value interface{}) for changing a table option
 	for _, command := range table.Commands {
 		switch command.Name {
 		case "AddColumn":
@@ -380,6 +596,21 @@ func (grammarSQL Postgres) AlterTable(table *dbal.Table) error {
 		case "DropPrimary":
 			grammarSQL.alterTableDropPrimary(table, command, sql, &stmts, &errs)
 			break
+		case "CreateForeign":
+			grammarSQL.alterTableCreateForeign(table, command, sql, &stmts, &errs)
+			break
+		case "DropForeign":
+			grammarSQL.alterTableDropForeign(table, command, sql, &stmts, &errs)
+			break
+		case "CreateConstraint":
+			grammarSQL.alterTableCreateConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "DropConstraint":
+			grammarSQL.alterTableDropConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "SetTableOption":
+			grammarSQL.alterTableSetOption(table, command, sql, &stmts, &errs)
+			break
 		}
 	}
 
@@ -423,11 +654,19 @@ func (grammarSQL Postgres) alterTableAddColumn(table *dbal.Table, command *dbal.
 			*errs = append(*errs, err)
 		}
 	}
+
+	if err == nil && column.OnUpdateCurrent {
+		err = grammarSQL.execOnUpdateTrigger(table, column.Name, true, stmts)
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+	}
 	command.Callback(err)
 }
 
 func (grammarSQL Postgres) alterTableChangeColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	column := command.Params[0].(*dbal.Column)
+	onUpdate := table.HasColumn(column.Name) && table.ColumnMap[column.Name].OnUpdateCurrent
 	stmt := "ALTER COLUMN " + grammarSQL.SQLAlterColumnType(column)
 	*stmts = append(*stmts, sql+stmt)
 	if column.Type == "enum" {
@@ -452,6 +691,13 @@ func (grammarSQL Postgres) alterTableChangeColumn(table *dbal.Table, command *db
 			*errs = append(*errs, err)
 		}
 	}
+
+	if err == nil && column.OnUpdateCurrent != onUpdate {
+		err = grammarSQL.execOnUpdateTrigger(table, column.Name, column.OnUpdateCurrent, stmts)
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+	}
 	command.Callback(err)
 }
 
@@ -464,6 +710,7 @@ func (grammarSQL Postgres) alterTableRenameColumn(table *dbal.Table, command *db
 		return
 	}
 	column.Name = new
+	onUpdate := column.OnUpdateCurrent
 	stmt := fmt.Sprintf("RENAME COLUMN %s TO %s",
 		grammarSQL.ID(old),
 		grammarSQL.ID(new),
@@ -473,11 +720,31 @@ func (grammarSQL Postgres) alterTableRenameColumn(table *dbal.Table, command *db
 	if err != nil {
 		*errs = append(*errs, err)
 	}
+
+	// the trigger argument is the column name
+	if err == nil && onUpdate {
+		err = grammarSQL.execOnUpdateTrigger(table, old, false, stmts)
+		if err == nil {
+			err = grammarSQL.execOnUpdateTrigger(table, new, true, stmts)
+		}
+		if err != nil {
+			*errs = append(*errs, err)
+		}
+	}
 	command.Callback(err)
 }
 
 func (grammarSQL Postgres) alterTableDropColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	name := command.Params[0].(string)
+	if column, has := table.ColumnMap[name]; has && column.OnUpdateCurrent {
+		err := grammarSQL.execOnUpdateTrigger(table, name, false, stmts)
+		if err != nil {
+			*errs = append(*errs, err)
+			command.Callback(err)
+			return
+		}
+	}
+
 	stmt := fmt.Sprintf("DROP COLUMN %s", grammarSQL.ID(name))
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
@@ -499,10 +766,10 @@ func (grammarSQL Postgres) alterTableCreateIndex(table *dbal.Table, command *dba
 }
 
 func (grammarSQL Postgres) alterTableDropIndex(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
-	name := fmt.Sprintf("%s_%s", table.TableName, command.Params[0])
+	name := fmt.Sprintf("%s_%s", baseName(table.TableName), command.Params[0])
 	stmt := fmt.Sprintf(
 		"DROP INDEX %s",
-		grammarSQL.ID(name),
+		grammarSQL.objectID(table.TableName, name),
 		// grammarSQL.Quoter.ID(table.TableName, db),
 	)
 	*stmts = append(*stmts, stmt)
@@ -527,7 +794,7 @@ func (grammarSQL Postgres) alterTableCreatePrimary(table *dbal.Table, command *d
 func (grammarSQL Postgres) alterTableDropPrimary(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	stmt := fmt.Sprintf(
 		"DROP CONSTRAINT %s",
-		grammarSQL.ID(fmt.Sprintf("%s_pkey", table.GetName())),
+		grammarSQL.ID(fmt.Sprintf("%s_pkey", baseName(table.GetName()))),
 	)
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
@@ -538,11 +805,11 @@ func (grammarSQL Postgres) alterTableDropPrimary(table *dbal.Table, command *dba
 }
 
 func (grammarSQL Postgres) alterTableRenameIndex(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
-	old := fmt.Sprintf("%s_%s", table.TableName, command.Params[0])
-	new := fmt.Sprintf("%s_%s", table.TableName, command.Params[1])
+	old := fmt.Sprintf("%s_%s", baseName(table.TableName), command.Params[0])
+	new := fmt.Sprintf("%s_%s", baseName(table.TableName), command.Params[1])
 	stmt := fmt.Sprintf(
 		"ALTER INDEX IF EXISTS %s RENAME TO %s",
-		grammarSQL.ID(old),
+		grammarSQL.objectID(table.TableName, old),
 		grammarSQL.ID(new),
 		// grammarSQL.Quoter.ID(table.TableName, db),
 	)
@@ -554,10 +821,101 @@ func (grammarSQL Postgres) alterTableRenameIndex(table *dbal.Table, command *dba
 	command.Callback(err)
 }
 
+func (grammarSQL Postgres) alterTableCreateForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	foreign := command.Params[0].(*dbal.ForeignKey)
+	stmt := "ADD " + grammarSQL.SQLAddForeign(foreign)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Postgres) alterTableDropForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	stmt := fmt.Sprintf("DROP CONSTRAINT %s", grammarSQL.ID(name))
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Postgres) alterTableCreateConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	constraint := command.Params[0].(*dbal.Constraint)
+	stmt := "ADD " + grammarSQL.SQLAddConstraint(constraint)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Postgres) alterTableDropConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	stmt := fmt.Sprintf("DROP CONSTRAINT %s", grammarSQL.ID(name))
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL Postgres) alterTableSetOption(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	stmt := grammarSQL.SQLTableOption(table, command.Params[0].(string), command.Params[1])
+	if stmt == "" {
+		command.Callback(nil)
+		return
+	}
+	*stmts = append(*stmts, stmt)
+	err := grammarSQL.ExecSQL(table, stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("SetTableOption: %s", err))
+	}
+	command.Callback(err)
+}
+
+// SQLTableOption return the statement changing the table option, returns "" if the option is not supported.
+// The comment, the fill factor, the tablespace and the next value of the auto-increment column are supported.
+func (grammarSQL Postgres) SQLTableOption(table *dbal.Table, name string, value interface{}) string {
+	switch name {
+	case "comment":
+		if value == "" {
+			return fmt.Sprintf("COMMENT ON TABLE %s IS NULL", grammarSQL.tableID(table.TableName))
+		}
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", grammarSQL.tableID(table.TableName), grammarSQL.VAL(value))
+	case "fillFactor":
+		if value == 0 {
+			return fmt.Sprintf("ALTER TABLE %s RESET (fillfactor)", grammarSQL.tableID(table.TableName))
+		}
+		return fmt.Sprintf("ALTER TABLE %s SET (fillfactor=%d)", grammarSQL.tableID(table.TableName), value)
+	case "tablespace":
+		tablespace := fmt.Sprintf("%v", value)
+		if tablespace == "" {
+			tablespace = "pg_default"
+		}
+		return fmt.Sprintf("ALTER TABLE %s SET TABLESPACE %s", grammarSQL.tableID(table.TableName), grammarSQL.ID(tablespace))
+	case "autoIncrement":
+		for _, column := range table.Columns {
+			if utils.StringVal(column.Extra) == "AutoIncrement" {
+				return fmt.Sprintf(
+					"SELECT setval(pg_get_serial_sequence(%s, %s), %d, false)",
+					grammarSQL.VAL(grammarSQL.tableID(table.TableName)), grammarSQL.VAL(column.Name), value,
+				)
+			}
+		}
+	}
+	return ""
+}
+
 // ExecSQL execute sql then update table structure
 func (grammarSQL Postgres) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
-	if err != nil {
+	err := grammarSQL.Exec(sql)
+	if err != nil || grammarSQL.IsPretend() {
 		return err
 	}
 	// update table structure
@@ -590,7 +948,7 @@ func (grammarSQL Postgres) SQLAlterColumnType(Column *dbal.Column) string {
 	} else if typ == "BYTEA" {
 		typ = "BYTEA"
 	} else if typ == "ENUM" {
-		typ = strings.ToLower("ENUM__" + strings.Join(Column.Option, "_EOPT_"))
+		typ = grammarSQL.typeID(Column.TableName, strings.ToLower("ENUM__"+strings.Join(Column.Option, "_EOPT_")))
 	} else if Column.Length != nil {
 		typ = fmt.Sprintf("%s(%d)", typ, utils.IntVal(Column.Length))
 	} else if typ == "IPADDRESS" { // ipAddress
@@ -599,7 +957,7 @@ func (grammarSQL Postgres) SQLAlterColumnType(Column *dbal.Column) string {
 		typ = "SMALLINT"
 	}
 
-	if utils.StringVal(Column.Extra) != "" {
+	if utils.StringVal(Column.Extra) == "AutoIncrement" {
 		if typ == "BIGINT" {
 			typ = "BIGSERIAL"
 		} else {
@@ -611,10 +969,15 @@ func (grammarSQL Postgres) SQLAlterColumnType(Column *dbal.Column) string {
 	// 	"%s SET DATA TYPE %s ",
 	// 	quoter.ID(Column.Name, db), typ)
 
+	collation := ""
+	if utils.StringVal(Column.Collation) != "" {
+		collation = fmt.Sprintf(" COLLATE %s", quoter.ID(utils.StringVal(Column.Collation)))
+	}
+
 	nameQuoter := quoter.ID(Column.Name)
 	sql := fmt.Sprintf(
-		"%s TYPE %s USING (%s::%s) ",
-		nameQuoter, typ, nameQuoter, typ)
+		"%s TYPE %s%s USING (%s::%s) ",
+		nameQuoter, typ, collation, nameQuoter, typ)
 
 	sql = strings.Trim(sql, " ")
 	return sql
@@ -638,7 +1001,7 @@ func (grammarSQL Postgres) SQLAlterIndex(index *dbal.Index) string {
 	name := quoter.ID(index.Name)
 	sql := fmt.Sprintf(
 		"CREATE %s %s ON %s (%s)",
-		typ, name, quoter.ID(index.TableName), strings.Join(columns, ","))
+		typ, name, grammarSQL.tableID(index.TableName), strings.Join(columns, ","))
 
 	if typ == "PRIMARY KEY" {
 		sql = fmt.Sprintf(
@@ -648,52 +1011,82 @@ func (grammarSQL Postgres) SQLAlterIndex(index *dbal.Index) string {
 	return sql
 }
 
-// GetIndexListing get a table indexes structure
+// GetIndexListing get a table indexes structure, one row for each key part.
+// The expression key parts have no column, the predicate is the WHERE clause of the partial index.
+// The index type is the access method (BTREE, GIN, GIST, BRIN, HASH), the covering columns are listed in the first row.
 func (grammarSQL Postgres) GetIndexListing(dbName string, tableName string) ([]*dbal.Index, error) {
+
+	// the covering columns (INCLUDE) are supported since Postgres 11
+	keyatts := "ix.indnatts"
+	pg11, _ := semver.Make("11.0.0")
+	version, err := grammarSQL.GetVersion()
+	covering := err == nil && version.GE(pg11)
+	if covering {
+		keyatts = "ix.indnkeyatts"
+	}
+
 	selectColumns := []string{
 		"n.nspname as db_name",
 		"t.relname as table_name",
 		"i.relname as index_name",
-		"a.attname as column_name",
+		"COALESCE(a.attname, '') as column_name",
 		"'' as collation",
 		"false as nullable",
 		"indisunique as unique",
 		`indisprimary as "primary"`,
 		"'' as comment",
-		"'BTREE' as index_type",
-		"a.attnum as seq_in_index",
+		"UPPER(am.amname) as index_type",
+		"k.n as seq_in_index",
 		"'' as index_comment",
+		"CASE WHEN a.attname IS NULL THEN pg_get_indexdef(ix.indexrelid, k.n, true) ELSE '' END as expression",
+		"CASE WHEN (ix.indoption[k.n - 1] & 1) = 1 THEN 'DESC' ELSE '' END as sort_order",
+		"COALESCE(pg_get_expr(ix.indpred, ix.indrelid, true), '') as predicate",
+		"CASE WHEN opc.opcdefault THEN '' ELSE COALESCE(opc.opcname, '') END as operator_class",
 	}
 	sql := fmt.Sprintf(`
 			SELECT %s 
-			FROM
-				pg_class t,pg_class i,pg_index ix,pg_attribute a,pg_type as typ,pg_namespace as n
+			FROM pg_index ix
+				JOIN pg_class t ON t.oid = ix.indrelid
+				JOIN pg_class i ON i.oid = ix.indexrelid
+				JOIN pg_am am ON am.oid = i.relam
+				JOIN pg_namespace n ON n.oid = t.relnamespace
+				CROSS JOIN LATERAL generate_series(1, %s) AS k(n)
+				LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND ix.indkey[k.n - 1] <> 0 AND a.attnum = ix.indkey[k.n - 1]
+				LEFT JOIN pg_opclass opc ON opc.oid = ix.indclass[k.n - 1]
 			WHERE 
-				t.oid = ix.indrelid
-				and n.oid = t.relnamespace
-				and i.oid = ix.indexrelid
-				and	typ.oid = a.atttypid
-				and a.attrelid = t.oid
-				and a.attnum = ANY(ix.indkey)
-				and t.relkind = 'r'
+				t.relkind = 'r'
 				and n.nspname = %s
 				and t.relname = %s
+				and NOT EXISTS (SELECT 1 FROM pg_constraint pc WHERE pc.conindid = i.oid AND pc.contype = 'u')
 			ORDER BY
-				t.relname, i.relname,a.attnum desc
+				t.relname, i.relname, k.n
 			`,
 		strings.Join(selectColumns, ","),
+		keyatts,
 		grammarSQL.VAL(dbName),
 		grammarSQL.VAL(tableName),
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err = grammarSQL.DB.Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
 
+	include := map[string][]string{}
+	if covering {
+		include, err = grammarSQL.getIndexInclude(dbName, tableName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// counting the type of indexes
 	for _, index := range indexes {
+		if columns, has := include[index.Name]; has {
+			index.Include = columns
+			delete(include, index.Name)
+		}
 		if index.Primary {
 			index.Type = "primary"
 			index.Name = "PRIMARY"
@@ -707,6 +1100,147 @@ func (grammarSQL Postgres) GetIndexListing(dbName string, tableName string) ([]*
 	return indexes, nil
 }
 
+// getIndexInclude get the covering columns of the table indexes, the key is the index name
+func (grammarSQL Postgres) getIndexInclude(dbName string, tableName string) (map[string][]string, error) {
+	sql := fmt.Sprintf(`
+			SELECT i.relname as index_name, a.attname as column_name
+			FROM pg_index ix
+				JOIN pg_class t ON t.oid = ix.indrelid
+				JOIN pg_class i ON i.oid = ix.indexrelid
+				JOIN pg_namespace n ON n.oid = t.relnamespace
+				CROSS JOIN LATERAL generate_series(ix.indnkeyatts + 1, ix.indnatts) AS k(n)
+				JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ix.indkey[k.n - 1]
+			WHERE 
+				n.nspname = %s
+				and t.relname = %s
+			ORDER BY
+				i.relname, k.n
+			`,
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	rows := []struct {
+		Name   string `db:"index_name"`
+		Column string `db:"column_name"`
+	}{}
+	err := grammarSQL.DB.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	include := map[string][]string{}
+	for _, row := range rows {
+		include[row.Name] = append(include[row.Name], row.Column)
+	}
+	return include, nil
+}
+
+// GetForeignKeyListing get a table foreign keys structure
+func (grammarSQL Postgres) GetForeignKeyListing(dbName string, tableName string) ([]*dbal.ForeignKey, error) {
+	action := `CASE %s
+				WHEN 'c' THEN 'CASCADE'
+				WHEN 'n' THEN 'SET NULL'
+				WHEN 'd' THEN 'SET DEFAULT'
+				WHEN 'r' THEN 'RESTRICT'
+				ELSE 'NO ACTION'
+			END`
+	selectColumns := []string{
+		"n.nspname as db_name",
+		"t.relname as table_name",
+		"c.conname as constraint_name",
+		"a.attname as column_name",
+		"k.ord as seq_in_key",
+		"ft.relname as referenced_table_name",
+		"fa.attname as referenced_column_name",
+		fmt.Sprintf(action, "c.confdeltype") + " as on_delete",
+		fmt.Sprintf(action, "c.confupdtype") + " as on_update",
+	}
+	sql := fmt.Sprintf(`
+			SELECT %s
+			FROM
+				pg_constraint c
+				JOIN pg_class t ON t.oid = c.conrelid
+				JOIN pg_namespace n ON n.oid = t.relnamespace
+				JOIN pg_class ft ON ft.oid = c.confrelid
+				CROSS JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord)
+				JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+				JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = k.fattnum
+			WHERE
+				c.contype = 'f'
+				and n.nspname = %s
+				and t.relname = %s
+			ORDER BY
+				c.conname, k.ord
+			`,
+		strings.Join(selectColumns, ","),
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	foreignKeys := []*dbal.ForeignKey{}
+	err := grammarSQL.DB.Select(&foreignKeys, sql)
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys, nil
+}
+
+// GetTableConstraintListing get a table unique and check constraints structure
+func (grammarSQL Postgres) GetTableConstraintListing(dbName string, tableName string) ([]*dbal.Constraint, error) {
+	selectColumns := []string{
+		"c.conname as constraint_name",
+		"CASE c.contype WHEN 'u' THEN 'UNIQUE' ELSE 'CHECK' END as constraint_type",
+		"COALESCE(a.attname, '') as column_name",
+		"CASE c.contype WHEN 'c' THEN pg_get_constraintdef(c.oid) ELSE '' END as definition",
+	}
+	sql := fmt.Sprintf(`
+			SELECT %s
+			FROM
+				pg_constraint c
+				JOIN pg_class t ON t.oid = c.conrelid
+				JOIN pg_namespace n ON n.oid = t.relnamespace
+				LEFT JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord) ON true
+				LEFT JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+			WHERE
+				c.contype IN ('u', 'c')
+				and n.nspname = %s
+				and t.relname = %s
+			ORDER BY
+				c.conname, k.ord
+			`,
+		strings.Join(selectColumns, ","),
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	rows := []struct {
+		Name       string `db:"constraint_name"`
+		Type       string `db:"constraint_type"`
+		ColumnName string `db:"column_name"`
+		Definition string `db:"definition"`
+	}{}
+	err := grammarSQL.DB.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := []*dbal.Constraint{}
+	for _, row := range rows {
+		constraint := dbal.NewConstraint(dbName, tableName, row.ColumnName)
+		constraint.Name = row.Name
+		constraint.Type = row.Type
+		if row.Type == "CHECK" {
+			// CHECK ((price >= 0)) NOT VALID => (price >= 0)
+			expression := strings.TrimSuffix(row.Definition, " NOT VALID")
+			expression = strings.TrimSuffix(strings.TrimPrefix(expression, "CHECK ("), ")")
+			constraint.Args = append(constraint.Args, expression)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
 // GetColumnListing get a table columns structure
 func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]*dbal.Column, error) {
 	selectColumns := []string{
@@ -737,8 +1271,10 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 		`false AS "primary"`,
 		`CASE 
 		 	WHEN (COLUMN_DEFAULT ~ 'nextval\(.*_seq') THEN 'auto_increment'
+		 	WHEN IS_GENERATED = 'ALWAYS' THEN 'StoredGenerated'
 		 	ELSE ''
 		END as "extra"`,
+		"COALESCE(GENERATION_EXPRESSION, '') as \"generation_expression\"",
 		"pg_catalog.col_description(format('%s.%s',table_schema,table_name)::regclass::oid,ordinal_position)  as \"comment\"",
 	}
 	sql := fmt.Sprintf(`
@@ -757,6 +1293,11 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 		return nil, err
 	}
 
+	triggers, err := grammarSQL.getOnUpdateTriggers(dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cast the database data type to DBAL data type
 	for _, column := range columns {
 		typ, has := grammarSQL.FlipTypes[column.Type]
@@ -770,10 +1311,16 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 				column.Type = typ
 			}
 		}
+		_, column.OnUpdateCurrent = triggers[column.Name]
 
 		// user defined types
 		if column.Type == "USER-DEFINED" {
 
+			// the PostGIS geometry, the sub types are restored from the comment
+			if column.TypeName == "geometry" {
+				column.Type = "geometry"
+			}
+
 			// enum options
 			enumOptions := map[string][]string{}
 			if strings.Contains(column.TypeName, "enum__") {
@@ -798,3 +1345,14 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 	}
 	return columns, nil
 }
+
+// NormalizeColumn describe the column as Postgres creates and introspects it, the given column is not changed.
+// The unsigned columns are emulated with the check constraints and the virtual generated columns are stored.
+func (grammarSQL Postgres) NormalizeColumn(column *dbal.Column) *dbal.Column {
+	normalized := grammarSQL.FlipColumnType(column, "ipAddress", "year", "geometryCollection", "point", "multiPoint", "polygon", "multiPolygon")
+	normalized.IsUnsigned = false
+	if utils.StringVal(column.Extra) == "VirtualGenerated" {
+		normalized.Extra = utils.StringPtr("StoredGenerated")
+	}
+	return normalized
+}
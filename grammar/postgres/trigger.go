@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// the trigger function sets the column given by the argument to the current time, if the update does not change it.
+const onUpdateFunction = "xun_on_update_current"
+
+// postgres has no ON UPDATE CURRENT_TIMESTAMP, the columns are maintained by the triggers named {table}_{column}_on_update
+
+// SQLCreateOnUpdateTrigger return the statements of creating the trigger that sets the column to the current time when the row is updated
+func (grammarSQL Postgres) SQLCreateOnUpdateTrigger(table *dbal.Table, columnName string) []string {
+	function := fmt.Sprintf("%s.%s", grammarSQL.ID(table.SchemaName), onUpdateFunction)
+	return []string{
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$
+	BEGIN
+		IF (to_jsonb(NEW) -> TG_ARGV[0]) IS NOT DISTINCT FROM (to_jsonb(OLD) -> TG_ARGV[0]) THEN
+			NEW := jsonb_populate_record(NEW, jsonb_build_object(TG_ARGV[0], CURRENT_TIMESTAMP));
+		END IF;
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql`, function),
+		grammarSQL.SQLDropOnUpdateTrigger(table.TableName, columnName),
+		fmt.Sprintf("CREATE TRIGGER %s BEFORE UPDATE ON %s FOR EACH ROW EXECUTE PROCEDURE %s(%s)",
+			grammarSQL.ID(dbal.OnUpdateTriggerName(baseName(table.TableName), columnName)), grammarSQL.tableID(table.TableName),
+			function, grammarSQL.VAL(columnName)),
+	}
+}
+
+// SQLDropOnUpdateTrigger return the statement of dropping the on update trigger of the column
+func (grammarSQL Postgres) SQLDropOnUpdateTrigger(tableName string, columnName string) string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s",
+		grammarSQL.ID(dbal.OnUpdateTriggerName(baseName(tableName), columnName)), grammarSQL.tableID(tableName))
+}
+
+// getOnUpdateTriggers get the on update triggers of the table, the key is the column name and the value is the trigger name
+func (grammarSQL Postgres) getOnUpdateTriggers(dbName string, tableName string) (map[string]string, error) {
+	sql := fmt.Sprintf(`
+		SELECT t.tgname AS "name", split_part(encode(t.tgargs, 'escape'), '\000', 1) AS "column"
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_proc p ON p.oid = t.tgfoid
+		WHERE n.nspname = %s AND c.relname = %s AND p.proname = %s AND NOT t.tgisinternal`,
+		grammarSQL.VAL(dbName), grammarSQL.VAL(tableName), grammarSQL.VAL(onUpdateFunction),
+	)
+	defer log.Debug(sql)
+	rows := []struct {
+		Name   string `db:"name"`
+		Column string `db:"column"`
+	}{}
+	err := grammarSQL.DB.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := map[string]string{}
+	for _, row := range rows {
+		triggers[row.Column] = row.Name
+	}
+	return triggers, nil
+}
+
+// execOnUpdateTrigger create or drop the on update trigger of the column
+func (grammarSQL Postgres) execOnUpdateTrigger(table *dbal.Table, columnName string, onUpdate bool, stmts *[]string) error {
+	sqls := []string{grammarSQL.SQLDropOnUpdateTrigger(table.TableName, columnName)}
+	if onUpdate {
+		sqls = grammarSQL.SQLCreateOnUpdateTrigger(table, columnName)
+	}
+
+	for _, sql := range sqls {
+		*stmts = append(*stmts, sql)
+		err := grammarSQL.ExecSQL(table, sql)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameOnUpdateTriggers rename the on update triggers of the renamed table, the trigger names contain the table name
+func (grammarSQL Postgres) renameOnUpdateTriggers(old string, new string) error {
+	if grammarSQL.IsPretend() {
+		return nil
+	}
+
+	triggers, err := grammarSQL.getOnUpdateTriggers(grammarSQL.splitName(new))
+	if err != nil {
+		return err
+	}
+
+	stmts := []string{}
+	for column, name := range triggers {
+		if strings.HasPrefix(name, baseName(old)+"_") {
+			stmts = append(stmts, fmt.Sprintf("ALTER TRIGGER %s ON %s RENAME TO %s",
+				grammarSQL.ID(name), grammarSQL.tableID(new), grammarSQL.ID(dbal.OnUpdateTriggerName(baseName(new), column))))
+		}
+	}
+
+	for _, stmt := range stmts {
+		defer log.Debug(stmt)
+		err = grammarSQL.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
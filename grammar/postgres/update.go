@@ -70,6 +70,11 @@ func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]in
 		return grammarSQL.SQL.CompileUpdate(query, values)
 	}
 
+	// update "t1" set ... from "t2" where "t2"."t1_id" = "t1"."id" and ...
+	if query.Limit < 0 && grammarSQL.canCompileJoinsAsFrom(query) {
+		return grammarSQL.compileUpdateFrom(query, values)
+	}
+
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
@@ -77,9 +82,9 @@ func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]in
 	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, values, &offset)
 	bindings = append(bindings, columnsBindings...)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", alias)}
+	qualifier := grammarSQL.FromQualifier(query)
+	if qualifier != "" {
+		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", qualifier)}
 	} else {
 		query.Columns = []interface{}{"ctid"}
 	}
@@ -91,3 +96,63 @@ func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]in
 
 	return sql, bindings
 }
+
+// compileUpdateFrom Compile an update statement with joins into the "update ... from ... where" form.
+func (grammarSQL Postgres) compileUpdateFrom(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, values, &offset)
+	bindings = append(bindings, columnsBindings...)
+
+	from, wheres := grammarSQL.compileJoinsAsFrom(query, &offset)
+	bindings = append(bindings, query.GetBindings("join")...)
+	bindings = append(bindings, query.GetBindings("where")...)
+
+	return grammarSQL.JoinSegments("update", table, "set", columns, "from", from, wheres), bindings
+}
+
+// canCompileJoinsAsFrom Determine if the joins of the query could be moved to the "from" ( "using" ) clause.
+// Only inner and cross joins are equivalent to a table list filtered by the where clause.
+func (grammarSQL Postgres) canCompileJoinsAsFrom(query *dbal.Query) bool {
+	for _, join := range query.Joins {
+		if join.Type != "inner" && join.Type != "cross" {
+			return false
+		}
+		if join.Query == nil || len(join.Query.Joins) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compileJoinsAsFrom Compile the joins of the query into a table list and a where clause.
+// The join conditions are prepended to the where constraints of the query.
+func (grammarSQL Postgres) compileJoinsAsFrom(query *dbal.Query, offset *int) (string, string) {
+	tables := []string{}
+	conditions := []string{}
+	for _, join := range query.Joins {
+		table := grammarSQL.WrapTable(join.Name)
+		if join.SQL != nil && join.Alias != "" {
+			sql := grammarSQL.CompileSub(join.SQL, offset)
+			table = fmt.Sprintf("(%s) as %s", sql, join.Alias)
+		}
+		tables = append(tables, table)
+
+		on := grammarSQL.CompileWheres(join.Query, join.Query.Wheres, offset)
+		if on != "" {
+			conditions = append(conditions, fmt.Sprintf("(%s)", strings.TrimPrefix(on, "on ")))
+		}
+	}
+
+	wheres := grammarSQL.CompileWheres(query, query.Wheres, offset)
+	if wheres != "" {
+		conditions = append(conditions, fmt.Sprintf("(%s)", strings.TrimPrefix(wheres, "where ")))
+	}
+
+	if len(conditions) == 0 {
+		return strings.Join(tables, ", "), ""
+	}
+	return strings.Join(tables, ", "), fmt.Sprintf("where %s", strings.Join(conditions, " and "))
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// GetViews Get all of the views and the materialized views of the schema, the definitions are read from the catalog.
+func (grammarSQL Postgres) GetViews() ([]*dbal.View, error) {
+	stmt := fmt.Sprintf(`
+		SELECT schemaname AS schema_name, viewname AS view_name, definition AS view_definition, false AS materialized
+		FROM pg_catalog.pg_views WHERE schemaname = %s
+		UNION ALL
+		SELECT schemaname AS schema_name, matviewname AS view_name, definition AS view_definition, true AS materialized
+		FROM pg_catalog.pg_matviews WHERE schemaname = %s
+		ORDER BY view_name`,
+		grammarSQL.VAL(grammarSQL.GetSchema()),
+		grammarSQL.VAL(grammarSQL.GetSchema()),
+	)
+	defer log.Debug(stmt)
+	views := []*dbal.View{}
+	err := grammarSQL.DB.Select(&views, stmt)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		view.DBName = grammarSQL.GetDatabase()
+	}
+	return views, nil
+}
+
+// CreateView create a new view or materialized view using the select statement.
+// The materialized view can not be replaced in place, it is dropped first if replace is true.
+func (grammarSQL Postgres) CreateView(view *dbal.View, options ...dbal.ViewOption) error {
+	option := dbal.ViewOption{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	create := "CREATE VIEW"
+	if option.Materialized {
+		create = "CREATE MATERIALIZED VIEW"
+		if option.Replace {
+			err := grammarSQL.dropView(view.Name, true, true)
+			if err != nil {
+				return err
+			}
+		}
+	} else if option.Replace {
+		create = "CREATE OR REPLACE VIEW"
+	}
+
+	stmt := fmt.Sprintf("%s %s AS %s", create, grammarSQL.ID(view.Name), sql.Interpolate(view.SQL, view.Bindings, sql.QuoteString))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// DropView drop the view or the materialized view
+func (grammarSQL Postgres) DropView(name string) error {
+	materialized, err := grammarSQL.isMaterializedView(name)
+	if err != nil {
+		return err
+	}
+	return grammarSQL.dropView(name, materialized, false)
+}
+
+// DropViewIfExists drop the view or the materialized view if it exists
+func (grammarSQL Postgres) DropViewIfExists(name string) error {
+	materialized, err := grammarSQL.isMaterializedView(name)
+	if err != nil {
+		return err
+	}
+	return grammarSQL.dropView(name, materialized, true)
+}
+
+// RefreshMaterializedView refresh the materialized view
+func (grammarSQL Postgres) RefreshMaterializedView(name string) error {
+	stmt := fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", grammarSQL.ID(name))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// dropView drop the view or the materialized view
+func (grammarSQL Postgres) dropView(name string, materialized bool, ifExists bool) error {
+	stmt := "DROP VIEW"
+	if materialized {
+		stmt = "DROP MATERIALIZED VIEW"
+	}
+	if ifExists {
+		stmt = stmt + " IF EXISTS"
+	}
+	stmt = fmt.Sprintf("%s %s", stmt, grammarSQL.ID(name))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
+
+// isMaterializedView determine if the view is a materialized view
+func (grammarSQL Postgres) isMaterializedView(name string) (bool, error) {
+	stmt := fmt.Sprintf(
+		"SELECT COUNT(*) FROM pg_catalog.pg_matviews WHERE schemaname = %s AND matviewname = %s",
+		grammarSQL.VAL(grammarSQL.GetSchema()),
+		grammarSQL.VAL(name),
+	)
+	defer log.Debug(stmt)
+	var cnt int
+	err := grammarSQL.DB.Get(&cnt, stmt)
+	if err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
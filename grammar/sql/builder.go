@@ -22,17 +22,24 @@ func (grammarSQL SQL) SQLAddColumn(column *dbal.Column) string {
 	defaultValue := grammarSQL.GetDefaultValue(column)
 	comment := utils.GetIF(utils.StringVal(column.Comment) != "", fmt.Sprintf("COMMENT %s", quoter.VAL(column.Comment)), "").(string)
 	collation := utils.GetIF(utils.StringVal(column.Collation) != "", fmt.Sprintf("COLLATE %s", utils.StringVal(column.Collation)), "").(string)
-	extra := utils.GetIF(utils.StringVal(column.Extra) != "", "AUTO_INCREMENT", "")
+	extra := utils.GetIF(utils.StringVal(column.Extra) == "AutoIncrement", "AUTO_INCREMENT", "")
 
 	// default now() -> DEFAULT CURRENT_TIMESTAMP(%d) / DEFAULT CURRENT_TIMESTAMP
+	current := "CURRENT_TIMESTAMP"
+	if column.DateTimePrecision != nil {
+		current = fmt.Sprintf("CURRENT_TIMESTAMP(%d)", *column.DateTimePrecision)
+	}
 	if strings.Contains(column.Type, "timestamp") && (defaultValue != "" || (defaultValue == "" && column.Nullable == false)) {
 		if strings.Contains(strings.ToLower(defaultValue), "now()") || defaultValue == "" {
-			if column.DateTimePrecision != nil {
-				defaultValue = fmt.Sprintf("DEFAULT CURRENT_TIMESTAMP(%d)", *column.DateTimePrecision)
-			} else {
-				defaultValue = "DEFAULT CURRENT_TIMESTAMP"
-			}
+			defaultValue = "DEFAULT " + current
 		}
+	} else if strings.HasPrefix(column.Type, "dateTime") && strings.Contains(strings.ToLower(defaultValue), "now()") {
+		defaultValue = "DEFAULT " + current
+	}
+
+	// ON UPDATE CURRENT_TIMESTAMP, the precision should be the same as the column
+	if column.OnUpdateCurrent && (strings.Contains(column.Type, "timestamp") || strings.HasPrefix(column.Type, "dateTime")) {
+		defaultValue = strings.TrimSpace(defaultValue + " ON UPDATE " + current)
 	}
 
 	// JSON type
@@ -60,6 +67,21 @@ func (grammarSQL SQL) SQLAddColumn(column *dbal.Column) string {
 		typ = "SMALLINT"
 	}
 
+	// the character set should be placed after the type, it is skipped when the collation belongs to another one
+	charset := utils.StringVal(column.Charset)
+	if charset != "" && (utils.StringVal(column.Collation) == "" || strings.HasPrefix(utils.StringVal(column.Collation), charset+"_")) {
+		typ = fmt.Sprintf("%s CHARACTER SET %s", typ, charset)
+	}
+
+	// the generated columns have no default value, the collation should be placed before the expression
+	if column.IsGenerated() {
+		storage := utils.GetIF(utils.StringVal(column.Extra) == "StoredGenerated", "STORED", "VIRTUAL").(string)
+		generated := fmt.Sprintf("GENERATED ALWAYS AS (%s) %s", column.GenerationExpression, storage)
+		defaultValue, extra = "", ""
+		nullable = strings.TrimSpace(collation + " " + generated + " " + nullable)
+		collation = ""
+	}
+
 	sql := fmt.Sprintf(
 		"%s %s %s %s %s %s %s %s",
 		quoter.ID(column.Name), typ, unsigned, nullable, defaultValue, extra, comment, collation)
@@ -120,14 +142,21 @@ func (grammarSQL SQL) SQLAddIndex(index *dbal.Index) string {
 	}
 
 	// UNIQUE KEY `unionid` (`unionid`) COMMENT 'xxxx'
+	// the expressions are the functional key parts (MySQL 8.0.13+), the partial indexes are not supported
 	columns := []string{}
-	for _, column := range index.Columns {
-		if column.Type == "text" || column.Type == "mediumText" || column.Type == "longText" {
-			columns = append(columns, fmt.Sprintf("%s(%d)", quoter.ID(column.Name), maxKeyLength))
+	for _, part := range index.KeyParts() {
+		column := part.Column
+		order := utils.GetIF(part.Order != "", " "+part.Order, "").(string)
+		if column == nil {
+			columns = append(columns, fmt.Sprintf("(%s)%s", part.Expression, order))
+		} else if part.Length > 0 && index.Type != "spatial" { // the spatial key parts are reported with a length
+			columns = append(columns, fmt.Sprintf("%s(%d)%s", quoter.ID(column.Name), part.Length, order))
+		} else if column.Type == "text" || column.Type == "mediumText" || column.Type == "longText" {
+			columns = append(columns, fmt.Sprintf("%s(%d)%s", quoter.ID(column.Name), maxKeyLength, order))
 		} else if column.Type == "json" || column.Type == "jsonb" { // ignore json and jsonb
 			continue
 		} else {
-			columns = append(columns, quoter.ID(column.Name))
+			columns = append(columns, quoter.ID(column.Name)+order)
 		}
 	}
 
@@ -166,3 +195,55 @@ func (grammarSQL SQL) SQLAddPrimary(primary *dbal.Primary) string {
 
 	return sql
 }
+
+// SQLAddForeign return the add foreign key constraint sql for table create
+func (grammarSQL SQL) SQLAddForeign(foreign *dbal.ForeignKey) string {
+	quoter := grammarSQL.Quoter
+
+	// CONSTRAINT `user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+	columns := []string{}
+	for _, column := range foreign.Columns {
+		columns = append(columns, quoter.ID(column.Name))
+	}
+
+	references := []string{}
+	for _, name := range foreign.ReferencedColumns {
+		references = append(references, quoter.ID(name))
+	}
+
+	sql := fmt.Sprintf(
+		"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quoter.ID(foreign.Name), strings.Join(columns, ","),
+		quoter.WrapTable(foreign.ReferencedTable), strings.Join(references, ","))
+
+	if dbal.ForeignKeyActions[foreign.OnDelete] {
+		sql = sql + " ON DELETE " + foreign.OnDelete
+	}
+
+	if dbal.ForeignKeyActions[foreign.OnUpdate] {
+		sql = sql + " ON UPDATE " + foreign.OnUpdate
+	}
+
+	return sql
+}
+
+// SQLAddConstraint return the add unique or check constraint sql for table create
+func (grammarSQL SQL) SQLAddConstraint(constraint *dbal.Constraint) string {
+	quoter := grammarSQL.Quoter
+
+	// CONSTRAINT `price_check` CHECK (price >= 0)
+	if constraint.Type == "CHECK" {
+		expression := ""
+		if len(constraint.Args) > 0 {
+			expression = constraint.Args[0]
+		}
+		return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", quoter.ID(constraint.Name), expression)
+	}
+
+	// CONSTRAINT `sku_unique` UNIQUE (`sku`)
+	columns := []string{}
+	for _, column := range constraint.Columns {
+		columns = append(columns, quoter.ID(column.Name))
+	}
+	return fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", quoter.ID(constraint.Name), strings.Join(columns, ","))
+}
@@ -82,6 +82,11 @@ func (grammarSQL SQL) CompileExists(query *dbal.Query) string {
 	return fmt.Sprintf("select exists(%s) as %s", sql, grammarSQL.Wrap("exists"))
 }
 
+// CompileDistance Compile the spherical distance in meters between the column and the point (WKT text), MySQL 5.7.6+
+func (grammarSQL SQL) CompileDistance(column interface{}, point string) string {
+	return fmt.Sprintf("ST_Distance_Sphere(%s, ST_GeomFromText(%s))", grammarSQL.Wrap(column), grammarSQL.VAL(point))
+}
+
 // CompileUnionAggregate Compile a union aggregate query into SQL.
 func (grammarSQL SQL) CompileUnionAggregate(query *dbal.Query) string {
 	qb := &(*query)
@@ -514,6 +519,13 @@ func (grammarSQL SQL) WhereBetween(query *dbal.Query, where dbal.Where, bindingO
 	return fmt.Sprintf("%s %s %s and %s", column, between, min, max)
 }
 
+// WhereDistance Compile a "where distance within" clause.
+func (grammarSQL SQL) WhereDistance(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	*bindingOffset = *bindingOffset + where.Offset
+	meters := grammarSQL.Parameter(where.Values[0], *bindingOffset)
+	return fmt.Sprintf("%s <= %s", grammarSQL.CompileDistance(where.Column, where.Value.(string)), meters)
+}
+
 // WhereIn Compile a "where in" clause.
 func (grammarSQL SQL) WhereIn(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
 
@@ -558,3 +570,14 @@ func (grammarSQL SQL) RemoveLeadingBoolean(value string) string {
 func (grammarSQL SQL) Raw(value string) dbal.Expression {
 	return dbal.NewExpression(value)
 }
+
+// JoinSegments Concatenate the non-empty segments of a statement with a single space.
+func (grammarSQL SQL) JoinSegments(segments ...string) string {
+	parts := []string{}
+	for _, segment := range segments {
+		if segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// the names of the charsets and the collations, they are not quoted in the statements
+var reCharsetName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// GetDatabases get the database names of the server, the system databases are excluded.
+func (grammarSQL SQL) GetDatabases() ([]string, error) {
+	sql := "SELECT SCHEMA_NAME FROM information_schema.SCHEMATA " +
+		"WHERE SCHEMA_NAME NOT IN ('information_schema', 'mysql', 'performance_schema', 'sys') ORDER BY SCHEMA_NAME"
+	defer log.Debug(sql)
+	databases := []string{}
+	err := grammarSQL.DB.Select(&databases, sql)
+	if err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// DatabaseExists check if the database exists
+func (grammarSQL SQL) DatabaseExists(name string) (bool, error) {
+	sql := fmt.Sprintf("SELECT COUNT(*) FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = %s", grammarSQL.VAL(name))
+	defer log.Debug(sql)
+	var count int
+	err := grammarSQL.DB.Get(&count, sql)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateDatabase create a new database, the charset and the collation are the server defaults if they are empty.
+// The charset and the collation should be the names, e.g. utf8mb4, utf8mb4_general_ci.
+func (grammarSQL SQL) CreateDatabase(name string, charset string, collation string) error {
+	if charset != "" && !reCharsetName.MatchString(charset) {
+		return fmt.Errorf("the charset %s is invalid", charset)
+	}
+	if collation != "" && !reCharsetName.MatchString(collation) {
+		return fmt.Errorf("the collation %s is invalid", collation)
+	}
+
+	sql := fmt.Sprintf("CREATE DATABASE %s", grammarSQL.ID(name))
+	if charset != "" {
+		sql = fmt.Sprintf("%s CHARACTER SET %s", sql, charset)
+	}
+	if collation != "" {
+		sql = fmt.Sprintf("%s COLLATE %s", sql, collation)
+	}
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropDatabase drop the database and all of its tables
+func (grammarSQL SQL) DropDatabase(name string) error {
+	sql := fmt.Sprintf("DROP DATABASE %s", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
@@ -2,7 +2,6 @@ package sql
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/yaoapp/xun/dbal"
 )
@@ -13,27 +12,37 @@ func (grammarSQL SQL) CompileDelete(query *dbal.Query) (string, []interface{}) {
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
-	alias := ""
 
 	joins := ""
 	if len(query.Joins) > 0 {
 		joins = grammarSQL.CompileJoins(query, query.Joins, &offset)
 		bindings = append(bindings, query.GetBindings("join")...)
 		offset = len(bindings)
-		tableArr := strings.Split(table, " as ")
-		if len(tableArr) >= 1 {
-			alias = tableArr[1]
-		}
 	}
 
 	wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
 	bindings = append(bindings, query.GetBindings("where")...)
 
 	if len(query.Joins) > 0 {
-		return fmt.Sprintf("delete %s from %s %s %s", alias, table, joins, wheres), bindings
+		target := table
+		if qualifier := grammarSQL.FromQualifier(query); qualifier != "" {
+			target = grammarSQL.ID(qualifier)
+		}
+		return grammarSQL.JoinSegments("delete", target, "from", table, joins, wheres), bindings
 	}
 
-	return fmt.Sprintf("delete from %s %s", table, wheres), bindings
+	return grammarSQL.JoinSegments("delete from", table, wheres), bindings
+}
+
+// FromQualifier Get the alias of the table the query is targeting, or its full name when it has no alias.
+func (grammarSQL SQL) FromQualifier(query *dbal.Query) string {
+	if query.From.Alias != "" {
+		return query.From.Alias
+	}
+	if name, ok := query.From.Name.(dbal.Name); ok {
+		return name.Fullname()
+	}
+	return ""
 }
 
 // CompileTruncate Compile a truncate table statement into SQL.
@@ -0,0 +1,6 @@
+package sql
+
+// WrapError Classify the driver error. The base grammar does not know the driver, returns the error as it is.
+func (grammarSQL SQL) WrapError(err error) error {
+	return err
+}
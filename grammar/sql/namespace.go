@@ -0,0 +1,20 @@
+package sql
+
+import (
+	"fmt"
+)
+
+// GetSchemas the schemas are the databases of MySQL, see the Postgres grammar
+func (grammarSQL SQL) GetSchemas() ([]string, error) {
+	return nil, fmt.Errorf("the schema is not supported by %s", grammarSQL.Driver)
+}
+
+// CreateSchema the schemas are the databases of MySQL, see the Postgres grammar
+func (grammarSQL SQL) CreateSchema(name string) error {
+	return fmt.Errorf("the schema is not supported by %s", grammarSQL.Driver)
+}
+
+// DropSchema the schemas are the databases of MySQL, see the Postgres grammar
+func (grammarSQL SQL) DropSchema(name string, cascade bool) error {
+	return fmt.Errorf("the schema is not supported by %s", grammarSQL.Driver)
+}
@@ -0,0 +1,138 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// GetPartitions get the partitions of the table, ordered by the position
+func (grammarSQL SQL) GetPartitions(tableName string) ([]*dbal.Partition, error) {
+	sql := fmt.Sprintf(
+		"SELECT TABLE_SCHEMA AS `db_name`, TABLE_NAME AS `table_name`, PARTITION_NAME AS `partition_name`, "+
+			"PARTITION_ORDINAL_POSITION AS `partition_position`, PARTITION_METHOD AS `partition_method`, "+
+			"COALESCE(PARTITION_EXPRESSION, '') AS `partition_expression`, COALESCE(PARTITION_DESCRIPTION, '') AS `partition_description` "+
+			"FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = %s AND PARTITION_NAME IS NOT NULL "+
+			"ORDER BY PARTITION_ORDINAL_POSITION",
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	partitions := []*dbal.Partition{}
+	err := grammarSQL.DB.Select(&partitions, sql)
+	if err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
+
+// AddPartition add the partitions to the partitioned table, the RANGE partitions should be added after the last one.
+func (grammarSQL SQL) AddPartition(tableName string, partitions []*dbal.Partition) error {
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	method := ""
+	if !grammarSQL.IsPretend() {
+		current, err := grammarSQL.GetPartitions(tableName)
+		if err != nil {
+			return err
+		}
+		if len(current) == 0 {
+			return fmt.Errorf("the table %s is not partitioned", tableName)
+		}
+		method = current[0].Method
+	}
+
+	definitions := []string{}
+	for _, partition := range partitions {
+		definitions = append(definitions, grammarSQL.SQLPartition(method, partition))
+	}
+
+	sql := fmt.Sprintf("ALTER TABLE %s ADD PARTITION (%s)", grammarSQL.ID(tableName), strings.Join(definitions, ", "))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropPartition drop the partitions and the rows of them, the HASH partitions can not be dropped
+func (grammarSQL SQL) DropPartition(tableName string, names []string) error {
+	ids := []string{}
+	for _, name := range names {
+		ids = append(ids, grammarSQL.ID(name))
+	}
+	sql := fmt.Sprintf("ALTER TABLE %s DROP PARTITION %s", grammarSQL.ID(tableName), strings.Join(ids, ", "))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DetachPartition detach the partition as the table {table}_{partition}, MySQL has no DETACH PARTITION,
+// the rows are exchanged with a new table then the empty partition is dropped.
+func (grammarSQL SQL) DetachPartition(tableName string, name string) error {
+	table := grammarSQL.ID(tableName)
+	detached := grammarSQL.ID(fmt.Sprintf("%s_%s", tableName, name))
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE %s LIKE %s", detached, table),
+		fmt.Sprintf("ALTER TABLE %s REMOVE PARTITIONING", detached),
+		fmt.Sprintf("ALTER TABLE %s EXCHANGE PARTITION %s WITH TABLE %s", table, grammarSQL.ID(name), detached),
+		fmt.Sprintf("ALTER TABLE %s DROP PARTITION %s", table, grammarSQL.ID(name)),
+	}
+
+	defer log.Debug(strings.Join(stmts, ";\n"))
+	for _, stmt := range stmts {
+		err := grammarSQL.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SQLPartitionBy return the PARTITION BY clause of the table, the columns are partitioned by
+// RANGE COLUMNS, LIST COLUMNS and KEY, the expression is partitioned by RANGE, LIST and HASH.
+func (grammarSQL SQL) SQLPartitionBy(option *dbal.PartitionOption) string {
+	method := strings.ToUpper(option.Method)
+	key := option.Expression
+	if len(option.Columns) > 0 {
+		columns := []string{}
+		for _, column := range option.Columns {
+			columns = append(columns, grammarSQL.ID(column))
+		}
+		key = strings.Join(columns, ",")
+		if name, has := map[string]string{"RANGE": "RANGE COLUMNS", "LIST": "LIST COLUMNS", "HASH": "KEY"}[method]; has {
+			method = name
+		}
+	}
+
+	sql := fmt.Sprintf("PARTITION BY %s(%s)", method, key)
+	if len(option.Partitions) > 0 {
+		definitions := []string{}
+		for _, partition := range option.Partitions {
+			definitions = append(definitions, grammarSQL.SQLPartition(method, partition))
+		}
+		sql = fmt.Sprintf("%s (%s)", sql, strings.Join(definitions, ", "))
+	}
+	return sql
+}
+
+// SQLPartition return the partition definition, e.g. PARTITION `p202101` VALUES LESS THAN ('2021-02-01').
+// The bound is taken from the partition if the method is unknown.
+func (grammarSQL SQL) SQLPartition(method string, partition *dbal.Partition) string {
+	method = strings.ToUpper(method)
+	name := grammarSQL.ID(partition.Name)
+	switch {
+	case strings.HasPrefix(method, "LIST") || (method == "" && len(partition.Values) > 0):
+		values := []string{}
+		for _, value := range partition.Values {
+			values = append(values, Literal(value, QuoteBackslash))
+		}
+		return fmt.Sprintf("PARTITION %s VALUES IN (%s)", name, strings.Join(values, ","))
+
+	case strings.HasPrefix(method, "RANGE") || (method == "" && (partition.To != nil || partition.From != nil)):
+		if partition.To == nil {
+			return fmt.Sprintf("PARTITION %s VALUES LESS THAN (MAXVALUE)", name)
+		}
+		return fmt.Sprintf("PARTITION %s VALUES LESS THAN (%s)", name, Literal(partition.To, QuoteBackslash))
+	}
+	return fmt.Sprintf("PARTITION %s", name)
+}
@@ -100,11 +100,9 @@ func (quoter *Quoter) WrapAliasedValue(value string) string {
 	if value == "*" {
 		return "*"
 	}
-	if strings.Contains(value, ".") {
-		arrs := strings.Split(value, ".")
-		table := arrs[0]
-		name := quoter.WrapAliasedValue(arrs[1])
-		return fmt.Sprintf("%s.%s", quoter.ID(table), name)
+	if i := strings.LastIndex(value, "."); i > 0 {
+		name := quoter.WrapAliasedValue(value[i+1:])
+		return fmt.Sprintf("%s.%s", quoter.wrapQualified(value[:i]), name)
 	}
 
 	name := dbal.NewName(value)
@@ -114,6 +112,15 @@ func (quoter *Quoter) WrapAliasedValue(value string) string {
 	return fmt.Sprintf("%s", quoter.ID(name.Fullname()))
 }
 
+// wrapQualified Wrap the schema-qualified name in keyword identifiers, e.g. reporting.daily
+func (quoter *Quoter) wrapQualified(value string) string {
+	segments := strings.Split(value, ".")
+	for i, segment := range segments {
+		segments[i] = quoter.ID(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
 // WrapTable Wrap a table in keyword identifiers.
 func (quoter *Quoter) WrapTable(value interface{}) string {
 	switch value.(type) {
@@ -122,13 +129,13 @@ func (quoter *Quoter) WrapTable(value interface{}) string {
 	case dbal.Name:
 		col := value.(dbal.Name)
 		if col.As() != "" {
-			return fmt.Sprintf("%s as %s", quoter.ID(col.Fullname()), quoter.ID(col.As()))
+			return fmt.Sprintf("%s as %s", quoter.wrapQualified(col.Fullname()), quoter.ID(col.As()))
 		}
-		return quoter.ID(value.(dbal.Name).Fullname())
+		return quoter.wrapQualified(value.(dbal.Name).Fullname())
 	case dbal.From:
 		return quoter.WrapTable(value.(dbal.From).Name)
 	case string:
-		return quoter.ID(dbal.NewName(value.(string)).Fullname())
+		return quoter.wrapQualified(dbal.NewName(value.(string)).Fullname())
 	default:
 		return fmt.Sprintf("%v", value)
 	}
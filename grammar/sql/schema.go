@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -12,6 +13,10 @@ import (
 	"github.com/yaoapp/xun/utils"
 )
 
+// the table options of SHOW CREATE TABLE
+var reTableAutoIncrement = regexp.MustCompile(`AUTO_INCREMENT=(\d+)`)
+var reTableTablespace = regexp.MustCompile("TABLESPACE `([^`]+)`")
+
 // GetDatabase get the database name of the current connection
 func (grammarSQL SQL) GetDatabase() string {
 	return grammarSQL.DatabaseName
@@ -72,7 +77,7 @@ func (grammarSQL SQL) GetVersion() (*dbal.Version, error) {
 
 // GetTables Get all of the table names for the database.
 func (grammarSQL SQL) GetTables() ([]string, error) {
-	sql := "SHOW TABLES"
+	sql := "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name"
 	defer log.Debug(sql)
 	tables := []string{}
 	err := grammarSQL.DB.Select(&tables, sql)
@@ -84,7 +89,7 @@ func (grammarSQL SQL) GetTables() ([]string, error) {
 
 // TableExists check if the table exists
 func (grammarSQL SQL) TableExists(name string) (bool, error) {
-	sql := fmt.Sprintf("SHOW TABLES like %s", grammarSQL.VAL(name))
+	sql := fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' AND table_name = %s", grammarSQL.VAL(name))
 	defer log.Debug(sql)
 	rows := []string{}
 	err := grammarSQL.DB.Select(&rows, sql)
@@ -111,6 +116,11 @@ func (grammarSQL SQL) GetTable(name string) (*dbal.Table, error) {
 
 	table := dbal.NewTable(name, grammarSQL.GetSchema(), grammarSQL.GetDatabase())
 
+	err = grammarSQL.getTableOption(table)
+	if err != nil {
+		return nil, fmt.Errorf("the table option reading failed %s", err)
+	}
+
 	columns, err := grammarSQL.GetColumnListing(table.SchemaName, table.TableName)
 	if err != nil {
 		return nil, fmt.Errorf("the column listing failed %s", err)
@@ -121,6 +131,16 @@ func (grammarSQL SQL) GetTable(name string) (*dbal.Table, error) {
 		return nil, fmt.Errorf("the index listing failed %s", err)
 	}
 
+	foreignKeys, err := grammarSQL.GetForeignKeyListing(table.SchemaName, table.TableName)
+	if err != nil {
+		return nil, fmt.Errorf("the foreign key listing failed %s", err)
+	}
+
+	constraints, err := grammarSQL.GetTableConstraintListing(table.SchemaName, table.TableName)
+	if err != nil {
+		return nil, fmt.Errorf("the constraint listing failed %s", err)
+	}
+
 	primaryKeyName := ""
 
 	// attaching columns
@@ -129,21 +149,32 @@ func (grammarSQL SQL) GetTable(name string) (*dbal.Table, error) {
 		table.PushColumn(column)
 	}
 
-	// attaching indexes
+	// attaching indexes, the expression key parts have no column
 	for i := range indexes {
 		idx := indexes[i]
-		if !table.HasColumn(idx.ColumnName) {
-			return nil, fmt.Errorf("the column does not exists %s", idx.ColumnName)
+		var column *dbal.Column
+		if idx.Expression == "" {
+			if !table.HasColumn(idx.ColumnName) {
+				return nil, fmt.Errorf("the column does not exists %s", idx.ColumnName)
+			}
+			column = table.ColumnMap[idx.ColumnName]
 		}
-		column := table.ColumnMap[idx.ColumnName]
 		if !table.HasIndex(idx.Name) {
 			index := *idx
 			index.Columns = []*dbal.Column{}
-			column.Indexes = append(column.Indexes, &index)
+			if column != nil {
+				column.Indexes = append(column.Indexes, &index)
+			}
 			table.PushIndex(&index)
 		}
 		index := table.IndexMap[idx.Name]
-		index.AddColumn(column)
+		index.AddPart(&dbal.IndexPart{
+			Column:     column,
+			Expression: idx.Expression,
+			Order:      idx.Order,
+			Length:     idx.SubPart,
+			OpClass:    idx.OpClass,
+		})
 		if index.Type == "primary" {
 			primaryKeyName = idx.Name
 		}
@@ -166,17 +197,85 @@ func (grammarSQL SQL) GetTable(name string) (*dbal.Table, error) {
 		}
 	}
 
+	// attaching constraints
+	for _, c := range constraints {
+		if !table.HasConstraint(c.Name) {
+			constraint := *c
+			constraint.Table = table
+			constraint.Columns = []*dbal.Column{}
+			table.PushConstraint(&constraint)
+		}
+		if column, has := table.ColumnMap[c.ColumnName]; has {
+			table.ConstraintMap[c.Name].AddColumn(column)
+		}
+	}
+
+	// attaching foreign keys
+	for _, fk := range foreignKeys {
+		if !table.HasColumn(fk.ColumnName) {
+			return nil, fmt.Errorf("the column %s does not exists", fk.ColumnName)
+		}
+		if !table.HasForeignKey(fk.Name) {
+			foreign := *fk
+			foreign.Table = table
+			foreign.Columns = []*dbal.Column{}
+			foreign.ReferencedColumns = []string{}
+			table.PushForeignKey(&foreign)
+		}
+		foreign := table.ForeignKeyMap[fk.Name]
+		foreign.AddColumn(table.ColumnMap[fk.ColumnName], fk.ReferencedColumnName)
+	}
+
 	return table, nil
 }
 
+// getTableOption read the table options (comment, engine, charset ...) from the catalog.
+// The auto-increment value and the tablespace are parsed from SHOW CREATE TABLE, the statistics of the catalog may be cached (MySQL 8.0).
+func (grammarSQL SQL) getTableOption(table *dbal.Table) error {
+	sql := fmt.Sprintf(
+		"SELECT IFNULL(t.`TABLE_COMMENT`, '') AS `table_comment`, IFNULL(t.`ENGINE`, '') AS `engine`, "+
+			"IFNULL(t.`TABLE_COLLATION`, '') AS `collation`, IFNULL(c.`CHARACTER_SET_NAME`, '') AS `charset`, "+
+			"UPPER(IFNULL(t.`ROW_FORMAT`, '')) AS `row_format`, IFNULL(t.`CREATE_OPTIONS`, '') AS `create_options` "+
+			"FROM information_schema.tables AS t "+
+			"LEFT JOIN information_schema.collation_character_set_applicability AS c ON c.`COLLATION_NAME` = t.`TABLE_COLLATION` "+
+			"WHERE t.`TABLE_SCHEMA` = DATABASE() AND t.`TABLE_NAME` = %s",
+		grammarSQL.VAL(table.TableName),
+	)
+	defer log.Debug(sql)
+	err := grammarSQL.DB.Get(table, sql)
+	if err != nil {
+		return err
+	}
+
+	sql = fmt.Sprintf("SHOW CREATE TABLE %s", grammarSQL.ID(table.TableName))
+	defer log.Debug(sql)
+	var name, create string
+	err = grammarSQL.DB.QueryRowx(sql).Scan(&name, &create)
+	if err != nil {
+		return err
+	}
+
+	// the last line is the table options: ) ENGINE=InnoDB AUTO_INCREMENT=1000 ... /*!50100 TABLESPACE `ts1` */
+	options := create[strings.LastIndex(create, "\n)")+1:]
+	if match := reTableAutoIncrement.FindStringSubmatch(options); match != nil {
+		table.AutoIncrement, _ = strconv.Atoi(match[1])
+	}
+	if match := reTableTablespace.FindStringSubmatch(options); match != nil {
+		table.Tablespace = match[1]
+	}
+	return nil
+}
+
 // GetIndexListing get a table indexes structure
 func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.Index, error) {
 	selectColumns := []string{
 		"`TABLE_SCHEMA` AS `db_name`",
 		"`TABLE_NAME` AS `table_name`",
 		"`INDEX_NAME` AS `index_name`",
-		"`COLUMN_NAME` AS `column_name`",
+		"IFNULL(`COLUMN_NAME`, '') AS `column_name`",
 		"`COLLATION` AS `collation`",
+		"IFNULL(`SUB_PART`, 0) AS `sub_part`",
+		"CASE WHEN `COLLATION` = 'D' THEN 'DESC' ELSE '' END AS `sort_order`",
 		`CASE
 			WHEN NULLABLE = 'YES' THEN true
 			WHEN NULLABLE = "NO" THEN false
@@ -192,6 +291,14 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 		"`SEQ_IN_INDEX` AS `seq_in_index`",
 		"`INDEX_COMMENT` AS `index_comment`",
 	}
+
+	// the functional key parts are supported since MySQL 8.0.13 (not MariaDB)
+	mysql8_0_13, _ := semver.Make("8.0.13")
+	version, err := grammarSQL.GetVersion()
+	if err == nil && version.GE(mysql8_0_13) && !strings.Contains(version.String(), "MariaDB") {
+		selectColumns = append(selectColumns, "IFNULL(`EXPRESSION`, '') AS `expression`")
+	}
+
 	sql := fmt.Sprintf(`
 			SELECT %s
 			FROM INFORMATION_SCHEMA.STATISTICS
@@ -204,7 +311,7 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err = grammarSQL.DB.Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +322,8 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 			index.Type = "primary"
 		} else if index.Unique {
 			index.Type = "unique"
+		} else if index.IndexType == "SPATIAL" {
+			index.Type = "spatial"
 		} else {
 			index.Type = "index"
 		}
@@ -222,6 +331,103 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 	return indexes, nil
 }
 
+// GetForeignKeyListing get a table foreign keys structure
+func (grammarSQL SQL) GetForeignKeyListing(dbName string, tableName string) ([]*dbal.ForeignKey, error) {
+	selectColumns := []string{
+		"k.`CONSTRAINT_SCHEMA` AS `db_name`",
+		"k.`TABLE_NAME` AS `table_name`",
+		"k.`CONSTRAINT_NAME` AS `constraint_name`",
+		"k.`COLUMN_NAME` AS `column_name`",
+		"k.`ORDINAL_POSITION` AS `seq_in_key`",
+		"k.`REFERENCED_TABLE_NAME` AS `referenced_table_name`",
+		"k.`REFERENCED_COLUMN_NAME` AS `referenced_column_name`",
+		"r.`DELETE_RULE` AS `on_delete`",
+		"r.`UPDATE_RULE` AS `on_update`",
+	}
+	sql := fmt.Sprintf(`
+			SELECT %s
+			FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+			JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS AS r
+				ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+				AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+				AND r.TABLE_NAME = k.TABLE_NAME
+			WHERE k.TABLE_SCHEMA = %s AND k.TABLE_NAME = %s
+				AND k.REFERENCED_TABLE_NAME IS NOT NULL
+			ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION;
+		`,
+		strings.Join(selectColumns, ","),
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	foreignKeys := []*dbal.ForeignKey{}
+	err := grammarSQL.DB.Select(&foreignKeys, sql)
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys, nil
+}
+
+// GetTableConstraintListing get a table check constraints structure.
+// The unique constraints of MySQL are the unique indexes, they are listed by GetIndexListing only.
+func (grammarSQL SQL) GetTableConstraintListing(dbName string, tableName string) ([]*dbal.Constraint, error) {
+	sql := fmt.Sprintf(`
+			SELECT
+				tc.CONSTRAINT_NAME AS `+"`constraint_name`"+`,
+				tc.CONSTRAINT_TYPE AS `+"`constraint_type`"+`
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
+			WHERE tc.TABLE_SCHEMA = %s AND tc.TABLE_NAME = %s
+				AND tc.CONSTRAINT_TYPE = 'CHECK'
+			ORDER BY tc.CONSTRAINT_NAME;
+		`,
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	rows := []struct {
+		Name string `db:"constraint_name"`
+		Type string `db:"constraint_type"`
+	}{}
+	err := grammarSQL.DB.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := []*dbal.Constraint{}
+	for _, row := range rows {
+		constraint := dbal.NewConstraint(dbName, tableName, "")
+		constraint.Name = row.Name
+		constraint.Type = row.Type
+		constraints = append(constraints, constraint)
+	}
+
+	// the check constraints are supported since MySQL 8.0.16
+	if len(constraints) > 0 {
+		sql = fmt.Sprintf(
+			"SELECT CONSTRAINT_NAME, CHECK_CLAUSE FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS WHERE CONSTRAINT_SCHEMA = %s",
+			grammarSQL.VAL(dbName),
+		)
+		defer log.Debug(sql)
+		clauses := []struct {
+			Name   string `db:"CONSTRAINT_NAME"`
+			Clause string `db:"CHECK_CLAUSE"`
+		}{}
+		err = grammarSQL.DB.Select(&clauses, sql)
+		if err != nil {
+			return nil, err
+		}
+		expressions := map[string]string{}
+		for _, clause := range clauses {
+			expressions[clause.Name] = clause.Clause
+		}
+		for _, constraint := range constraints {
+			constraint.Args = append(constraint.Args, expressions[constraint.Name])
+		}
+	}
+
+	return constraints, nil
+}
+
 // GetColumnListing get a table columns structure
 func (grammarSQL SQL) GetColumnListing(dbName string, tableName string) ([]*dbal.Column, error) {
 	selectColumns := []string{
@@ -256,6 +462,14 @@ func (grammarSQL SQL) GetColumnListing(dbName string, tableName string) ([]*dbal
 		"EXTRA as `extra`",
 		"COLUMN_COMMENT as `comment`",
 	}
+
+	// the generated columns are supported since MySQL 5.7.6
+	mysql5_7_6, _ := semver.Make("5.7.6")
+	version, err := grammarSQL.GetVersion()
+	if err == nil && version.GE(mysql5_7_6) {
+		selectColumns = append(selectColumns, "GENERATION_EXPRESSION as `generation_expression`")
+	}
+
 	sql := fmt.Sprintf(`
 			SELECT %s
 			FROM INFORMATION_SCHEMA.COLUMNS
@@ -267,7 +481,7 @@ func (grammarSQL SQL) GetColumnListing(dbName string, tableName string) ([]*dbal
 	)
 	defer log.Debug(sql)
 	columns := []*dbal.Column{}
-	err := grammarSQL.DB.Select(&columns, sql)
+	err = grammarSQL.DB.Select(&columns, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -295,8 +509,20 @@ func (grammarSQL SQL) GetColumnListing(dbName string, tableName string) ([]*dbal
 			}
 		}
 
-		if utils.StringVal(column.Extra) == "auto_increment" {
+		// DEFAULT_GENERATED on update CURRENT_TIMESTAMP(6)
+		extra := strings.ToUpper(utils.StringVal(column.Extra))
+		if strings.Contains(extra, "ON UPDATE CURRENT_TIMESTAMP") {
+			column.OnUpdateCurrent = true
+			column.Extra = nil
+		}
+
+		switch extra {
+		case "AUTO_INCREMENT":
 			column.Extra = utils.StringPtr("AutoIncrement")
+		case "STORED GENERATED":
+			column.Extra = utils.StringPtr("StoredGenerated")
+		case "VIRTUAL GENERATED":
+			column.Extra = utils.StringPtr("VirtualGenerated")
 		}
 	}
 	return columns, nil
@@ -321,6 +547,8 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 	var primary *dbal.Primary = nil
 	columns := []*dbal.Column{}
 	indexes := []*dbal.Index{}
+	foreigns := []*dbal.ForeignKey{}
+	constraints := []*dbal.Constraint{}
 	cbCommands := []*dbal.Command{}
 
 	// Commands
@@ -333,6 +561,7 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 	//    DropIndex( name string) for  dropping a index
 	//    RenameIndex(old string,new string)  for renaming a index
 	//    CreatePrimary for creating the primary key
+	//    CreateForeign(foreign *ForeignKey) for creating a foreign key
 	for _, command := range table.Commands {
 		switch command.Name {
 		case "AddColumn":
@@ -347,6 +576,14 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 			primary = command.Params[0].(*dbal.Primary)
 			cbCommands = append(cbCommands, command)
 			break
+		case "CreateForeign":
+			foreigns = append(foreigns, command.Params[0].(*dbal.ForeignKey))
+			cbCommands = append(cbCommands, command)
+			break
+		case "CreateConstraint":
+			constraints = append(constraints, command.Params[0].(*dbal.Constraint))
+			cbCommands = append(cbCommands, command)
+			break
 		}
 
 	}
@@ -373,6 +610,16 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 		}
 	}
 
+	// foreign keys
+	for _, foreign := range foreigns {
+		stmts = append(stmts, grammarSQL.SQLAddForeign(foreign))
+	}
+
+	// Constraints
+	for _, constraint := range constraints {
+		stmts = append(stmts, grammarSQL.SQLAddConstraint(constraint))
+	}
+
 	engine := utils.GetIF(table.Engine != "", "ENGINE "+table.Engine, "")
 	// Temporary table in specific engine
 	if len(options) > 0 && options[0].Temporary && options[0].Engine != "" {
@@ -381,15 +628,32 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 
 	charset := utils.GetIF(table.Charset != "", "DEFAULT CHARSET "+table.Charset, "")
 	collation := utils.GetIF(table.Collation != "", "COLLATE="+table.Collation, "")
+	rowFormat := utils.GetIF(table.RowFormat != "", table.RowFormat, "DYNAMIC")
 
 	sql = sql + strings.Join(stmts, ",\n")
 	sql = sql + fmt.Sprintf(
-		"\n) %s %s %s ROW_FORMAT=DYNAMIC",
-		engine, charset, collation,
+		"\n) %s %s %s ROW_FORMAT=%s",
+		engine, charset, collation, rowFormat,
 	)
 
+	// AUTO_INCREMENT=1000 COMMENT='...' TABLESPACE `...`
+	if table.AutoIncrement > 0 {
+		sql = sql + " " + grammarSQL.SQLTableOption("autoIncrement", table.AutoIncrement)
+	}
+	if table.Comment != "" {
+		sql = sql + " " + grammarSQL.SQLTableOption("comment", table.Comment)
+	}
+	if table.Tablespace != "" {
+		sql = sql + " " + grammarSQL.SQLTableOption("tablespace", table.Tablespace)
+	}
+
+	// PARTITION BY RANGE COLUMNS(`created_at`) (PARTITION `p202101` VALUES LESS THAN ('2021-02-01'), ...)
+	if len(options) > 0 && options[0].Partition != nil {
+		sql = sql + " " + grammarSQL.SQLPartitionBy(options[0].Partition)
+	}
+
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 
 	// Callback
 	for _, cmd := range cbCommands {
@@ -402,7 +666,7 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 func (grammarSQL SQL) DropTable(name string) error {
 	sql := fmt.Sprintf("DROP TABLE %s", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	return err
 }
 
@@ -410,7 +674,7 @@ func (grammarSQL SQL) DropTable(name string) error {
 func (grammarSQL SQL) DropTableIfExists(name string) error {
 	sql := fmt.Sprintf("DROP TABLE IF EXISTS %s", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	return err
 }
 
@@ -418,7 +682,7 @@ func (grammarSQL SQL) DropTableIfExists(name string) error {
 func (grammarSQL SQL) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	return err
 }
 
@@ -438,6 +702,11 @@ func (grammarSQL SQL) AlterTable(table *dbal.Table) error {
 	//    CreateIndex(index *Index) for creating a index
 	//    DropIndex(name string) for  dropping a index
 	//    RenameIndex(old string,new string)  for renaming a index
+	//    CreateForeign(foreign *ForeignKey) for creating a foreign key
+	//    DropForeign(name string) for dropping a foreign key
+	//    CreateConstraint(constraint *Constraint) for creating a unique or check constraint
+	//    DropConstraint(name string) for dropping a unique or check constraint
+	//    SetTableOption(name string, value interface{}) for changing a table option
 	for _, command := range table.Commands {
 		switch command.Name {
 		case "AddColumn":
@@ -467,6 +736,21 @@ func (grammarSQL SQL) AlterTable(table *dbal.Table) error {
 		case "DropPrimary":
 			grammarSQL.alterTableDropPrimary(table, command, sql, &stmts, &errs)
 			break
+		case "CreateForeign":
+			grammarSQL.alterTableCreateForeign(table, command, sql, &stmts, &errs)
+			break
+		case "DropForeign":
+			grammarSQL.alterTableDropForeign(table, command, sql, &stmts, &errs)
+			break
+		case "CreateConstraint":
+			grammarSQL.alterTableCreateConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "DropConstraint":
+			grammarSQL.alterTableDropConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "SetTableOption":
+			grammarSQL.alterTableSetOption(table, command, sql, &stmts, &errs)
+			break
 		}
 	}
 
@@ -486,7 +770,7 @@ func (grammarSQL SQL) AlterTable(table *dbal.Table) error {
 
 func (grammarSQL SQL) alterTableAddColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	column := command.Params[0].(*dbal.Column)
-	stmt := "ADD " + grammarSQL.SQLAddColumn(column)
+	stmt := "ADD " + grammarSQL.SQLAddColumn(column) + grammarSQL.sqlColumnPosition(column)
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
 	if err != nil {
@@ -497,7 +781,7 @@ func (grammarSQL SQL) alterTableAddColumn(table *dbal.Table, command *dbal.Comma
 
 func (grammarSQL SQL) alterTableChangeColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	column := command.Params[0].(*dbal.Column)
-	stmt := "MODIFY " + grammarSQL.SQLAddColumn(column)
+	stmt := "MODIFY " + grammarSQL.SQLAddColumn(column) + grammarSQL.sqlColumnPosition(column)
 	*stmts = append(*stmts, sql+stmt)
 	err := grammarSQL.ExecSQL(table, sql+stmt)
 	if err != nil {
@@ -506,6 +790,16 @@ func (grammarSQL SQL) alterTableChangeColumn(table *dbal.Table, command *dbal.Co
 	command.Callback(err)
 }
 
+// sqlColumnPosition return the FIRST / AFTER clause of the column
+func (grammarSQL SQL) sqlColumnPosition(column *dbal.Column) string {
+	if column.First {
+		return " FIRST"
+	} else if column.After != "" {
+		return " AFTER " + grammarSQL.ID(column.After)
+	}
+	return ""
+}
+
 func (grammarSQL SQL) alterTableRenameColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
 	old := command.Params[0].(string)
 	new := command.Params[1].(string)
@@ -618,7 +912,7 @@ func (grammarSQL SQL) alterTableDropPrimary(table *dbal.Table, command *dbal.Com
 		// remove AutoIncrement
 		if utils.StringVal(column.Extra) == "AutoIncrement" {
 			column.Extra = nil
-			stmt := "MODIFY " + grammarSQL.SQLAddColumn(column)
+			stmt := "MODIFY " + grammarSQL.SQLAddColumn(column) + grammarSQL.sqlColumnPosition(column)
 			*stmts = append(*stmts, sql+stmt)
 			err := grammarSQL.ExecSQL(table, sql+stmt)
 			if err != nil {
@@ -635,10 +929,117 @@ func (grammarSQL SQL) alterTableDropPrimary(table *dbal.Table, command *dbal.Com
 	command.Callback(err)
 }
 
+func (grammarSQL SQL) alterTableCreateForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	foreign := command.Params[0].(*dbal.ForeignKey)
+	stmt := "ADD " + grammarSQL.SQLAddForeign(foreign)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQL) alterTableDropForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	stmt := fmt.Sprintf("DROP FOREIGN KEY %s", grammarSQL.ID(name))
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQL) alterTableCreateConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	constraint := command.Params[0].(*dbal.Constraint)
+	stmt := "ADD " + grammarSQL.SQLAddConstraint(constraint)
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQL) alterTableDropConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	constraint := table.GetConstraint(name)
+	index := table.GetIndex(name)
+	if constraint == nil && (index == nil || index.Type != "unique") {
+		err := fmt.Errorf("DropConstraint: The constraint %s not found", name)
+		*errs = append(*errs, err)
+		command.Callback(err)
+		return
+	}
+
+	// the unique constraint is listed as an unique index on MySQL
+	stmt := fmt.Sprintf("DROP INDEX %s", grammarSQL.ID(name))
+	if constraint != nil && constraint.Type == "CHECK" {
+		stmt = fmt.Sprintf("DROP CHECK %s", grammarSQL.ID(name))
+	}
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQL) alterTableSetOption(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	stmt := grammarSQL.SQLTableOption(command.Params[0].(string), command.Params[1])
+	if stmt == "" {
+		command.Callback(nil)
+		return
+	}
+	*stmts = append(*stmts, sql+stmt)
+	err := grammarSQL.ExecSQL(table, sql+stmt)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("SetTableOption: %s", err))
+	}
+	command.Callback(err)
+}
+
+// SQLTableOption return the table option clause, e.g. COMMENT='...', returns "" if the option is not supported
+func (grammarSQL SQL) SQLTableOption(name string, value interface{}) string {
+	switch name {
+	case "comment":
+		return fmt.Sprintf("COMMENT=%s", grammarSQL.VAL(value))
+	case "engine":
+		return fmt.Sprintf("ENGINE=%s", value)
+	case "charset":
+		return fmt.Sprintf("DEFAULT CHARSET=%s", value)
+	case "collation":
+		return fmt.Sprintf("COLLATE=%s", value)
+	case "rowFormat":
+		return fmt.Sprintf("ROW_FORMAT=%s", value)
+	case "autoIncrement":
+		return fmt.Sprintf("AUTO_INCREMENT=%d", value)
+	case "tablespace":
+		return fmt.Sprintf("TABLESPACE %s", grammarSQL.ID(fmt.Sprintf("%v", value)))
+	}
+	return ""
+}
+
+// Exec execute the schema statement, the statement is collected but not executed in the pretend mode
+func (grammarSQL SQL) Exec(sql string) error {
+	if grammarSQL.IsPretend() {
+		grammarSQL.Option.Pretend.Add(sql)
+		return nil
+	}
+	_, err := grammarSQL.DB.Exec(sql)
+	return err
+}
+
+// IsPretend determine if the grammar is in the pretend (dry-run) mode
+func (grammarSQL SQL) IsPretend() bool {
+	return grammarSQL.Option != nil && grammarSQL.Option.Pretend != nil
+}
+
 // ExecSQL execute sql then update table structure
 func (grammarSQL SQL) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
-	if err != nil {
+	err := grammarSQL.Exec(sql)
+	if err != nil || grammarSQL.IsPretend() {
 		return err
 	}
 	// update table structure
@@ -670,3 +1071,35 @@ func (grammarSQL SQL) GetTypeFromComment(comment *string) string {
 
 	return ""
 }
+
+// NormalizeColumn describe the column as MySQL creates and introspects it, the given column is not changed.
+// The booleans are TINYINT(1) columns, the types written with a type hint (T:json|) are restored from the comments.
+func (grammarSQL SQL) NormalizeColumn(column *dbal.Column) *dbal.Column {
+	normalized := grammarSQL.FlipColumnType(column, "json", "jsonb", "uuid", "ipAddress", "macAddress", "year")
+	if column.Type == "boolean" {
+		normalized.Type = "tinyInteger"
+		if value, ok := column.Default.(bool); ok {
+			normalized.Default = utils.GetIF(value, 1, 0)
+		}
+	}
+	return normalized
+}
+
+// FlipColumnType copy the column with the type introspected from the type it is created with, e.g. dateTimeTz => dateTime.
+// The hinted types are restored from the comments, they are kept.
+func (grammarSQL SQL) FlipColumnType(column *dbal.Column, hinted ...string) *dbal.Column {
+	normalized := *column
+	if utils.StringHave(hinted, column.Type) {
+		return &normalized
+	}
+
+	// TIMESTAMP(%d) WITHOUT TIME ZONE => TIMESTAMP WITHOUT TIME ZONE
+	typ, has := grammarSQL.Types[column.Type]
+	if has {
+		typ = strings.Join(strings.Fields(strings.ReplaceAll(typ, "(%d)", "")), " ")
+		if flip, has := grammarSQL.FlipTypes[typ]; has {
+			normalized.Type = flip
+		}
+	}
+	return &normalized
+}
@@ -35,8 +35,9 @@ func NewSQL(quoter dbal.Quoter, opts ...Option) SQL {
 		Mode:   "production",
 		Quoter: quoter,
 		IndexTypes: map[string]string{
-			"unique": "UNIQUE KEY",
-			"index":  "KEY",
+			"unique":  "UNIQUE KEY",
+			"index":   "KEY",
+			"spatial": "SPATIAL KEY",
 		},
 		FlipTypes: map[string]string{},
 		Types: map[string]string{
@@ -68,6 +69,13 @@ func NewSQL(quoter dbal.Quoter, opts ...Option) SQL {
 			"ipAddress":    "IPADDRESS",
 			"macAddress":   "MACADDRESS",
 			"year":         "YEAR",
+
+			"geometry":           "GEOMETRY",
+			"geometryCollection": "GEOMETRYCOLLECTION",
+			"point":              "POINT",
+			"multiPoint":         "MULTIPOINT",
+			"polygon":            "POLYGON",
+			"multiPolygon":       "MULTIPOLYGON",
 			// "mediumInteger": "mediumInteger",
 		},
 	}
@@ -100,7 +108,8 @@ func New(dsn string) dbal.Grammar {
 // setup the method will be executed when db server was connected
 func (grammarSQL *SQL) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Option) error {
 
-	if db == nil {
+	// the grammar without a database only collects the statements in the pretend mode, e.g. dumping to another driver
+	if db == nil && (option == nil || option.Pretend == nil) {
 		return fmt.Errorf("db is nil")
 	}
 
@@ -111,6 +120,10 @@ func (grammarSQL *SQL) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Opti
 	grammarSQL.DB = db
 	grammarSQL.Config = config
 	grammarSQL.Option = option
+	if config.DSN == "" {
+		return nil
+	}
+
 	uinfo, err := url.Parse(grammarSQL.Config.DSN)
 	if err != nil {
 		return err
@@ -32,7 +32,7 @@ func (grammarSQL SQL) CompileUpdate(query *dbal.Query, values map[string]interfa
 	wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
 	bindings = append(bindings, query.GetBindings("where")...)
 
-	return fmt.Sprintf("update %s %sset %s %s", table, joins, columns, wheres), bindings
+	return grammarSQL.JoinSegments("update", table, joins, "set", columns, wheres), bindings
 }
 
 // CompileUpdateColumns Compile the columns for an update statement.
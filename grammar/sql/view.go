@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// GetViews Get all of the views of the database, the definitions are read from the catalog.
+func (grammarSQL SQL) GetViews() ([]*dbal.View, error) {
+	sql := "SELECT table_schema AS `db_name`, table_name AS `view_name`, view_definition AS `view_definition` FROM information_schema.views WHERE table_schema = DATABASE() ORDER BY table_name"
+	defer log.Debug(sql)
+	views := []*dbal.View{}
+	err := grammarSQL.DB.Select(&views, sql)
+	if err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// CreateView create a new view using the select statement
+func (grammarSQL SQL) CreateView(view *dbal.View, options ...dbal.ViewOption) error {
+	option := dbal.ViewOption{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	if option.Materialized {
+		return fmt.Errorf("the materialized view is not supported by %s", grammarSQL.Driver)
+	}
+
+	create := "CREATE VIEW"
+	if option.Replace {
+		create = "CREATE OR REPLACE VIEW"
+	}
+
+	sql := fmt.Sprintf("%s %s AS %s", create, grammarSQL.ID(view.Name), Interpolate(view.SQL, view.Bindings, QuoteBackslash))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropView drop the view
+func (grammarSQL SQL) DropView(name string) error {
+	sql := fmt.Sprintf("DROP VIEW %s", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// DropViewIfExists drop the view if it exists
+func (grammarSQL SQL) DropViewIfExists(name string) error {
+	sql := fmt.Sprintf("DROP VIEW IF EXISTS %s", grammarSQL.ID(name))
+	defer log.Debug(sql)
+	return grammarSQL.Exec(sql)
+}
+
+// RefreshMaterializedView refresh the materialized view
+func (grammarSQL SQL) RefreshMaterializedView(name string) error {
+	return fmt.Errorf("the materialized view is not supported by %s", grammarSQL.Driver)
+}
+
+// Interpolate inline the bindings of the statement as the literals, the views can not be parameterized.
+// Both the question mark (?) and the numbered ($1) place-holders are replaced, the quoted texts are kept.
+func Interpolate(sql string, bindings []interface{}, quote func(value string) string) string {
+	if len(bindings) == 0 {
+		return sql
+	}
+
+	var res strings.Builder
+	var quoted rune = 0
+	next := 0
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if quoted != 0 {
+			if r == quoted {
+				quoted = 0
+			}
+			res.WriteRune(r)
+			continue
+		}
+
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			quoted = r
+
+		case r == '?' && next < len(bindings):
+			res.WriteString(Literal(bindings[next], quote))
+			next++
+			continue
+
+		case r == '$' && i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9':
+			j := i + 1
+			for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+				j++
+			}
+			num, _ := strconv.Atoi(string(runes[i+1 : j]))
+			if num >= 1 && num <= len(bindings) {
+				res.WriteString(Literal(bindings[num-1], quote))
+				i = j - 1
+				continue
+			}
+		}
+		res.WriteRune(r)
+	}
+	return res.String()
+}
+
+// Literal get the SQL literal of the value, the strings are quoted using the given function
+func Literal(value interface{}, quote func(value string) string) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v)
+	case time.Time:
+		return quote(v.Format("2006-01-02 15:04:05"))
+	case []byte:
+		return quote(string(v))
+	case dbal.Expression:
+		return v.GetValue()
+	}
+	return quote(fmt.Sprintf("%v", value))
+}
+
+// QuoteString quote the string literal using the standard SQL escaping, the single quotes are doubled
+func QuoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// QuoteBackslash quote the string literal, the backslashes are escaped as well (MySQL)
+func QuoteBackslash(value string) string {
+	return QuoteString(strings.ReplaceAll(value, "\\", "\\\\"))
+}
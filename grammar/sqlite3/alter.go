@@ -0,0 +1,663 @@
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/jmoiron/sqlx"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// the keywords of the table constraint definitions
+var tableConstraintKeywords = []string{"CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN"}
+
+// the key parts and the predicate of the index definition, after the ON table
+var reIndexBody = regexp.MustCompile(`(?is)\bON\s+\S+?\s*(\(.*)$`)
+
+// the partial index definition
+var reIndexWhere = regexp.MustCompile(`(?is)\)\s*WHERE\b`)
+
+// sqlite 3.35.0 supports ALTER TABLE DROP COLUMN
+var dropColumnVersion = semver.MustParse("3.35.0")
+
+func (grammarSQL SQLite3) alterTableDropColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+
+	// the on update trigger uses the column
+	if column, has := table.ColumnMap[name]; has && column.OnUpdateCurrent {
+		err := grammarSQL.syncOnUpdateTrigger(table, onUpdateColumns(table, name, false), stmts)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("DropColumn: %s", err))
+			command.Callback(err)
+			return
+		}
+	}
+
+	// try the native DROP COLUMN first, it fails when the column is indexed or used by the constraints
+	version, err := grammarSQL.GetVersion()
+	if err == nil && version.GE(dropColumnVersion) {
+		stmt := fmt.Sprintf("%sDROP COLUMN %s", sql, grammarSQL.ID(name))
+		*stmts = append(*stmts, stmt)
+		err = grammarSQL.ExecSQL(table, stmt)
+		if err == nil {
+			command.Callback(err)
+			return
+		}
+		log.Debug("sqlite3 DROP COLUMN %s failed (%s), rebuild the table", name, err)
+	}
+
+	err = grammarSQL.rebuildTable(table, stmts, []string{name}, func(definitions []string) ([]string, error) {
+		found := false
+		results := []string{}
+		for _, definition := range definitions {
+			column := definitionColumn(definition)
+			if column == name {
+				found = true
+				continue
+			}
+
+			// drop the table constraints use the column
+			if column == "" && definitionUses(definition, name) {
+				continue
+			}
+			results = append(results, definition)
+		}
+
+		if !found {
+			return nil, fmt.Errorf("the column %s does not exists", name)
+		}
+		return results, nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropColumn: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableChangeColumn(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	column := *command.Params[0].(*dbal.Column)
+	onUpdate := table.HasColumn(column.Name) && table.ColumnMap[column.Name].OnUpdateCurrent
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		// the composite primary key is defined as a table constraint
+		for _, definition := range definitions {
+			if definitionColumn(definition) == "" && definitionIs(definition, "PRIMARY") {
+				column.Primary = false
+			}
+		}
+
+		for i, definition := range definitions {
+			if definitionColumn(definition) == column.Name {
+				definitions[i] = grammarSQL.SQLAddColumn(&column)
+				return definitions, nil
+			}
+		}
+		return nil, fmt.Errorf("the column %s does not exists", column.Name)
+	})
+
+	// the existing trigger is recreated by the rebuilding
+	if err == nil && column.OnUpdateCurrent != onUpdate {
+		err = grammarSQL.syncOnUpdateTrigger(table, onUpdateColumns(table, column.Name, column.OnUpdateCurrent), stmts)
+	}
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("ChangeColumn %s: %s", column.Name, err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableCreatePrimary(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	primary := command.Params[0].(*dbal.Primary)
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		return append(definitions, grammarSQL.SQLAddPrimary(primary)), nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreatePrimary: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableDropPrimary(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	re := regexp.MustCompile(`(?i)\s+(PRIMARY\s+KEY(\s+(ASC|DESC))?|AUTOINCREMENT)\b`)
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		results := []string{}
+		for _, definition := range definitions {
+			if definitionColumn(definition) == "" {
+				if definitionIs(definition, "PRIMARY") {
+					continue
+				}
+				results = append(results, definition)
+				continue
+			}
+			results = append(results, re.ReplaceAllString(definition, ""))
+		}
+		return results, nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropPrimary: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableCreateForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	foreign := command.Params[0].(*dbal.ForeignKey)
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		return append(definitions, grammarSQL.SQLAddForeign(foreign)), nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableDropForeign(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	foreign := table.GetForeignKey(name)
+	if foreign == nil {
+		err := fmt.Errorf("DropForeign: The foreign key %s not found", name)
+		*errs = append(*errs, err)
+		command.Callback(err)
+		return
+	}
+
+	columns := []string{}
+	for _, column := range foreign.Columns {
+		columns = append(columns, column.Name)
+	}
+
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		results := []string{}
+		for _, definition := range definitions {
+			if definitionColumn(definition) == "" && definitionIs(definition, "FOREIGN") && definitionKeyColumns(definition, "FOREIGN KEY") == strings.Join(columns, ",") {
+				continue
+			}
+			results = append(results, definition)
+		}
+		return results, nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropForeign: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableCreateConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	constraint := command.Params[0].(*dbal.Constraint)
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		return append(definitions, grammarSQL.SQLAddConstraint(constraint)), nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("CreateConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableDropConstraint(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	name := command.Params[0].(string)
+	err := grammarSQL.rebuildTable(table, stmts, nil, func(definitions []string) ([]string, error) {
+		found := false
+		results := []string{}
+		for _, definition := range definitions {
+			if definitionColumn(definition) == "" && definitionConstraintName(definition) == name {
+				found = true
+				continue
+			}
+			results = append(results, definition)
+		}
+
+		if !found {
+			return nil, fmt.Errorf("the constraint %s not found", name)
+		}
+		return results, nil
+	})
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("DropConstraint: %s", err))
+	}
+	command.Callback(err)
+}
+
+func (grammarSQL SQLite3) alterTableRenameIndex(table *dbal.Table, command *dbal.Command, sql string, stmts *[]string, errs *[]error) {
+	old := fmt.Sprintf("%s_%s", table.TableName, command.Params[0])
+	new := fmt.Sprintf("%s_%s", table.TableName, command.Params[1])
+
+	rows := []string{}
+	err := grammarSQL.DB.Select(&rows, "SELECT `sql` FROM sqlite_master WHERE type='index' AND sql IS NOT NULL AND name=?", old)
+	if err == nil && len(rows) == 0 {
+		err = fmt.Errorf("The index %s not found", command.Params[0])
+	}
+
+	if err == nil {
+		// CREATE UNIQUE INDEX `table_old` ON ... => CREATE UNIQUE INDEX `table_new` ON ...
+		re := regexp.MustCompile(`(?is)^(\s*CREATE\s+(UNIQUE\s+)?INDEX\s+(IF\s+NOT\s+EXISTS\s+)?)(\S+)`)
+		create := re.ReplaceAllString(rows[0], "${1}"+strings.ReplaceAll(grammarSQL.ID(new), "$", "$$"))
+		drop := fmt.Sprintf("DROP INDEX %s", grammarSQL.ID(old))
+		*stmts = append(*stmts, drop, create)
+
+		if grammarSQL.IsPretend() {
+			grammarSQL.Exec(drop)
+			grammarSQL.Exec(create)
+			command.Callback(nil)
+			return
+		}
+
+		tx, txErr := grammarSQL.DB.Beginx()
+		err = txErr
+		if err == nil {
+			_, err = tx.Exec(drop)
+			if err == nil {
+				_, err = tx.Exec(create)
+			}
+			if err != nil {
+				tx.Rollback()
+			} else {
+				err = tx.Commit()
+			}
+		}
+	}
+
+	if err == nil {
+		err = grammarSQL.refreshTable(table)
+	}
+
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("RenameIndex: %s", err))
+	}
+	command.Callback(err)
+}
+
+// rebuildTable alter the table using the 12-steps procedure, see https://www.sqlite.org/lang_altertable.html
+// The alter function receives the column and constraint definitions of the table and returns the new ones,
+// the indexes and the triggers use the dropped columns will not be recreated, the skipped triggers are logged as warnings.
+func (grammarSQL SQLite3) rebuildTable(table *dbal.Table, stmts *[]string, dropped []string, alter func(definitions []string) ([]string, error)) error {
+	ctx := context.Background()
+	name := table.TableName
+
+	if grammarSQL.IsPretend() {
+		sqls, err := grammarSQL.rebuildStatements(ctx, grammarSQL.DB, table, dropped, alter)
+		if err != nil {
+			return err
+		}
+		for _, sql := range sqls {
+			*stmts = append(*stmts, sql)
+			grammarSQL.Exec(sql)
+		}
+		return nil
+	}
+
+	// the foreign_keys pragma is connection-wide, pin a connection
+	conn, err := grammarSQL.DB.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// 1. disable the foreign key constraints (it is a no-op inside a transaction)
+	foreignKeys := 0
+	err = conn.GetContext(ctx, &foreignKeys, "PRAGMA foreign_keys")
+	if err != nil {
+		return err
+	}
+	if foreignKeys == 1 {
+		_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+		if err != nil {
+			return err
+		}
+		defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+	}
+
+	// 2. start a transaction
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 3 ~ 8. create the new table, copy the data, drop the old one and rename the new one
+	sqls, err := grammarSQL.rebuildStatements(ctx, tx, table, dropped, alter)
+	if err != nil {
+		return err
+	}
+
+	for _, sql := range sqls {
+		*stmts = append(*stmts, sql)
+		_, err = tx.ExecContext(ctx, sql)
+		if err != nil {
+			return fmt.Errorf("SQL: %s ERROR: %s", sql, err)
+		}
+	}
+
+	// 9. check the foreign key constraints
+	if foreignKeys == 1 {
+		violations := []struct {
+			Table string `db:"table"`
+		}{}
+		err = tx.SelectContext(ctx, &violations, "SELECT `table` FROM pragma_foreign_key_check(?)", name)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return fmt.Errorf("the table %s violates %d foreign key constraints", name, len(violations))
+		}
+	}
+
+	// 10. commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return grammarSQL.refreshTable(table)
+}
+
+// rebuildStatements generate the statements of rebuilding the table
+func (grammarSQL SQLite3) rebuildStatements(ctx context.Context, q sqlx.QueryerContext, table *dbal.Table, dropped []string, alter func(definitions []string) ([]string, error)) ([]string, error) {
+	name := table.TableName
+	temp := fmt.Sprintf("__xun_new_%s", name)
+
+	// 3. remember the table, indexes, triggers and views definition
+	create := ""
+	err := sqlx.GetContext(ctx, q, &create, "SELECT `sql` FROM sqlite_master WHERE type='table' AND name=?", name)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := []struct {
+		Type string `db:"type"`
+		Name string `db:"name"`
+		SQL  string `db:"sql"`
+	}{}
+	err = sqlx.SelectContext(ctx, q, &schemas,
+		"SELECT `type`, `name`, `sql` FROM sqlite_master WHERE `sql` IS NOT NULL AND ((tbl_name = ? AND type IN ('index', 'trigger')) OR (type = 'view' AND `sql` LIKE ?))",
+		name, "%"+name+"%",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recreates := []string{}
+	views := []string{}
+	for _, schema := range schemas {
+		if schema.Type == "view" {
+			views = append(views, schema.Name)
+		}
+
+		if schema.Type == "index" && len(dropped) > 0 {
+			used, err := grammarSQL.indexUses(ctx, q, schema.Name, schema.SQL, dropped)
+			if err != nil {
+				return nil, err
+			}
+			if used {
+				continue
+			}
+		}
+
+		// the trigger uses the dropped columns can't be recreated, it is dropped with the old table
+		if schema.Type == "trigger" && len(dropped) > 0 && sqlUses(schema.SQL, dropped) {
+			log.Warn("sqlite3 the trigger %s of %s uses the dropped columns %s, it is not recreated", schema.Name, name, strings.Join(dropped, ","))
+			continue
+		}
+		recreates = append(recreates, schema.SQL)
+	}
+
+	// 4. create the new table
+	definitions, tail, err := parseTableDefinitions(create)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err = alter(definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	sqls := []string{
+		fmt.Sprintf("CREATE TABLE %s (\n%s\n)%s", grammarSQL.ID(temp), strings.Join(definitions, ",\n"), tail),
+	}
+
+	// 5. copy the data of the columns both in the old and new table
+	columns := []string{}
+	for _, column := range table.Columns {
+		if column.IsGenerated() {
+			continue
+		}
+		for _, definition := range definitions {
+			if definitionColumn(definition) == column.Name {
+				columns = append(columns, grammarSQL.ID(column.Name))
+				break
+			}
+		}
+	}
+	sqls = append(sqls, fmt.Sprintf(
+		"INSERT INTO %s (%s) SELECT %s FROM %s",
+		grammarSQL.ID(temp), strings.Join(columns, ","), strings.Join(columns, ","), grammarSQL.ID(name),
+	))
+
+	// 6. drop the views use the table and the old table
+	for _, view := range views {
+		sqls = append(sqls, fmt.Sprintf("DROP VIEW %s", grammarSQL.ID(view)))
+	}
+	sqls = append(sqls, fmt.Sprintf("DROP TABLE %s", grammarSQL.ID(name)))
+
+	// 7. rename the new table
+	sqls = append(sqls, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(temp), grammarSQL.ID(name)))
+
+	// 8. recreate the indexes, triggers and views
+	sqls = append(sqls, recreates...)
+	return sqls, nil
+}
+
+// indexUses check if the index uses the given columns, the key parts of the expressions have no name,
+// so the expressions and the predicate (WHERE ...) are checked by the index definition.
+func (grammarSQL SQLite3) indexUses(ctx context.Context, q sqlx.QueryerContext, name string, create string, columns []string) (bool, error) {
+	parts := []sql.NullString{}
+	err := sqlx.SelectContext(ctx, q, &parts, "SELECT `name` FROM pragma_index_info(?)", name)
+	if err != nil {
+		return false, err
+	}
+
+	names := []string{}
+	expression := false
+	for _, part := range parts {
+		if !part.Valid {
+			expression = true
+			continue
+		}
+		names = append(names, part.String)
+	}
+	if columnsUsed(names, columns) {
+		return true, nil
+	}
+
+	if !expression && !reIndexWhere.MatchString(create) {
+		return false, nil
+	}
+
+	// CREATE INDEX `t_email_lower` ON `t` (lower(email)) WHERE deleted_at IS NULL => (lower(email)) WHERE deleted_at IS NULL
+	if matched := reIndexBody.FindStringSubmatch(create); matched != nil {
+		create = matched[1]
+	}
+	return sqlUses(create, columns), nil
+}
+
+// refreshTable update table structure
+func (grammarSQL SQLite3) refreshTable(table *dbal.Table) error {
+	new, err := grammarSQL.GetTable(table.TableName)
+	if err != nil {
+		return err
+	}
+	*table = *new
+	return nil
+}
+
+// parseTableDefinitions split the CREATE TABLE statement into the column and constraint definitions
+// and the table options after the definitions (e.g. WITHOUT ROWID)
+func parseTableDefinitions(create string) ([]string, string, error) {
+	start := strings.Index(create, "(")
+	if start < 0 {
+		return nil, "", fmt.Errorf("can't parse the table definition %s", create)
+	}
+
+	definitions := []string{}
+	depth := 0
+	quote := rune(0)
+	begin := start + 1
+	for i, c := range create[start:] {
+		pos := start + i
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '[':
+			quote = ']'
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				definitions = append(definitions, strings.TrimSpace(create[begin:pos]))
+				return definitions, create[pos+1:], nil
+			}
+		case ',':
+			if depth == 1 {
+				definitions = append(definitions, strings.TrimSpace(create[begin:pos]))
+				begin = pos + 1
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("can't parse the table definition %s", create)
+}
+
+// definitionColumn get the column name of the column definition, returns "" when it's a table constraint
+func definitionColumn(definition string) string {
+	definition = strings.TrimSpace(definition)
+	if definition == "" {
+		return ""
+	}
+
+	switch definition[0] {
+	case '`', '"', '[':
+		end := map[byte]byte{'`': '`', '"': '"', '[': ']'}[definition[0]]
+		pos := strings.IndexByte(definition[1:], end)
+		if pos < 0 {
+			return ""
+		}
+		return definition[1 : pos+1]
+	}
+
+	name := strings.Fields(definition)[0]
+	for _, keyword := range tableConstraintKeywords {
+		if strings.EqualFold(name, keyword) {
+			return ""
+		}
+	}
+	return name
+}
+
+// definitionGeneration get the expression of the generated column definition, e.g. GENERATED ALWAYS AS (a + b) STORED => a + b
+func definitionGeneration(definition string) string {
+	loc := regexp.MustCompile(`(?i)\bAS\s*\(`).FindStringIndex(definition)
+	if loc == nil {
+		return ""
+	}
+
+	begin := loc[1]
+	depth := 1
+	quote := rune(0)
+	for i, c := range definition[begin:] {
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(definition[begin : begin+i])
+			}
+		}
+	}
+	return ""
+}
+
+// definitionIs check if the table constraint is the given type (PRIMARY, UNIQUE, CHECK, FOREIGN)
+func definitionIs(definition string, typ string) bool {
+	fields := strings.Fields(strings.ToUpper(definition))
+	if len(fields) > 2 && fields[0] == "CONSTRAINT" {
+		fields = fields[2:]
+	}
+	return len(fields) > 0 && strings.HasPrefix(fields[0], typ)
+}
+
+// definitionConstraintName get the name of the table constraint
+func definitionConstraintName(definition string) string {
+	fields := strings.Fields(definition)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "CONSTRAINT") {
+		return ""
+	}
+	return strings.Trim(fields[1], "`\"[]")
+}
+
+// definitionKeyColumns get the column names after the given keyword, e.g. FOREIGN KEY (`a`,`b`) => a,b
+func definitionKeyColumns(definition string, keyword string) string {
+	re := regexp.MustCompile(`(?i)` + strings.ReplaceAll(keyword, " ", `\s+`) + `\s*\(([^)]*)\)`)
+	matched := re.FindStringSubmatch(definition)
+	if len(matched) != 2 {
+		return ""
+	}
+	columns := strings.Split(matched[1], ",")
+	for i := range columns {
+		columns[i] = strings.Trim(columns[i], " `\"[]")
+	}
+	return strings.Join(columns, ",")
+}
+
+// definitionUses check if the table constraint uses the given column
+func definitionUses(definition string, column string) bool {
+	re := regexp.MustCompile(`(^|[^0-9A-Za-z_])` + regexp.QuoteMeta(column) + `([^0-9A-Za-z_]|$)`)
+	return re.MatchString(definition)
+}
+
+// sqlUses check if the statement uses any of the given columns
+func sqlUses(stmt string, columns []string) bool {
+	for _, column := range columns {
+		if definitionUses(stmt, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnsUsed check if any of the names is in the columns
+func columnsUsed(columns []string, names []string) bool {
+	for _, column := range columns {
+		for _, name := range names {
+			if column == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -22,9 +22,15 @@ func (grammarSQL SQLite3) SQLAddIndex(index *dbal.Index) string {
 	}
 
 	// UNIQUE KEY `unionid` (`unionid`) COMMENT 'xxxx'
+	// the prefix lengths are not supported, the whole columns are indexed
 	columns := []string{}
-	for _, column := range index.Columns {
-		columns = append(columns, quoter.ID(column.Name))
+	for _, part := range index.KeyParts() {
+		order := utils.GetIF(part.Order != "", " "+part.Order, "").(string)
+		if part.Column == nil {
+			columns = append(columns, fmt.Sprintf("(%s)%s", part.Expression, order))
+			continue
+		}
+		columns = append(columns, quoter.ID(part.Column.Name)+order)
 	}
 
 	name := fmt.Sprintf("%s_%s", index.TableName, index.Name)
@@ -32,6 +38,9 @@ func (grammarSQL SQLite3) SQLAddIndex(index *dbal.Index) string {
 		"CREATE %s %s ON %s (%s)",
 		typ, quoter.ID(name), quoter.ID(index.TableName), strings.Join(columns, ","))
 
+	if index.Predicate != "" {
+		sql = fmt.Sprintf("%s WHERE %s", sql, index.Predicate)
+	}
 	return sql
 }
 
@@ -49,6 +58,8 @@ func (grammarSQL SQLite3) SQLAddColumn(column *dbal.Column) string {
 		if strings.Contains(strings.ToLower(defaultValue), "now()") || defaultValue == "" {
 			defaultValue = "DEFAULT (datetime('now','localtime'))"
 		}
+	} else if strings.HasPrefix(column.Type, "dateTime") && strings.Contains(strings.ToLower(defaultValue), "now()") {
+		defaultValue = "DEFAULT (datetime('now','localtime'))"
 	}
 
 	// unsigned := utils.GetIF(column.IsUnsigned && column.Type == "BIGINT", "UNSIGNED", "").(string)
@@ -63,7 +74,7 @@ func (grammarSQL SQLite3) SQLAddColumn(column *dbal.Column) string {
 	}
 
 	collation := utils.GetIF(column.Collation != nil, fmt.Sprintf("COLLATE %s", utils.StringVal(column.Collation)), "").(string)
-	extra := utils.GetIF(column.Extra != nil, "AUTOINCREMENT", "")
+	extra := utils.GetIF(utils.StringVal(column.Extra) == "AutoIncrement", "AUTOINCREMENT", "")
 
 	if extra == "AUTOINCREMENT" {
 		typ = "INTEGER"
@@ -73,6 +84,13 @@ func (grammarSQL SQLite3) SQLAddColumn(column *dbal.Column) string {
 		typ = "UNSIGNED BIG INT"
 	}
 
+	// the stored generated columns can not be added to an existing table
+	if column.IsGenerated() {
+		storage := utils.GetIF(utils.StringVal(column.Extra) == "StoredGenerated", "STORED", "VIRTUAL").(string)
+		extra = fmt.Sprintf("GENERATED ALWAYS AS (%s) %s", column.GenerationExpression, storage)
+		defaultValue = ""
+	}
+
 	sql := fmt.Sprintf(
 		"%s %s %s %s %s %s",
 		quoter.ID(column.Name), typ, nullable, defaultValue, extra, collation)
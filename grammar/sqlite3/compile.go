@@ -151,6 +151,17 @@ func (grammarSQL SQLite3) WhereDateBased(typ string, query *dbal.Query, where db
 	return fmt.Sprintf("strftime('%s',%s) %s cast(%s as text)", typ, grammarSQL.Wrap(where.Column), where.Operator, value)
 }
 
+// CompileDistance the geometries are stored as the WKT text, the spatial functions are not supported.
+// The query builder compiles the distance when the condition is added, so the panic happens there.
+func (grammarSQL SQLite3) CompileDistance(column interface{}, point string) string {
+	panic(fmt.Errorf("SQLite does not support the spatial functions, the distance can not be computed"))
+}
+
+// WhereDistance the geometries are stored as the WKT text, the spatial functions are not supported
+func (grammarSQL SQLite3) WhereDistance(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	return grammarSQL.CompileDistance(where.Column, where.Value.(string))
+}
+
 // CompileLock the lock into SQL.
 func (grammarSQL SQLite3) CompileLock(query *dbal.Query, lock interface{}) string {
 	return ""
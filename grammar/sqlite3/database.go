@@ -0,0 +1,151 @@
+package sqlite3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// GetDatabases get the database names, the databases of sqlite3 are the files in the directory of the connected database.
+func (grammarSQL SQLite3) GetDatabases() ([]string, error) {
+	dir, ext, err := grammarSQL.databaseDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return nil, err
+	}
+
+	databases := []string{}
+	for _, file := range files {
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			continue
+		}
+		databases = append(databases, strings.TrimSuffix(filepath.Base(file), ext))
+	}
+	sort.Strings(databases)
+	return databases, nil
+}
+
+// DatabaseExists check if the database file exists
+func (grammarSQL SQLite3) DatabaseExists(name string) (bool, error) {
+	file, err := grammarSQL.databaseFile(name)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(file)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateDatabase create the database file {dir}/{name}{ext} by attaching it, the charset and the collation are ignored.
+// The database is detached after created, the attaching is per connection, open a new connection to use it.
+func (grammarSQL SQLite3) CreateDatabase(name string, charset string, collation string) error {
+	file, err := grammarSQL.databaseFile(name)
+	if err != nil {
+		return err
+	}
+
+	stmts := []string{
+		fmt.Sprintf("ATTACH DATABASE %s AS %s", grammarSQL.VAL(file), grammarSQL.ID(name)),
+		fmt.Sprintf("DETACH DATABASE %s", grammarSQL.ID(name)),
+	}
+	defer log.Debug(strings.Join(stmts, ";\n"))
+	if grammarSQL.IsPretend() {
+		for _, stmt := range stmts {
+			grammarSQL.Exec(stmt)
+		}
+		return nil
+	}
+
+	exists, err := grammarSQL.DatabaseExists(name)
+	if err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("the database %s exists", name)
+	}
+
+	conn, err := grammarSQL.DB.Connx(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, stmt := range stmts {
+		_, err = conn.ExecContext(context.Background(), stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropDatabase remove the database file and the journal files of it, the connected database can not be dropped.
+// In the pretend mode, the removing is collected as a SQL comment.
+func (grammarSQL SQLite3) DropDatabase(name string) error {
+	if name == grammarSQL.DatabaseName {
+		return fmt.Errorf("the connected database %s can not be dropped", name)
+	}
+
+	file, err := grammarSQL.databaseFile(name)
+	if err != nil {
+		return err
+	}
+	// SQLite has no DROP DATABASE statement, the removing of the file is described as a comment
+	stmt := fmt.Sprintf("-- DROP DATABASE %s: remove the file %s and its journal files", grammarSQL.ID(name), grammarSQL.VAL(file))
+	defer log.Debug(stmt)
+	if grammarSQL.IsPretend() {
+		return grammarSQL.Exec(stmt)
+	}
+
+	err = os.Remove(file)
+	if err != nil {
+		return err
+	}
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		os.Remove(file + suffix)
+	}
+	return nil
+}
+
+// databaseFile get the file of the database, the name should not be a path.
+func (grammarSQL SQLite3) databaseFile(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("the database name %s is invalid", name)
+	}
+
+	dir, ext, err := grammarSQL.databaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+ext), nil
+}
+
+// databaseDir get the directory and the extension of the connected database file
+func (grammarSQL SQLite3) databaseDir() (string, string, error) {
+	uinfo, err := url.Parse(grammarSQL.Config.DSN)
+	if err != nil {
+		return "", "", err
+	}
+
+	path := uinfo.Path
+	if path == "" {
+		path = uinfo.Opaque
+	}
+	if path == "" || strings.HasPrefix(path, ":memory:") || uinfo.Query().Get("mode") == "memory" {
+		return "", "", fmt.Errorf("the databases are not supported by the in-memory database")
+	}
+	return filepath.Dir(path), filepath.Ext(path), nil
+}
@@ -17,9 +17,9 @@ func (grammarSQL SQLite3) CompileDelete(query *dbal.Query) (string, []interface{
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", alias)}
+	qualifier := grammarSQL.FromQualifier(query)
+	if qualifier != "" {
+		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", qualifier)}
 	} else {
 		query.Columns = []interface{}{"rowid"}
 	}
@@ -27,7 +27,7 @@ func (grammarSQL SQLite3) CompileDelete(query *dbal.Query) (string, []interface{
 	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
 
 	bindings = append(bindings, query.GetBindings()...)
-	sql := fmt.Sprintf("delete from  %s where %s in (%s)", table, grammarSQL.Wrap("rowid"), selectSQL)
+	sql := fmt.Sprintf("delete from %s where %s in (%s)", table, grammarSQL.Wrap("rowid"), selectSQL)
 
 	return sql, bindings
 }
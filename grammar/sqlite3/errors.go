@@ -0,0 +1,71 @@
+package sqlite3
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// WrapError Classify the SQLite driver error.
+func (grammarSQL SQLite3) WrapError(err error) error {
+	var sqliteErr sqlite3.Error
+	if err == nil || !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		// UNIQUE constraint failed: table.column1, table.column2
+		wrapped := dbal.NewError(dbal.ErrUniqueViolation, err)
+		wrapped.Table, wrapped.Column = parseSQLiteColumn(sqliteErr.Error(), "constraint failed: ")
+		return wrapped
+
+	case sqlite3.ErrConstraintNotNull:
+		// NOT NULL constraint failed: table.column
+		wrapped := dbal.NewError(dbal.ErrNotNullViolation, err)
+		wrapped.Table, wrapped.Column = parseSQLiteColumn(sqliteErr.Error(), "constraint failed: ")
+		return wrapped
+
+	case sqlite3.ErrConstraintCheck:
+		// CHECK constraint failed: name
+		wrapped := dbal.NewError(dbal.ErrCheckViolation, err)
+		if pos := strings.Index(sqliteErr.Error(), "constraint failed: "); pos >= 0 {
+			wrapped.Constraint = strings.TrimSpace(sqliteErr.Error()[pos+len("constraint failed: "):])
+		}
+		return wrapped
+
+	case sqlite3.ErrConstraintForeignKey:
+		// SQLite does not report the name of the foreign key
+		return dbal.NewError(dbal.ErrForeignKeyViolation, err)
+	}
+
+	switch sqliteErr.Code {
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		return dbal.NewError(dbal.ErrLockTimeout, err)
+	}
+
+	return err
+}
+
+// parseSQLiteColumn parse the table and the column name from the constraint message
+// multiple columns are joined with comma. "table.col1, table.col2" => "table", "col1,col2"
+func parseSQLiteColumn(message string, prefix string) (string, string) {
+	pos := strings.Index(message, prefix)
+	if pos < 0 {
+		return "", ""
+	}
+
+	table := ""
+	columns := []string{}
+	for _, name := range strings.Split(message[pos+len(prefix):], ",") {
+		name = strings.TrimSpace(name)
+		if dot := strings.LastIndex(name, "."); dot >= 0 {
+			table = name[:dot]
+			name = name[dot+1:]
+		}
+		columns = append(columns, name)
+	}
+	return table, strings.Join(columns, ",")
+}
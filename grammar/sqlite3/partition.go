@@ -0,0 +1,32 @@
+package sqlite3
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// GetPartitions the table partitioning is not supported
+func (grammarSQL SQLite3) GetPartitions(tableName string) ([]*dbal.Partition, error) {
+	return nil, grammarSQL.errPartitionNotSupported()
+}
+
+// AddPartition the table partitioning is not supported
+func (grammarSQL SQLite3) AddPartition(tableName string, partitions []*dbal.Partition) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// DropPartition the table partitioning is not supported
+func (grammarSQL SQLite3) DropPartition(tableName string, names []string) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// DetachPartition the table partitioning is not supported
+func (grammarSQL SQLite3) DetachPartition(tableName string, name string) error {
+	return grammarSQL.errPartitionNotSupported()
+}
+
+// errPartitionNotSupported the error of the table partitioning
+func (grammarSQL SQLite3) errPartitionNotSupported() error {
+	return fmt.Errorf("the table partitioning is not supported by %s", grammarSQL.Driver)
+}
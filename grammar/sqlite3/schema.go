@@ -67,6 +67,10 @@ func (grammarSQL SQLite3) TableExists(name string) (bool, error) {
 // CreateTable create a new table on the schema
 func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateTableOption) error {
 
+	if len(options) > 0 && options[0].Partition != nil {
+		return grammarSQL.errPartitionNotSupported()
+	}
+
 	name := grammarSQL.ID(table.TableName)
 	sql := fmt.Sprintf("CREATE TABLE %s (\n", name)
 	stmts := []string{}
@@ -74,6 +78,8 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 	var primary *dbal.Primary = nil
 	columns := []*dbal.Column{}
 	indexes := []*dbal.Index{}
+	foreigns := []*dbal.ForeignKey{}
+	constraints := []*dbal.Constraint{}
 	cbCommands := []*dbal.Command{}
 
 	// Commands
@@ -85,6 +91,7 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 	//    CreateIndex(index *Index) for creating a index
 	//    DropIndex( name string) for  dropping a index
 	//    RenameIndex(old string,new string)  for renaming a index
+	// The operations that sqlite3 ALTER TABLE does not support are done by rebuilding the table (see alter.go)
 	for _, command := range table.Commands {
 		switch command.Name {
 		case "AddColumn":
@@ -98,6 +105,12 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 		case "CreatePrimary":
 			primary = command.Params[0].(*dbal.Primary)
 			cbCommands = append(cbCommands, command)
+		case "CreateForeign":
+			foreigns = append(foreigns, command.Params[0].(*dbal.ForeignKey))
+			cbCommands = append(cbCommands, command)
+		case "CreateConstraint":
+			constraints = append(constraints, command.Params[0].(*dbal.Constraint))
+			cbCommands = append(cbCommands, command)
 		}
 	}
 
@@ -115,12 +128,22 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 		)
 	}
 
+	// Foreign keys (sqlite3 only supports creating them with the table)
+	for _, foreign := range foreigns {
+		stmts = append(stmts, grammarSQL.SQLAddForeign(foreign))
+	}
+
+	// Constraints
+	for _, constraint := range constraints {
+		stmts = append(stmts, grammarSQL.SQLAddConstraint(constraint))
+	}
+
 	sql = sql + strings.Join(stmts, ",\n")
 	sql = sql + fmt.Sprintf("\n)")
 
 	// Create table
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	err := grammarSQL.Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -132,8 +155,24 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 			grammarSQL.SQLAddIndex(index),
 		)
 	}
+	// the on update trigger
+	onUpdates := []string{}
+	for _, column := range columns {
+		if column.OnUpdateCurrent {
+			onUpdates = append(onUpdates, column.Name)
+		}
+	}
+	if len(onUpdates) > 0 {
+		indexStmts = append(indexStmts, grammarSQL.SQLCreateOnUpdateTrigger(table.TableName, onUpdates))
+	}
+
 	defer log.Debug(strings.Join(indexStmts, ";\n"))
-	_, err = grammarSQL.DB.Exec(strings.Join(indexStmts, ";\n"))
+	for _, stmt := range indexStmts {
+		err = grammarSQL.Exec(stmt)
+		if err != nil {
+			break
+		}
+	}
 
 	for _, cmd := range cbCommands {
 		cmd.Callback(err)
@@ -150,8 +189,11 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 func (grammarSQL SQLite3) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
-	return err
+	err := grammarSQL.Exec(sql)
+	if err != nil {
+		return err
+	}
+	return grammarSQL.renameOnUpdateTrigger(old, new)
 }
 
 // GetTable get a table on the schema
@@ -177,6 +219,16 @@ func (grammarSQL SQLite3) GetTable(name string) (*dbal.Table, error) {
 		return nil, err
 	}
 
+	foreignKeys, err := grammarSQL.GetForeignKeyListing(table.DBName, table.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, err := grammarSQL.GetTableConstraintListing(table.DBName, table.TableName)
+	if err != nil {
+		return nil, err
+	}
+
 	primaryKeyName := ""
 
 	// attaching columns
@@ -185,21 +237,32 @@ func (grammarSQL SQLite3) GetTable(name string) (*dbal.Table, error) {
 		table.PushColumn(column)
 	}
 
-	// attaching indexes
+	// attaching indexes, the expression key parts have no column
 	for i := range indexes {
 		idx := indexes[i]
-		if !table.HasColumn(idx.ColumnName) {
-			return nil, fmt.Errorf("the column   %s does not exists", idx.ColumnName)
+		var column *dbal.Column
+		if idx.Expression == "" {
+			if !table.HasColumn(idx.ColumnName) {
+				return nil, fmt.Errorf("the column   %s does not exists", idx.ColumnName)
+			}
+			column = table.ColumnMap[idx.ColumnName]
 		}
-		column := table.ColumnMap[idx.ColumnName]
 		if !table.HasIndex(idx.Name) {
 			index := *idx
 			index.Columns = []*dbal.Column{}
-			column.Indexes = append(column.Indexes, &index)
+			if column != nil {
+				column.Indexes = append(column.Indexes, &index)
+			}
 			table.PushIndex(&index)
 		}
 		index := table.IndexMap[idx.Name]
-		index.Columns = append(index.Columns, column)
+		index.AddPart(&dbal.IndexPart{
+			Column:     column,
+			Expression: idx.Expression,
+			Order:      idx.Order,
+			Length:     idx.SubPart,
+			OpClass:    idx.OpClass,
+		})
 
 		if index.Type == "primary" {
 			primaryKeyName = idx.Name
@@ -223,6 +286,35 @@ func (grammarSQL SQLite3) GetTable(name string) (*dbal.Table, error) {
 		}
 	}
 
+	// attaching constraints
+	for _, c := range constraints {
+		if !table.HasConstraint(c.Name) {
+			constraint := *c
+			constraint.Table = table
+			constraint.Columns = []*dbal.Column{}
+			table.PushConstraint(&constraint)
+		}
+		if column, has := table.ColumnMap[c.ColumnName]; has {
+			table.ConstraintMap[c.Name].AddColumn(column)
+		}
+	}
+
+	// attaching foreign keys
+	for _, fk := range foreignKeys {
+		if !table.HasColumn(fk.ColumnName) {
+			return nil, fmt.Errorf("the column %s does not exists", fk.ColumnName)
+		}
+		if !table.HasForeignKey(fk.Name) {
+			foreign := *fk
+			foreign.Table = table
+			foreign.Columns = []*dbal.Column{}
+			foreign.ReferencedColumns = []string{}
+			table.PushForeignKey(&foreign)
+		}
+		foreign := table.ForeignKeyMap[fk.Name]
+		foreign.AddColumn(table.ColumnMap[fk.ColumnName], fk.ReferencedColumnName)
+	}
+
 	return table, nil
 }
 
@@ -231,7 +323,8 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 	selectColumns := []string{
 		"m.`tbl_name` AS `table_name`",
 		"il.`name` AS `index_name`",
-		"ii.`name` AS `column_name`",
+		"IFNULL(ii.`name`, '') AS `column_name`",
+		"CASE WHEN ii.`desc` = 1 THEN 'DESC' ELSE '' END AS `sort_order`",
 		`CASE 
 			WHEN il.origin = 'pk' then 'primary' 
 			WHEN il.[unique] = 1  THEN 'unique'
@@ -250,14 +343,17 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 			SELECT %s
 				FROM sqlite_master AS m,
 				pragma_index_list(m.name) AS il,
-				pragma_index_info(il.name) AS ii
+				pragma_index_xinfo(il.name) AS ii
 			WHERE 
 				m.type = 'table'
 				and m.tbl_name = %s
+				and il.origin <> 'u'
+				and ii.key = 1
 			GROUP BY
 				m.tbl_name,
 				il.name,
 				ii.name,
+				ii.seqno,
 				il.origin,
 				il.partial,
 				il.seq
@@ -266,6 +362,7 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 				%s as table_name, 
 				'PRIMARY' as index_name, 
 				ti.name as column_name,
+				'' as sort_order,
 				"primary" as index_type,
 				1 as `+"`unique`"+`,
 				0 as `+"`seq_in_index`"+`,
@@ -285,8 +382,20 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 		return nil, err
 	}
 
+	// the expressions and the predicates are parsed from the index definitions
+	definitions, err := grammarSQL.getIndexDefinitions(tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	// counting the type of indexes
 	for _, index := range indexes {
+		if definition, has := definitions[index.Name]; has {
+			index.Predicate = definition.predicate
+			if index.ColumnName == "" && index.SeqColumn < len(definition.parts) {
+				index.Expression = definition.parts[index.SeqColumn]
+			}
+		}
 		index.Nullable = true
 		index.DBName = dbName
 		index.Type = index.IndexType
@@ -296,6 +405,73 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 	return indexes, nil
 }
 
+// GetForeignKeyListing get a table foreign keys structure
+func (grammarSQL SQLite3) GetForeignKeyListing(dbName string, tableName string) ([]*dbal.ForeignKey, error) {
+	selectColumns := []string{
+		"CAST(fk.`id` AS TEXT) AS `constraint_name`",
+		"fk.`seq` AS `seq_in_key`",
+		"fk.`table` AS `referenced_table_name`",
+		"fk.`from` AS `column_name`",
+		"IFNULL(fk.`to`, '') AS `referenced_column_name`",
+		"fk.`on_delete` AS `on_delete`",
+		"fk.`on_update` AS `on_update`",
+	}
+	sql := fmt.Sprintf(`
+			SELECT %s
+			FROM pragma_foreign_key_list(%s) AS fk
+			ORDER BY fk.id, fk.seq
+		`,
+		strings.Join(selectColumns, ","),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	foreignKeys := []*dbal.ForeignKey{}
+	err := grammarSQL.DB.Select(&foreignKeys, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(foreignKeys) == 0 {
+		return foreignKeys, nil
+	}
+
+	// the pragma does not return the constraint name, parse it from the table definition
+	rows := []string{}
+	err = grammarSQL.DB.Select(&rows, "SELECT `sql` FROM sqlite_master WHERE type='table' and name=?", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	if len(rows) > 0 {
+		re := regexp.MustCompile("(?i)CONSTRAINT\\s+[`\"]?([0-9a-zA-Z_]+)[`\"]?\\s+FOREIGN KEY\\s*\\(([^)]*)\\)")
+		for _, matched := range re.FindAllStringSubmatch(rows[0], -1) {
+			columns := strings.Split(matched[2], ",")
+			for i := range columns {
+				columns[i] = strings.Trim(columns[i], " `\"")
+			}
+			names[strings.Join(columns, ",")] = matched[1]
+		}
+	}
+
+	columns := map[string][]string{}
+	for _, foreign := range foreignKeys {
+		columns[foreign.Name] = append(columns[foreign.Name], foreign.ColumnName)
+	}
+
+	for _, foreign := range foreignKeys {
+		id := foreign.Name
+		name, has := names[strings.Join(columns[id], ",")]
+		if !has {
+			name = fmt.Sprintf("%s_%s_foreign", tableName, strings.Join(columns[id], "_"))
+		}
+		foreign.Name = name
+		foreign.DBName = dbName
+		foreign.TableName = tableName
+	}
+	return foreignKeys, nil
+}
+
 // GetColumnListing get a table columns structure
 func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string) ([]*dbal.Column, error) {
 	selectColumns := []string{
@@ -319,13 +495,15 @@ func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string)
 		END AS ` + "`primary`",
 		`CASE
 			WHEN p.pk = 1 and INSTR(m.sql, 'AUTOINCREMENT' ) THEN "AutoIncrement"
+			WHEN p.hidden = 2 THEN "VirtualGenerated"
+			WHEN p.hidden = 3 THEN "StoredGenerated"
 			ELSE ""
 		END AS ` + "`extra`",
 	}
 	sql := fmt.Sprintf(`
 			SELECT %s
 			FROM sqlite_master m
-			LEFT OUTER JOIN pragma_table_info((m.name)) p  ON m.name <> p.name
+			LEFT OUTER JOIN pragma_table_xinfo((m.name)) p  ON m.name <> p.name
 			WHERE m.type = 'table' and table_name=%s
 		`,
 		strings.Join(selectColumns, ","),
@@ -344,10 +522,26 @@ func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string)
 		return nil, err
 	}
 
+	// Get the expressions of the generated columns
+	generations, err := grammarSQL.getGenerationExpressions(tableName, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the columns set by the on update trigger
+	onUpdates, err := grammarSQL.getOnUpdateColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cast the database data type to DBAL data type
 	for _, column := range columns {
 		grammarSQL.ParseType(column)
 		column.DBName = schemaName
+		column.OnUpdateCurrent = onUpdates[column.Name]
+		if column.IsGenerated() {
+			column.GenerationExpression = generations[column.Name]
+		}
 		constraint, has := constraints[column.Name]
 		if has {
 			column.Constraint = constraint
@@ -367,6 +561,96 @@ func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string)
 	return columns, nil
 }
 
+// the key parts and the predicate of an index definition
+type indexDefinition struct {
+	parts     []string
+	predicate string
+}
+
+// getIndexDefinitions get the key parts and the predicates of the table indexes, they are parsed from the CREATE INDEX statements
+func (grammarSQL SQLite3) getIndexDefinitions(tableName string) (map[string]indexDefinition, error) {
+	rows := []struct {
+		Name string `db:"name"`
+		SQL  string `db:"sql"`
+	}{}
+	err := grammarSQL.DB.Select(&rows, "SELECT `name`, `sql` FROM sqlite_master WHERE type='index' AND `sql` IS NOT NULL AND tbl_name=?", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := map[string]indexDefinition{}
+	for _, row := range rows {
+		parts, tail, err := parseTableDefinitions(row.SQL)
+		if err != nil {
+			return nil, err
+		}
+
+		definition := indexDefinition{}
+		for _, part := range parts {
+			part = regexp.MustCompile(`(?i)\s+(ASC|DESC)$`).ReplaceAllString(part, "")
+			definition.parts = append(definition.parts, unwrapParentheses(part))
+		}
+		if matched := regexp.MustCompile(`(?is)^\s*WHERE\s+(.+)$`).FindStringSubmatch(tail); matched != nil {
+			definition.predicate = strings.TrimSpace(matched[1])
+		}
+		definitions[row.Name] = definition
+	}
+	return definitions, nil
+}
+
+// unwrapParentheses remove the parentheses wrapping the whole expression, e.g. (lower(email)) => lower(email)
+func unwrapParentheses(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return expression
+	}
+
+	depth := 0
+	for i, c := range expression {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(expression)-1 {
+				return expression
+			}
+		}
+	}
+	return strings.TrimSpace(expression[1 : len(expression)-1])
+}
+
+// getGenerationExpressions get the expressions of the generated columns, they are parsed from the table definition
+func (grammarSQL SQLite3) getGenerationExpressions(tableName string, columns []*dbal.Column) (map[string]string, error) {
+	generations := map[string]string{}
+	generated := false
+	for _, column := range columns {
+		generated = generated || column.IsGenerated()
+	}
+	if !generated {
+		return generations, nil
+	}
+
+	create := ""
+	err := grammarSQL.DB.Get(&create, "SELECT `sql` FROM sqlite_master WHERE type='table' AND name=?", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, _, err := parseTableDefinitions(create)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definition := range definitions {
+		name := definitionColumn(definition)
+		if name != "" {
+			generations[name] = definitionGeneration(definition)
+		}
+	}
+	return generations, nil
+}
+
 // AlterTable alter a table on the schema
 func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 
@@ -386,11 +670,16 @@ func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 	for _, command := range table.Commands {
 		switch command.Name {
 		case "AddColumn":
-			column := command.Params[0].(*dbal.Column)
+			// sqlite3 cannot add a PRIMARY KEY column, the primary key is created by the CreatePrimary command
+			column := *command.Params[0].(*dbal.Column)
+			column.Primary = false
 			stmt := ""
-			stmt = sql + "ADD COLUMN " + grammarSQL.SQLAddColumn(column)
+			stmt = sql + "ADD COLUMN " + grammarSQL.SQLAddColumn(&column)
 			stmts = append(stmts, stmt)
 			err := grammarSQL.ExecSQL(table, stmt)
+			if err == nil && column.OnUpdateCurrent {
+				err = grammarSQL.syncOnUpdateTrigger(table, onUpdateColumns(table, column.Name, true), &stmts)
+			}
 			if err != nil {
 				errs = append(errs, errors.New("SQL: "+stmt+" ERROR: "+err.Error()))
 			}
@@ -399,6 +688,7 @@ func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 		case "RenameColumn":
 			old := command.Params[0].(string)
 			new := command.Params[1].(string)
+			onUpdate := table.HasColumn(old) && table.ColumnMap[old].OnUpdateCurrent
 			stmt := fmt.Sprintf("%s RENAME COLUMN %s TO %s",
 				sql,
 				grammarSQL.ID(old),
@@ -406,6 +696,10 @@ func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 			)
 			stmts = append(stmts, stmt)
 			err := grammarSQL.ExecSQL(table, stmt)
+
+			if err == nil && onUpdate {
+				err = grammarSQL.syncOnUpdateTrigger(table, onUpdateColumns(table, new, true), &stmts)
+			}
 			if err != nil {
 				errs = append(errs, errors.New("SQL: "+stmt+" ERROR: "+err.Error()))
 			}
@@ -431,8 +725,32 @@ func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 			}
 			command.Callback(err)
 			break
-		case "DropColumn", "ChangeColumn", "DropPrimary", "RenameIndex":
-			log.Warn("sqlite3 not support %s operation", command.Name)
+		case "DropColumn":
+			grammarSQL.alterTableDropColumn(table, command, sql, &stmts, &errs)
+			break
+		case "ChangeColumn":
+			grammarSQL.alterTableChangeColumn(table, command, sql, &stmts, &errs)
+			break
+		case "RenameIndex":
+			grammarSQL.alterTableRenameIndex(table, command, sql, &stmts, &errs)
+			break
+		case "CreatePrimary":
+			grammarSQL.alterTableCreatePrimary(table, command, sql, &stmts, &errs)
+			break
+		case "DropPrimary":
+			grammarSQL.alterTableDropPrimary(table, command, sql, &stmts, &errs)
+			break
+		case "CreateForeign":
+			grammarSQL.alterTableCreateForeign(table, command, sql, &stmts, &errs)
+			break
+		case "DropForeign":
+			grammarSQL.alterTableDropForeign(table, command, sql, &stmts, &errs)
+			break
+		case "CreateConstraint":
+			grammarSQL.alterTableCreateConstraint(table, command, sql, &stmts, &errs)
+			break
+		case "DropConstraint":
+			grammarSQL.alterTableDropConstraint(table, command, sql, &stmts, &errs)
 			break
 		}
 	}
@@ -474,9 +792,54 @@ func (grammarSQL SQLite3) GetConstraintListing(schemaName string, tableName stri
 	return constraints, nil
 }
 
+// GetTableConstraintListing get a table unique and check constraints structure
+func (grammarSQL SQLite3) GetTableConstraintListing(schemaName string, tableName string) ([]*dbal.Constraint, error) {
+	rows := []string{}
+	err := grammarSQL.DB.Select(&rows, "SELECT `sql` FROM sqlite_master WHERE type='table' and name=?", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) < 1 {
+		return nil, fmt.Errorf("the table %s does not exists", tableName)
+	}
+
+	// CONSTRAINT `sku_unique` UNIQUE (`sku`),
+	// CONSTRAINT `price_check` CHECK (price >= 0)
+	re := regexp.MustCompile("(?i)^\\s*CONSTRAINT\\s+[`\"]?([0-9a-zA-Z_]+)[`\"]?\\s+(UNIQUE|CHECK)\\s*\\((.*)\\)\\s*,?\\s*$")
+	constraints := []*dbal.Constraint{}
+	for _, line := range strings.Split(rows[0], "\n") {
+		matched := re.FindStringSubmatch(line)
+		if len(matched) != 4 {
+			continue
+		}
+
+		typ := strings.ToUpper(matched[2])
+		if typ == "CHECK" {
+			constraint := dbal.NewConstraint(schemaName, tableName, "")
+			constraint.Name = matched[1]
+			constraint.Type = typ
+			constraint.Args = append(constraint.Args, strings.TrimSpace(matched[3]))
+			constraints = append(constraints, constraint)
+			continue
+		}
+
+		for _, column := range strings.Split(matched[3], ",") {
+			constraint := dbal.NewConstraint(schemaName, tableName, strings.Trim(column, " `\""))
+			constraint.Name = matched[1]
+			constraint.Type = typ
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints, nil
+}
+
 func (grammarSQL SQLite3) parseConstraint(schemaName string, tableName string, line string) *dbal.Constraint {
 	// fmt.Printf("GetConstraintListing Line: %#v\n", line)
-	if strings.Contains(line, "CHECK(") {
+	if strings.HasPrefix(strings.TrimSpace(line), "CONSTRAINT") {
+		// the table constraints, see GetTableConstraintListing
+		return nil
+	} else if strings.Contains(line, "CHECK(") {
 		re := regexp.MustCompile("`([0-9a-zA-Z_]+)` .* CHECK\\((.*)\\)")
 		matched := re.FindStringSubmatch(line)
 		if len(matched) == 3 {
@@ -499,8 +862,8 @@ func (grammarSQL SQLite3) parseConstraint(schemaName string, tableName string, l
 
 // ExecSQL execute sql then update table structure
 func (grammarSQL SQLite3) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
-	if err != nil {
+	err := grammarSQL.Exec(sql)
+	if err != nil || grammarSQL.IsPretend() {
 		return err
 	}
 	// update table structure
@@ -566,3 +929,22 @@ func (grammarSQL SQLite3) ParseType(column *dbal.Column) {
 	}
 	// utils.Println(column)
 }
+
+// NormalizeColumn describe the column as SQLite creates and introspects it, the given column is not changed.
+// SQLite creates the NOT NULL columns without default as NULL and the NOT NULL timestamps with the current time default,
+// keeps the unsigned attribute of the big integers only, creates the auto-increment columns as INTEGER and ignores the comments.
+func (grammarSQL SQLite3) NormalizeColumn(column *dbal.Column) *dbal.Column {
+	normalized := *column
+	hasDefault := column.Default != nil || column.DefaultRaw != ""
+	if strings.Contains(column.Type, "timestamp") && !column.Nullable && !hasDefault {
+		normalized.DefaultRaw = "NOW()"
+	} else if !column.Nullable && !hasDefault {
+		normalized.Nullable = true
+	}
+	normalized.IsUnsigned = column.IsUnsigned && column.Type == "bigInteger"
+	if utils.StringVal(column.Extra) == "AutoIncrement" {
+		normalized.Type = "integer"
+	}
+	normalized.Comment = nil
+	return &normalized
+}
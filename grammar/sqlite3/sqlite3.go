@@ -38,7 +38,8 @@ func init() {
 // Setup the method will be executed when db server was connected
 func (grammarSQL *SQLite3) setup(db *sqlx.DB, config *dbal.Config, option *dbal.Option) error {
 
-	if db == nil {
+	// the grammar without a database only collects the statements in the pretend mode, e.g. dumping to another driver
+	if db == nil && (option == nil || option.Pretend == nil) {
 		return fmt.Errorf("db is nil")
 	}
 
@@ -49,6 +50,10 @@ func (grammarSQL *SQLite3) setup(db *sqlx.DB, config *dbal.Config, option *dbal.
 	grammarSQL.DB = db
 	grammarSQL.Config = config
 	grammarSQL.Option = option
+	if config.DSN == "" {
+		return nil
+	}
+
 	uinfo, err := url.Parse(grammarSQL.Config.DSN)
 	if err != nil {
 		return err
@@ -91,8 +96,9 @@ func New(opts ...sql.Option) dbal.Grammar {
 		sqlite.Driver = "sqlite3"
 	}
 	sqlite.IndexTypes = map[string]string{
-		"unique": "UNIQUE INDEX",
-		"index":  "INDEX",
+		"unique":  "UNIQUE INDEX",
+		"index":   "INDEX",
+		"spatial": "INDEX",
 	}
 
 	// overwrite types
@@ -0,0 +1,127 @@
+package sqlite3
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// sqlite3 has no ON UPDATE CURRENT_TIMESTAMP and can not change the NEW row in a trigger, the columns are updated
+// by one AFTER UPDATE trigger of the table named {table}_on_update. The trigger of each column can not be used,
+// the UPDATE statement of one trigger fires the others.
+
+// the columns set by the on update trigger, e.g. `updated_at` = CASE WHEN NEW.`updated_at` IS OLD.`updated_at`
+var reOnUpdateColumn = regexp.MustCompile("[`\"]([^`\"]+)[`\"] = CASE WHEN NEW\\.")
+
+// onUpdateTriggerName get the name of the on update trigger of the table
+func onUpdateTriggerName(tableName string) string {
+	return fmt.Sprintf("%s_on_update", tableName)
+}
+
+// SQLCreateOnUpdateTrigger return the statement of creating the trigger that sets the columns to the current time when the row is updated,
+// the column is set only if the update does not change it.
+func (grammarSQL SQLite3) SQLCreateOnUpdateTrigger(tableName string, columns []string) string {
+	conditions := []string{}
+	sets := []string{}
+	for _, name := range columns {
+		column := grammarSQL.ID(name)
+		conditions = append(conditions, fmt.Sprintf("NEW.%s IS OLD.%s", column, column))
+		sets = append(sets, fmt.Sprintf("%s = CASE WHEN NEW.%s IS OLD.%s THEN datetime('now','localtime') ELSE %s END", column, column, column, column))
+	}
+	return fmt.Sprintf(
+		"CREATE TRIGGER %s AFTER UPDATE ON %s FOR EACH ROW WHEN %s BEGIN UPDATE %s SET %s WHERE rowid = NEW.rowid; END",
+		grammarSQL.ID(onUpdateTriggerName(tableName)), grammarSQL.ID(tableName), strings.Join(conditions, " OR "),
+		grammarSQL.ID(tableName), strings.Join(sets, ", "),
+	)
+}
+
+// SQLDropOnUpdateTrigger return the statement of dropping the on update trigger of the table
+func (grammarSQL SQLite3) SQLDropOnUpdateTrigger(tableName string) string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s", grammarSQL.ID(onUpdateTriggerName(tableName)))
+}
+
+// getOnUpdateColumns get the columns set by the on update trigger of the table, they are parsed from the CREATE TRIGGER statement
+func (grammarSQL SQLite3) getOnUpdateColumns(tableName string) (map[string]bool, error) {
+	sql := fmt.Sprintf("SELECT `sql` FROM sqlite_master WHERE type = 'trigger' AND tbl_name = %s AND `name` LIKE %s",
+		grammarSQL.VAL(tableName), grammarSQL.VAL("%_on_update"))
+	defer log.Debug(sql)
+	rows := []string{}
+	err := grammarSQL.DB.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]bool{}
+	for _, create := range rows {
+		for _, matched := range reOnUpdateColumn.FindAllStringSubmatch(create, -1) {
+			columns[matched[1]] = true
+		}
+	}
+	return columns, nil
+}
+
+// syncOnUpdateTrigger recreate the on update trigger of the table using the given columns, the trigger is dropped if no column given
+func (grammarSQL SQLite3) syncOnUpdateTrigger(table *dbal.Table, columns []string, stmts *[]string) error {
+	sqls := []string{grammarSQL.SQLDropOnUpdateTrigger(table.TableName)}
+	if len(columns) > 0 {
+		sqls = append(sqls, grammarSQL.SQLCreateOnUpdateTrigger(table.TableName, columns))
+	}
+
+	for _, sql := range sqls {
+		*stmts = append(*stmts, sql)
+		err := grammarSQL.ExecSQL(table, sql)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onUpdateColumns get the on update columns of the table, the given column is added or removed
+func onUpdateColumns(table *dbal.Table, name string, onUpdate bool) []string {
+	columns := []string{}
+	for _, column := range table.Columns {
+		if column.OnUpdateCurrent && column.Name != name {
+			columns = append(columns, column.Name)
+		}
+	}
+	if onUpdate {
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// renameOnUpdateTrigger recreate the on update trigger of the renamed table, the trigger name contains the table name
+func (grammarSQL SQLite3) renameOnUpdateTrigger(old string, new string) error {
+	if grammarSQL.IsPretend() {
+		return nil
+	}
+
+	columns, err := grammarSQL.getOnUpdateColumns(new)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+
+	names := []string{}
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stmts := []string{
+		grammarSQL.SQLDropOnUpdateTrigger(old),
+		grammarSQL.SQLCreateOnUpdateTrigger(new, names),
+	}
+	for _, stmt := range stmts {
+		defer log.Debug(stmt)
+		err = grammarSQL.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
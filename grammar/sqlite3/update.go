@@ -56,9 +56,9 @@ func (grammarSQL SQLite3) CompileUpdate(query *dbal.Query, values map[string]int
 	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, values, &offset)
 	bindings = append(bindings, columnsBindings...)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", alias)}
+	qualifier := grammarSQL.FromQualifier(query)
+	if qualifier != "" {
+		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", qualifier)}
 	} else {
 		query.Columns = []interface{}{"rowid"}
 	}
@@ -0,0 +1,56 @@
+package sqlite3
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// the select statement of the CREATE VIEW statement kept by sqlite_master
+var reViewDefinition = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?VIEW\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:"[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|\S+)\s*(?:\([^)]*\)\s*)?AS\s+(.*)$`)
+
+// GetViews Get all of the views of the database, the definitions are read from the catalog.
+func (grammarSQL SQLite3) GetViews() ([]*dbal.View, error) {
+	sql := "SELECT `name` AS `view_name`, `sql` AS `view_definition` FROM `sqlite_master` WHERE type='view' ORDER BY `name`"
+	defer log.Debug(sql)
+	views := []*dbal.View{}
+	err := grammarSQL.DB.Select(&views, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, view := range views {
+		view.DBName = grammarSQL.GetDatabase()
+		view.SchemaName = grammarSQL.GetSchema()
+		if matches := reViewDefinition.FindStringSubmatch(view.SQL); matches != nil {
+			view.SQL = matches[1]
+		}
+	}
+	return views, nil
+}
+
+// CreateView create a new view using the select statement, the existing view is dropped first if replace is true.
+func (grammarSQL SQLite3) CreateView(view *dbal.View, options ...dbal.ViewOption) error {
+	option := dbal.ViewOption{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	if option.Materialized {
+		return fmt.Errorf("the materialized view is not supported by %s", grammarSQL.Driver)
+	}
+
+	if option.Replace {
+		err := grammarSQL.DropViewIfExists(view.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := fmt.Sprintf("CREATE VIEW %s AS %s", grammarSQL.ID(view.Name), sql.Interpolate(view.SQL, view.Bindings, sql.QuoteString))
+	defer log.Debug(stmt)
+	return grammarSQL.Exec(stmt)
+}
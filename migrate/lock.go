@@ -0,0 +1,160 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+)
+
+// the name of the migration lock row
+const lockName = "migrate"
+
+// the interval of trying to acquire the lock
+var lockInterval = 200 * time.Millisecond
+
+// Lock acquire the migration lock, it waits for the lock held by another process until LockTimeout.
+// The lock is a row of the lock table, so it works across the processes and the hosts share the database.
+// Return the owner of the lock, use it to renew and release the lock. The lock expires after LockExpire unless it is renewed,
+// the migrating methods renew it in the background.
+func (migrator *Migrator) Lock() (string, error) {
+	err := migrator.prepareLockTable()
+	if err != nil {
+		return "", err
+	}
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), time.Now().UnixNano())
+	deadline := time.Now().Add(migrator.LockTimeout)
+	for {
+		// clean the stale lock that the process crashed
+		_, err = migrator.Query.New().Table(migrator.LockTable).
+			Where("name", lockName).
+			Where("expired_at", "<", time.Now().Unix()).
+			Delete()
+		if err != nil {
+			return "", err
+		}
+
+		err = migrator.Query.New().Table(migrator.LockTable).Insert(map[string]interface{}{
+			"name":       lockName,
+			"owner":      owner,
+			"expired_at": time.Now().Add(migrator.LockExpire).Unix(),
+		})
+		if err == nil {
+			return owner, nil
+		}
+
+		if !errors.Is(err, dbal.ErrUniqueViolation) {
+			return "", err
+		}
+
+		if time.Now().After(deadline) {
+			return "", ErrLocked
+		}
+		time.Sleep(lockInterval)
+	}
+}
+
+// Unlock release the migration lock held by the given owner
+func (migrator *Migrator) Unlock(owner string) error {
+	_, err := migrator.Query.New().Table(migrator.LockTable).
+		Where("name", lockName).
+		Where("owner", owner).
+		Delete()
+	return err
+}
+
+// Renew extend the expiry of the migration lock held by the given owner, return ErrLocked if the lock is not held by the owner.
+func (migrator *Migrator) Renew(owner string) error {
+	lock := migrator.Query.New().Table(migrator.LockTable).Where("name", lockName).Where("owner", owner)
+	affected, err := lock.Update(map[string]interface{}{
+		"expired_at": time.Now().Add(migrator.LockExpire).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// MySQL does not count the rows not changed
+	if affected == 0 {
+		held, err := migrator.Query.New().Table(migrator.LockTable).Where("name", lockName).Where("owner", owner).Exists()
+		if err != nil {
+			return err
+		}
+		if !held {
+			return ErrLocked
+		}
+	}
+	return nil
+}
+
+// withLock run the callback while holding the migration lock, the lock is renewed until the callback returns
+func (migrator *Migrator) withLock(callback func() error) error {
+	owner, err := migrator.Lock()
+	if err != nil {
+		return err
+	}
+	defer migrator.Unlock(owner)
+
+	stop := migrator.heartbeat(owner)
+	defer stop()
+	return callback()
+}
+
+// heartbeat renew the lock every third of LockExpire in the background, return the function to stop it
+func (migrator *Migrator) heartbeat(owner string) func() {
+	interval := migrator.LockExpire / 3
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := migrator.Renew(owner)
+				if err != nil {
+					log.Warn("the migration lock of %s is not renewed: %s", owner, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// prepareLockTable create the lock table if it does not exist
+func (migrator *Migrator) prepareLockTable() error {
+	has, err := migrator.Schema.HasTable(migrator.LockTable)
+	if err != nil || has {
+		return err
+	}
+
+	err = migrator.Schema.CreateTable(migrator.LockTable, func(table schema.Blueprint) {
+		table.String("name", 64).Primary()
+		table.String("owner", 200)
+		table.BigInteger("expired_at")
+	})
+
+	// the table may be created by another process at the same time
+	if err != nil {
+		if has, _ := migrator.Schema.HasTable(migrator.LockTable); has {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,272 @@
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/utils"
+)
+
+// New create a new migrator using the given schema and query builders, the migrations of the global registry are loaded.
+//
+//	migrator := migrate.New(capsule.Schema(), capsule.Query())
+//	migrated, err := migrator.Migrate()
+func New(sch schema.Schema, qb query.Query) *Migrator {
+	return &Migrator{
+		Schema:      sch,
+		Query:       qb,
+		Table:       "migrations",
+		LockTable:   "migrations_lock",
+		LockTimeout: 60 * time.Second,
+		LockExpire:  time.Hour,
+		Migrations:  Registered(),
+	}
+}
+
+// Migrate run the pending migrations in a new batch, return the names of the migrations have been run.
+func (migrator *Migrator) Migrate() ([]string, error) {
+	migrated := []string{}
+	err := migrator.withLock(func() error {
+		var err error
+		migrated, err = migrator.migrate()
+		return err
+	})
+	return migrated, err
+}
+
+// MustMigrate run the pending migrations in a new batch, return the names of the migrations have been run.
+func (migrator *Migrator) MustMigrate() []string {
+	migrated, err := migrator.Migrate()
+	utils.PanicIF(err)
+	return migrated
+}
+
+// Rollback roll back the last given batches of migrations (1 batch if steps less than 1),
+// return the names of the migrations have been rolled back.
+func (migrator *Migrator) Rollback(steps int) ([]string, error) {
+	if steps < 1 {
+		steps = 1
+	}
+	return migrator.rollbackWithLock(steps)
+}
+
+// MustRollback roll back the last given batches of migrations (1 batch if steps less than 1),
+// return the names of the migrations have been rolled back.
+func (migrator *Migrator) MustRollback(steps int) []string {
+	rolledBack, err := migrator.Rollback(steps)
+	utils.PanicIF(err)
+	return rolledBack
+}
+
+// Reset roll back all the migrations, return the names of the migrations have been rolled back.
+func (migrator *Migrator) Reset() ([]string, error) {
+	return migrator.rollbackWithLock(0)
+}
+
+// MustReset roll back all the migrations, return the names of the migrations have been rolled back.
+func (migrator *Migrator) MustReset() []string {
+	rolledBack, err := migrator.Reset()
+	utils.PanicIF(err)
+	return rolledBack
+}
+
+// Refresh roll back all the migrations and run them again, return the names of the migrations have been run.
+// The lock is held until the migrations are run again, the other migrators can not run between them.
+func (migrator *Migrator) Refresh() ([]string, error) {
+	migrated := []string{}
+	err := migrator.withLock(func() error {
+		_, err := migrator.rollback(0)
+		if err != nil {
+			return err
+		}
+		migrated, err = migrator.migrate()
+		return err
+	})
+	return migrated, err
+}
+
+// MustRefresh roll back all the migrations and run them again, return the names of the migrations have been run.
+func (migrator *Migrator) MustRefresh() []string {
+	migrated, err := migrator.Refresh()
+	utils.PanicIF(err)
+	return migrated
+}
+
+// Status get the status of the migrations, the registered ones are sorted by name,
+// the ones have been run but not registered are appended.
+func (migrator *Migrator) Status() ([]Status, error) {
+	err := migrator.prepareTable()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := migrator.records()
+	if err != nil {
+		return nil, err
+	}
+
+	batches := map[string]int{}
+	for _, record := range records {
+		batches[record.Migration] = record.Batch
+	}
+
+	status := []Status{}
+	for _, name := range migrator.Names() {
+		batch, ran := batches[name]
+		status = append(status, Status{Name: name, Ran: ran, Batch: batch})
+	}
+
+	for _, record := range records {
+		if _, has := migrator.Migrations[record.Migration]; !has {
+			status = append(status, Status{Name: record.Migration, Ran: true, Batch: record.Batch})
+		}
+	}
+	return status, nil
+}
+
+// MustStatus get the status of the migrations
+func (migrator *Migrator) MustStatus() []Status {
+	status, err := migrator.Status()
+	utils.PanicIF(err)
+	return status
+}
+
+// rollbackWithLock roll back the last given batches of migrations while holding the lock, roll back all if steps is 0
+func (migrator *Migrator) rollbackWithLock(steps int) ([]string, error) {
+	rolledBack := []string{}
+	err := migrator.withLock(func() error {
+		var err error
+		rolledBack, err = migrator.rollback(steps)
+		return err
+	})
+	return rolledBack, err
+}
+
+// migrate run the pending migrations in a new batch, the lock should be held
+func (migrator *Migrator) migrate() ([]string, error) {
+	migrated := []string{}
+	records, err := migrator.records()
+	if err != nil {
+		return migrated, err
+	}
+
+	ran := map[string]bool{}
+	batch := 0
+	for _, record := range records {
+		ran[record.Migration] = true
+		if record.Batch > batch {
+			batch = record.Batch
+		}
+	}
+	batch++
+
+	for _, name := range migrator.Names() {
+		if ran[name] {
+			continue
+		}
+
+		err = migrator.run(name, "up")
+		if err != nil {
+			return migrated, err
+		}
+
+		err = migrator.Query.New().Table(migrator.Table).Insert(map[string]interface{}{
+			"migration": name,
+			"batch":     batch,
+		})
+		if err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, name)
+	}
+	return migrated, nil
+}
+
+// rollback roll back the last given batches of migrations, roll back all if steps is 0, the lock should be held
+func (migrator *Migrator) rollback(steps int) ([]string, error) {
+	rolledBack := []string{}
+	records, err := migrator.records()
+	if err != nil {
+		return rolledBack, err
+	}
+
+	// the records are sorted by batch and id, roll back from the last one
+	batches := 0
+	last := -1
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.Batch != last {
+			batches++
+			last = record.Batch
+		}
+		if steps > 0 && batches > steps {
+			break
+		}
+
+		if _, has := migrator.Migrations[record.Migration]; !has {
+			return rolledBack, fmt.Errorf("the migration %s not found", record.Migration)
+		}
+
+		err = migrator.run(record.Migration, "down")
+		if err != nil {
+			return rolledBack, err
+		}
+
+		_, err = migrator.Query.New().Table(migrator.Table).Where("id", record.ID).Delete()
+		if err != nil {
+			return rolledBack, err
+		}
+		rolledBack = append(rolledBack, record.Migration)
+	}
+	return rolledBack, nil
+}
+
+// run the up or down method of the migration, the panic is returned as an error
+func (migrator *Migrator) run(name string, method string) (err error) {
+	migration := migrator.Migrations[name]
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("migration %s %s: %v", name, method, r)
+		}
+	}()
+
+	log.Info("migration %s %s", name, method)
+	if method == "down" {
+		migration.Down(migrator.Schema)
+		return nil
+	}
+	migration.Up(migrator.Schema)
+	return nil
+}
+
+// records get the migrations have been run, sorted by batch and id
+func (migrator *Migrator) records() ([]Record, error) {
+	err := migrator.prepareTable()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []Record{}
+	_, err = migrator.Query.New().Table(migrator.Table).
+		Select("id", "migration", "batch").
+		OrderBy("batch").
+		OrderBy("id").
+		Get(&records)
+	return records, err
+}
+
+// prepareTable create the migrations bookkeeping table if it does not exist
+func (migrator *Migrator) prepareTable() error {
+	has, err := migrator.Schema.HasTable(migrator.Table)
+	if err != nil || has {
+		return err
+	}
+	return migrator.Schema.CreateTable(migrator.Table, func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("migration", 200).Unique()
+		table.Integer("batch").Index()
+	})
+}
@@ -0,0 +1,200 @@
+package migrate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/dbal/schema"
+	_ "github.com/yaoapp/xun/grammar/mysql"    // Load the MySQL Grammar
+	_ "github.com/yaoapp/xun/grammar/postgres" // Load the Postgres Grammar
+	_ "github.com/yaoapp/xun/grammar/sqlite3"  // Load the SQLite3 Grammar
+	"github.com/yaoapp/xun/unit"
+)
+
+var testSchema schema.Schema = nil
+var testQuery query.Query = nil
+
+func getTestMigrator() *Migrator {
+	defer unit.Catch()
+	unit.SetLogger()
+	if testSchema == nil {
+		testSchema = schema.New(unit.Driver(), unit.DSN())
+		testQuery = query.New(unit.Driver(), unit.DSN())
+	}
+	migrator := New(testSchema, testQuery)
+	migrator.Table = "table_test_migrations"
+	migrator.LockTable = "table_test_migrations_lock"
+	migrator.Migrations = map[string]Migration{}
+	migrator.Register("2021_01_01_000000_create_users", &testCreateTable{name: "table_test_migrate_users"})
+	migrator.Register("2021_01_02_000000_create_posts", &testCreateTable{name: "table_test_migrate_posts"})
+	return migrator
+}
+
+type testCreateTable struct{ name string }
+
+func (migration *testCreateTable) Up(sch schema.Schema) {
+	sch.MustCreateTable(migration.name, func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name", 80)
+	})
+}
+
+func (migration *testCreateTable) Down(sch schema.Schema) {
+	sch.MustDropTableIfExists(migration.name)
+}
+
+type testFailMigration struct{}
+
+func (migration *testFailMigration) Up(sch schema.Schema) {
+	panic(fmt.Errorf("the migration failed"))
+}
+
+func (migration *testFailMigration) Down(sch schema.Schema) {}
+
+func TestMigrateMigrate(t *testing.T) {
+	defer unit.Catch()
+	migrator := getTestMigrator()
+	TestMigrateClean(t)
+
+	migrated, err := migrator.Migrate()
+	assert.Nil(t, err, "the migrate should be succeed")
+	assert.Equal(t, []string{"2021_01_01_000000_create_users", "2021_01_02_000000_create_posts"}, migrated, "the migrations should run in order")
+	assert.True(t, migrator.Schema.MustHasTable("table_test_migrate_users"), "the users table should be created")
+	assert.True(t, migrator.Schema.MustHasTable("table_test_migrate_posts"), "the posts table should be created")
+
+	migrated, err = migrator.Migrate()
+	assert.Nil(t, err, "the migrate should be succeed")
+	assert.Equal(t, 0, len(migrated), "there should be no pending migrations")
+
+	migrator.Register("2021_01_03_000000_create_tags", &testCreateTable{name: "table_test_migrate_tags"})
+	migrated = migrator.MustMigrate()
+	assert.Equal(t, []string{"2021_01_03_000000_create_tags"}, migrated, "the new migration should run")
+
+	status := migrator.MustStatus()
+	assert.Equal(t, 3, len(status), "the status should have 3 migrations")
+	if len(status) == 3 {
+		assert.Equal(t, 1, status[0].Batch, "the first migration should be in batch 1")
+		assert.Equal(t, 1, status[1].Batch, "the second migration should be in batch 1")
+		assert.Equal(t, 2, status[2].Batch, "the third migration should be in batch 2")
+	}
+}
+
+func TestMigrateRollback(t *testing.T) {
+	defer unit.Catch()
+	TestMigrateMigrate(t)
+	migrator := getTestMigrator()
+	migrator.Register("2021_01_03_000000_create_tags", &testCreateTable{name: "table_test_migrate_tags"})
+
+	rolledBack, err := migrator.Rollback(1)
+	assert.Nil(t, err, "the rollback should be succeed")
+	assert.Equal(t, []string{"2021_01_03_000000_create_tags"}, rolledBack, "the last batch should be rolled back")
+	assert.False(t, migrator.Schema.MustHasTable("table_test_migrate_tags"), "the tags table should be dropped")
+
+	rolledBack = migrator.MustRollback(1)
+	assert.Equal(t, []string{"2021_01_02_000000_create_posts", "2021_01_01_000000_create_users"}, rolledBack, "the migrations should be rolled back in reverse order")
+	assert.False(t, migrator.Schema.MustHasTable("table_test_migrate_users"), "the users table should be dropped")
+
+	status := migrator.MustStatus()
+	for _, s := range status {
+		assert.False(t, s.Ran, "the migration %s should not be ran", s.Name)
+	}
+}
+
+func TestMigrateResetAndRefresh(t *testing.T) {
+	defer unit.Catch()
+	TestMigrateMigrate(t)
+	migrator := getTestMigrator()
+	migrator.Register("2021_01_03_000000_create_tags", &testCreateTable{name: "table_test_migrate_tags"})
+
+	migrated := migrator.MustRefresh()
+	assert.Equal(t, 3, len(migrated), "all migrations should run again")
+	for _, s := range migrator.MustStatus() {
+		assert.Equal(t, 1, s.Batch, "the migration %s should be in batch 1", s.Name)
+	}
+
+	rolledBack := migrator.MustReset()
+	assert.Equal(t, 3, len(rolledBack), "all migrations should be rolled back")
+	assert.False(t, migrator.Schema.MustHasTable("table_test_migrate_users"), "the users table should be dropped")
+}
+
+func TestMigrateFail(t *testing.T) {
+	defer unit.Catch()
+	migrator := getTestMigrator()
+	TestMigrateClean(t)
+	migrator.Register("2021_01_01_500000_fail", &testFailMigration{})
+
+	migrated, err := migrator.Migrate()
+	assert.NotNil(t, err, "the migrate should be failed")
+	assert.Equal(t, []string{"2021_01_01_000000_create_users"}, migrated, "the migrations before the failed one should be run")
+
+	status := migrator.MustStatus()
+	assert.True(t, status[0].Ran, "the first migration should be ran")
+	assert.False(t, status[1].Ran, "the failed migration should not be ran")
+
+	// the lock should be released
+	owner, err := migrator.Lock()
+	assert.Nil(t, err, "the lock should be released after failure")
+	migrator.Unlock(owner)
+}
+
+func TestMigrateLock(t *testing.T) {
+	defer unit.Catch()
+	migrator := getTestMigrator()
+	TestMigrateClean(t)
+
+	owner, err := migrator.Lock()
+	assert.Nil(t, err, "the lock should be acquired")
+
+	other := getTestMigrator()
+	other.LockTimeout = 300 * time.Millisecond
+	_, err = other.Migrate()
+	assert.Equal(t, ErrLocked, err, "the migrate should wait for the lock and fail")
+
+	// the stale lock is released
+	other.LockExpire = -time.Hour
+	err = migrator.Unlock(owner)
+	assert.Nil(t, err, "the lock should be released")
+	owner, err = other.Lock()
+	assert.Nil(t, err, "the lock should be acquired")
+	migrator.LockTimeout = 0
+	owner2, err := migrator.Lock()
+	assert.Nil(t, err, "the expired lock should be taken over")
+	migrator.Unlock(owner2)
+	other.Unlock(owner)
+}
+
+func TestMigrateLockRenew(t *testing.T) {
+	defer unit.Catch()
+	migrator := getTestMigrator()
+	TestMigrateClean(t)
+	migrator.LockExpire = time.Second
+
+	other := getTestMigrator()
+	other.LockTimeout = 0
+	err := migrator.withLock(func() error {
+		// the lock is expired without renewing
+		time.Sleep(2500 * time.Millisecond)
+		_, err := other.Lock()
+		assert.Equal(t, ErrLocked, err, "the lock should be renewed while it is held")
+		return nil
+	})
+	assert.Nil(t, err, "the callback should be succeed")
+
+	owner, err := other.Lock()
+	assert.Nil(t, err, "the lock should be released")
+	assert.Equal(t, ErrLocked, migrator.Renew("unknown"), "the lock held by others should not be renewed")
+	assert.Nil(t, other.Renew(owner), "the lock should be renewed by the owner")
+	other.Unlock(owner)
+}
+
+func TestMigrateClean(t *testing.T) {
+	builder := getTestMigrator().Schema
+	builder.DropTableIfExists("table_test_migrate_users")
+	builder.DropTableIfExists("table_test_migrate_posts")
+	builder.DropTableIfExists("table_test_migrate_tags")
+	builder.DropTableIfExists("table_test_migrations")
+	builder.DropTableIfExists("table_test_migrations_lock")
+}
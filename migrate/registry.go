@@ -0,0 +1,54 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// the global migrations registry
+var registry = map[string]Migration{}
+var registryLock sync.Mutex
+
+// Register register a migration to the global registry, the migrations run in the order of names.
+// Prefix the name with a timestamp to keep the order:
+//
+//	migrate.Register("2021_01_01_000000_create_users_table", &CreateUsersTable{})
+func Register(name string, migration Migration) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, has := registry[name]; has {
+		panic(fmt.Errorf("the migration %s has been registered", name))
+	}
+	registry[name] = migration
+}
+
+// Registered get the migrations of the global registry
+func Registered() map[string]Migration {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	migrations := map[string]Migration{}
+	for name, migration := range registry {
+		migrations[name] = migration
+	}
+	return migrations
+}
+
+// Register register a migration to the migrator
+func (migrator *Migrator) Register(name string, migration Migration) *Migrator {
+	if _, has := migrator.Migrations[name]; has {
+		panic(fmt.Errorf("the migration %s has been registered", name))
+	}
+	migrator.Migrations[name] = migration
+	return migrator
+}
+
+// Names get the sorted names of the registered migrations
+func (migrator *Migrator) Names() []string {
+	names := []string{}
+	for name := range migrator.Migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
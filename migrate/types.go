@@ -0,0 +1,43 @@
+package migrate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/dbal/schema"
+)
+
+// ErrLocked another migrator holds the migration lock
+var ErrLocked = errors.New("the migrations are locked by another process")
+
+// Migration the migration interface
+type Migration interface {
+	Up(schema schema.Schema)
+	Down(schema schema.Schema)
+}
+
+// Migrator the migration runner
+type Migrator struct {
+	Schema      schema.Schema
+	Query       query.Query
+	Table       string        // the migrations bookkeeping table name, default is migrations
+	LockTable   string        // the migrations lock table name, default is migrations_lock
+	LockTimeout time.Duration // how long to wait for the lock held by another process, default is 60 seconds
+	LockExpire  time.Duration // the lock not renewed longer than it is considered stale (the process crashed), default is 1 hour
+	Migrations  map[string]Migration
+}
+
+// Status the status of a migration
+type Status struct {
+	Name  string // the migration name
+	Ran   bool   // the migration has been run
+	Batch int    // the batch number, 0 if the migration has not been run
+}
+
+// Record the row of the migrations bookkeeping table
+type Record struct {
+	ID        int64  `db:"id"`
+	Migration string `db:"migration"`
+	Batch     int    `db:"batch"`
+}
@@ -15,6 +15,13 @@ type T struct {
 	Time interface{}
 }
 
+// Point a geographic point, Lng is the longitude (x) and Lat is the latitude (y).
+// It is written as the WKT text POINT(lng lat), and scanned from WKT, WKB or the MySQL internal format.
+type Point struct {
+	Lng float64 `json:"lng"`
+	Lat float64 `json:"lat"`
+}
+
 // P an Paginator struct, P is the first letter of "Paginator"
 type P struct {
 	Items        []interface{}          `json:"items"`
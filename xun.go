@@ -2,7 +2,10 @@ package xun
 
 import (
 	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -14,6 +17,15 @@ import (
 	"github.com/yaoapp/xun/utils"
 )
 
+// ErrNotFound the query does not match any record
+var ErrNotFound = errors.New("record not found")
+
+// ErrMultipleRecords the query matches more than one record while only one is expected
+var ErrMultipleRecords = errors.New("multiple records found")
+
+// ErrStaleRecord the record has been changed by others since it was read
+var ErrStaleRecord = errors.New("stale record")
+
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
 var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
 
@@ -138,6 +150,133 @@ func (t *T) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MakePoint create a new xun.Point struct
+func MakePoint(lng float64, lat float64) Point {
+	return Point{Lng: lng, Lat: lat}
+}
+
+// ParsePoint cast the value to xun.Point, the value could be the WKT text (POINT(lng lat)),
+// the WKB or EWKB (binary or hex encoded, Postgres) or the MySQL internal format (SRID + WKB).
+func ParsePoint(value interface{}) (Point, error) {
+	var data []byte
+	switch v := value.(type) {
+	case Point:
+		return v, nil
+	case *Point:
+		return *v, nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return Point{}, fmt.Errorf("can't cast %T to the point", value)
+	}
+
+	text := strings.TrimSpace(string(data))
+	upper := strings.ToUpper(text)
+	if strings.HasPrefix(upper, "POINT") || strings.HasPrefix(upper, "SRID=") {
+		return parsePointWKT(text)
+	}
+
+	if decoded, err := hex.DecodeString(text); err == nil && len(decoded) > 0 {
+		data = decoded
+	}
+
+	// the MySQL internal format, the 4 bytes SRID is followed by the WKB
+	if len(data) == 25 && data[4] <= 1 {
+		if point, err := parsePointWKB(data[4:]); err == nil {
+			return point, nil
+		}
+	}
+	return parsePointWKB(data)
+}
+
+// MustParsePoint cast the value to xun.Point, if the value is not a point panic
+func MustParsePoint(value interface{}) Point {
+	point, err := ParsePoint(value)
+	utils.PanicIF(err)
+	return point
+}
+
+// WKT the well-known text of the point, POINT(lng lat)
+func (p Point) WKT() string {
+	return fmt.Sprintf("POINT(%s %s)", strconv.FormatFloat(p.Lng, 'f', -1, 64), strconv.FormatFloat(p.Lat, 'f', -1, 64))
+}
+
+// String the well-known text of the point
+func (p Point) String() string {
+	return p.WKT()
+}
+
+// Scan for db scan
+func (p *Point) Scan(src interface{}) error {
+	point, err := ParsePoint(src)
+	if err != nil {
+		return err
+	}
+	*p = point
+	return nil
+}
+
+// Value for db driver value, the point is written as the WKT text
+func (p Point) Value() (driver.Value, error) {
+	return p.WKT(), nil
+}
+
+// parsePointWKT parse the well-known text, the EWKT SRID prefix (SRID=4326;) is ignored
+func parsePointWKT(text string) (Point, error) {
+	if pos := strings.Index(text, ";"); pos >= 0 {
+		text = text[pos+1:]
+	}
+
+	start := strings.Index(text, "(")
+	end := strings.LastIndex(text, ")")
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(text)), "POINT") || start < 0 || end < start {
+		return Point{}, fmt.Errorf("%s is not a point", text)
+	}
+
+	coordinates := strings.Fields(text[start+1 : end])
+	if len(coordinates) < 2 {
+		return Point{}, fmt.Errorf("%s is not a point", text)
+	}
+
+	lng, err := strconv.ParseFloat(coordinates[0], 64)
+	if err != nil {
+		return Point{}, err
+	}
+	lat, err := strconv.ParseFloat(coordinates[1], 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{Lng: lng, Lat: lat}, nil
+}
+
+// parsePointWKB parse the well-known binary, the EWKB SRID is skipped
+func parsePointWKB(data []byte) (Point, error) {
+	if len(data) < 21 || data[0] > 1 {
+		return Point{}, fmt.Errorf("the data is not a point")
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if data[0] == 1 {
+		order = binary.LittleEndian
+	}
+
+	typ := order.Uint32(data[1:5])
+	offset := 5
+	if typ&0x20000000 != 0 { // EWKB SRID flag
+		offset = offset + 4
+	}
+
+	if (typ&0x0fffffff)%1000 != 1 || len(data) < offset+16 {
+		return Point{}, fmt.Errorf("the data is not a point")
+	}
+
+	lng := math.Float64frombits(order.Uint64(data[offset : offset+8]))
+	lat := math.Float64frombits(order.Uint64(data[offset+8 : offset+16]))
+	return Point{Lng: lng, Lat: lat}, nil
+}
+
 // MakeRow create a new R struct alias MakeR
 func MakeRow(value ...interface{}) R {
 	return MakeR(value...)
@@ -236,6 +375,12 @@ func (row R) GetTime(key interface{}) T {
 	return MakeTime(value)
 }
 
+// GetPoint get the value of the given key, and cast the type to xun.Point
+func (row R) GetPoint(key interface{}) Point {
+	point, _ := ParsePoint(row.Get(key))
+	return point
+}
+
 // GetString get the string value  of the given key
 func (row R) GetString(key interface{}) string {
 	value := row.Get(key)
@@ -424,7 +569,9 @@ func structToR(value interface{}) R {
 			field := reflectValue.Field(i).Interface()
 			if tag != "" && tag != "-" {
 				kind := reflectType.Field(i).Type.Kind()
-				if kind == reflect.Struct {
+				if _, ok := field.(driver.Valuer); ok { // the database values (e.g. xun.Point) are kept
+					r[tag] = field
+				} else if kind == reflect.Struct {
 					r[tag] = structToR(field)
 				} else if kind == reflect.Slice || kind == reflect.Array {
 					r[tag] = MakeRSlice(field)
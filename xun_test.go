@@ -1,6 +1,9 @@
 package xun
 
 import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -95,3 +98,33 @@ func TestMakeRSlice(t *testing.T) {
 		assert.Equal(t, interface{}(4), rows[0].Get("vote"), `rows[0]["vote"] should be 4`)
 	}
 }
+
+func TestParsePoint(t *testing.T) {
+	point := MakePoint(116.397128, 39.916527)
+	assert.Equal(t, "POINT(116.397128 39.916527)", point.WKT(), "the WKT should be POINT(116.397128 39.916527)")
+
+	value, err := point.Value()
+	assert.Nil(t, err, "the value should be returned")
+	assert.Equal(t, point, MustParsePoint(value), "the WKT should be parsed")
+	assert.Equal(t, point, MustParsePoint("SRID=4326;POINT (116.397128 39.916527)"), "the EWKT should be parsed")
+
+	wkb := make([]byte, 21)
+	wkb[0], wkb[1] = 1, 1
+	binary.LittleEndian.PutUint64(wkb[5:], math.Float64bits(point.Lng))
+	binary.LittleEndian.PutUint64(wkb[13:], math.Float64bits(point.Lat))
+	assert.Equal(t, point, MustParsePoint(wkb), "the WKB should be parsed")
+	assert.Equal(t, point, MustParsePoint(append([]byte{0, 0, 0, 0}, wkb...)), "the MySQL internal format should be parsed")
+
+	ewkb := append([]byte{1, 1, 0, 0, 0x20, 0xE6, 0x10, 0, 0}, wkb[5:]...)
+	assert.Equal(t, point, MustParsePoint(hex.EncodeToString(ewkb)), "the hex encoded EWKB should be parsed")
+
+	var scanned Point
+	assert.Nil(t, scanned.Scan([]byte("POINT(1.5 -2)")), "the point should be scanned")
+	assert.Equal(t, MakePoint(1.5, -2), scanned, "the scanned point should be POINT(1.5 -2)")
+	assert.Equal(t, MakePoint(1.5, -2), R{"loc": "POINT(1.5 -2)"}.GetPoint("loc"), "the row value should be cast to the point")
+
+	_, err = ParsePoint("LINESTRING(1 2, 3 4)")
+	assert.NotNil(t, err, "the line string is not a point")
+	_, err = ParsePoint(nil)
+	assert.NotNil(t, err, "the nil is not a point")
+}